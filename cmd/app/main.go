@@ -16,6 +16,7 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/krijebr/printer-shop/internal/config"
 	"github.com/krijebr/printer-shop/internal/delivery/http"
+	"github.com/krijebr/printer-shop/internal/entity"
 	"github.com/krijebr/printer-shop/internal/repo"
 	"github.com/krijebr/printer-shop/internal/usecase"
 	_ "github.com/lib/pq"
@@ -72,25 +73,77 @@ func main() {
 	userRepo := repo.NewUserRepoPg(db)
 	producerRepo := repo.NewProducerRepoPg(db)
 	productRepo := repo.NewProductRepoPg(db)
+	categoryRepo := repo.NewCategoryRepoPg(db)
 	tokenRepo := repo.NewTokenRedis(rdb)
+	guestCartRepo := repo.NewGuestCartRedis(rdb)
 	cartRepo := repo.NewCartRepoPg(db)
 	orderRepo := repo.NewOrderRepoPg(db)
+	orderEventRepo := repo.NewOrderEventRepoPg(db)
+	exchangeRateRepo := repo.NewExchangeRateRepoPg(db)
+	stockRepo := repo.NewStockRepoPg(db)
+	warehouseRepo := repo.NewWarehouseRepoPg(db)
+	wishlistRepo := repo.NewWishlistRepoPg(db)
+	promoCodeRepo := repo.NewPromoCodeRepoPg(db)
+	promotionRepo := repo.NewPromotionRepoPg(db)
+	addressRepo := repo.NewAddressRepoPg(db)
+	shipmentRepo := repo.NewShipmentRepoPg(db)
 
 	producerUseCase := usecase.NewProducer(producerRepo, productRepo)
+	exchangeRateUseCase := usecase.NewExchangeRate(exchangeRateRepo, entity.Currency(cfg.Currency.Base))
+	taxSettings := entity.TaxSettings{
+		PricesIncludeTax: cfg.Tax.PricesIncludeTax,
+		DefaultClass:     cfg.Tax.DefaultClass,
+		Rates:            cfg.Tax.Rates,
+	}
+	shippingMethods := newShippingMethods(&cfg.Shipping, entity.Currency(cfg.Currency.Base))
+	guestCartUseCase := usecase.NewGuestCart(
+		guestCartRepo,
+		cartRepo,
+		productRepo,
+		promotionRepo,
+		exchangeRateUseCase,
+		taxSettings,
+		time.Duration(cfg.GuestCart.TTL))
 	authUseCase := usecase.NewAuth(
 		userRepo,
 		tokenRepo,
+		guestCartUseCase,
 		time.Duration(cfg.Security.TokenTTL),
 		time.Duration(cfg.Security.RefreshTokenTTL),
 		cfg.Security.HashSalt)
 	userUseCase := usecase.NewUser(userRepo, cartRepo, orderRepo, authUseCase)
+	orderUseCase := usecase.NewOrder(
+		orderRepo,
+		cartRepo,
+		productRepo,
+		warehouseRepo,
+		orderEventRepo,
+		promoCodeRepo,
+		promotionRepo,
+		addressRepo,
+		exchangeRateUseCase,
+		taxSettings,
+		shippingMethods,
+		time.Duration(cfg.Reservation.TTL))
+	cartUseCase := usecase.NewCart(cartRepo, productRepo, promoCodeRepo, promotionRepo, exchangeRateUseCase, taxSettings)
 	u := usecase.NewUseCases(
+		usecase.NewAddress(addressRepo),
 		authUseCase,
-		usecase.NewCart(cartRepo, productRepo),
-		usecase.NewOrder(orderRepo, cartRepo, productRepo),
+		cartUseCase,
+		usecase.NewCategory(categoryRepo, productRepo),
+		exchangeRateUseCase,
+		guestCartUseCase,
+		orderUseCase,
 		producerUseCase,
-		usecase.NewProduct(productRepo, producerRepo, cartRepo, orderRepo),
-		userUseCase)
+		usecase.NewProduct(productRepo, producerRepo, categoryRepo, cartRepo, orderRepo, exchangeRateUseCase, taxSettings),
+		usecase.NewPromoCode(promoCodeRepo, productRepo, producerRepo, categoryRepo, exchangeRateUseCase),
+		usecase.NewPromotion(promotionRepo, productRepo, exchangeRateUseCase),
+		usecase.NewShipment(shipmentRepo, orderRepo),
+		usecase.NewShipping(shippingMethods, cartUseCase, addressRepo, exchangeRateUseCase),
+		usecase.NewStock(stockRepo, productRepo, warehouseRepo),
+		userUseCase,
+		usecase.NewWarehouse(warehouseRepo),
+		usecase.NewWishlist(wishlistRepo, productRepo, cartUseCase))
 	r := http.CreateNewEchoServer(u, roleConf, baseUrl)
 
 	slog.Info("starting http server", slog.Int("port", cfg.HttpServer.Port))
@@ -102,6 +155,8 @@ func main() {
 		}
 	}()
 
+	go sweepReservations(ctx, orderUseCase, time.Duration(cfg.Reservation.SweepInterval))
+
 	<-ctx.Done()
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -113,6 +168,30 @@ func main() {
 	}
 }
 
+// sweepReservations periodically cancels unpaid orders which reservation has expired.
+func sweepReservations(ctx context.Context, o usecase.Order, interval time.Duration) {
+	if interval <= 0 {
+		slog.Info("reservation sweeper is disabled")
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released, err := o.ReleaseExpired(ctx)
+			if err != nil {
+				slog.Error("releasing expired reservations error", slog.Any("error", err))
+			}
+			if released > 0 {
+				slog.Info("expired reservations released", slog.Int("orders", released))
+			}
+		}
+	}
+}
+
 func initDB(ctx context.Context, cfg *config.Postgres) (*sql.DB, error) {
 	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable", cfg.Host, cfg.Port, cfg.UserName, cfg.Password, cfg.DBName)
 	var (
@@ -191,6 +270,32 @@ func initRedis(ctx context.Context, cfg *config.Redis) (*redis.Client, error) {
 	return client, nil
 }
 
+// newShippingMethods converts the shipping methods of the config, their prices are in the base currency.
+func newShippingMethods(cfg *config.Shipping, base entity.Currency) []*entity.ShippingMethod {
+	methods := make([]*entity.ShippingMethod, 0, len(cfg.Methods))
+	for _, m := range cfg.Methods {
+		method := &entity.ShippingMethod{
+			Code:       m.Code,
+			Name:       m.Name,
+			Calculator: entity.ShippingCalculatorType(m.Calculator),
+			Currency:   base,
+		}
+		for _, r := range m.Rates {
+			rate := &entity.ShippingRate{
+				Regions:   r.Regions,
+				Price:     r.Price,
+				Threshold: r.Threshold,
+			}
+			for _, w := range r.Weights {
+				rate.Weights = append(rate.Weights, &entity.ShippingWeightPrice{MaxWeight: w.MaxWeight, Price: w.Price})
+			}
+			method.Rates = append(method.Rates, rate)
+		}
+		methods = append(methods, method)
+	}
+	return methods
+}
+
 func getConfigPath(defaultPath string) string {
 	if os.Getenv("CONFIG_PATH") != "" {
 		return os.Getenv("CONFIG_PATH")
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,11 +11,14 @@ import (
 	_ "embed"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
 	"github.com/krijebr/printer-shop/internal/config"
 	"github.com/krijebr/printer-shop/internal/entity"
 	"github.com/krijebr/printer-shop/internal/repo"
@@ -23,18 +27,23 @@ import (
 )
 
 type ActionsCli struct {
-	authUseCase     usecase.Auth
-	userUseCase     usecase.User
-	producerUseCase usecase.Producer
-	productUseCase  usecase.Product
+	authUseCase         usecase.Auth
+	userUseCase         usecase.User
+	producerUseCase     usecase.Producer
+	productUseCase      usecase.Product
+	categoryUseCase     usecase.Category
+	exchangeRateUseCase usecase.ExchangeRate
 }
 
-func NewActionsCli(a usecase.Auth, u usecase.User, p usecase.Producer, pr usecase.Product) *ActionsCli {
+func NewActionsCli(a usecase.Auth, u usecase.User, p usecase.Producer, pr usecase.Product, cat usecase.Category,
+	e usecase.ExchangeRate) *ActionsCli {
 	return &ActionsCli{
-		authUseCase:     a,
-		userUseCase:     u,
-		producerUseCase: p,
-		productUseCase:  pr,
+		authUseCase:         a,
+		userUseCase:         u,
+		producerUseCase:     p,
+		productUseCase:      pr,
+		categoryUseCase:     cat,
+		exchangeRateUseCase: e,
 	}
 }
 
@@ -47,6 +56,9 @@ const (
 //go:embed demo-data.json
 var data []byte
 
+//go:embed demo-categories.json
+var categoriesData []byte
+
 func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
@@ -66,21 +78,31 @@ func main() {
 	userRepo := repo.NewUserRepoPg(db)
 	producerRepo := repo.NewProducerRepoPg(db)
 	productRepo := repo.NewProductRepoPg(db)
+	categoryRepo := repo.NewCategoryRepoPg(db)
 	tokenRepo := repo.NewTokenRedis(nil)
 	cartRepo := repo.NewCartRepoPg(db)
 	orderRepo := repo.NewOrderRepoPg(db)
+	exchangeRateRepo := repo.NewExchangeRateRepoPg(db)
 
 	producerUseCase := usecase.NewProducer(producerRepo, productRepo)
+	exchangeRateUseCase := usecase.NewExchangeRate(exchangeRateRepo, entity.Currency(cfg.Currency.Base))
+	taxSettings := entity.TaxSettings{
+		PricesIncludeTax: cfg.Tax.PricesIncludeTax,
+		DefaultClass:     cfg.Tax.DefaultClass,
+		Rates:            cfg.Tax.Rates,
+	}
 
 	authUseCase := usecase.NewAuth(
 		userRepo,
 		tokenRepo,
+		usecase.NewGuestCart(repo.NewGuestCartRedis(nil), cartRepo, productRepo, repo.NewPromotionRepoPg(db), exchangeRateUseCase, taxSettings, 0),
 		time.Duration(cfg.Security.TokenTTL),
 		time.Duration(cfg.Security.RefreshTokenTTL),
 		cfg.Security.HashSalt)
 	userUseCase := usecase.NewUser(userRepo, cartRepo, orderRepo, authUseCase)
-	productUseCase := usecase.NewProduct(productRepo, producerRepo, cartRepo, orderRepo)
-	actionsCli := NewActionsCli(authUseCase, userUseCase, producerUseCase, productUseCase)
+	productUseCase := usecase.NewProduct(productRepo, producerRepo, categoryRepo, cartRepo, orderRepo, exchangeRateUseCase, taxSettings)
+	categoryUseCase := usecase.NewCategory(categoryRepo, productRepo)
+	actionsCli := NewActionsCli(authUseCase, userUseCase, producerUseCase, productUseCase, categoryUseCase, exchangeRateUseCase)
 
 	app := &cli.App{
 		Name:  "cli",
@@ -96,6 +118,18 @@ func main() {
 				Usage:  "fills the database with demo data",
 				Action: actionsCli.AddDemoData(),
 			},
+			{
+				Name:      "set-exchange-rate",
+				Usage:     "sets the amount of currency given for one unit of the base currency, the currency is an ISO 4217 code",
+				ArgsUsage: "<currency> <rate>",
+				Action:    actionsCli.SetExchangeRate(),
+			},
+			{
+				Name:      "import-compatibility",
+				Usage:     "loads printer and consumable compatibility from a csv file, the first row must be the printer,consumable header followed by product names",
+				ArgsUsage: "<file>",
+				Action:    actionsCli.ImportCompatibility(),
+			},
 		},
 	}
 
@@ -141,7 +175,7 @@ func (a *ActionsCli) CreateAdmin() cli.ActionFunc {
 			Email:        email,
 			PasswordHash: password,
 		}
-		newUser, err := a.authUseCase.Register(c.Context, user)
+		newUser, _, err := a.authUseCase.Register(c.Context, user, nil)
 		if err != nil {
 			switch {
 			case errors.Is(err, usecase.ErrEmailAlreadyExists):
@@ -170,8 +204,20 @@ func (a *ActionsCli) AddDemoData() cli.ActionFunc {
 		Products []entity.Product `json:"products"`
 	}
 	return func(c *cli.Context) error {
+		demoCategories := []*entity.Category{}
+		err := json.Unmarshal(categoriesData, &demoCategories)
+		if err != nil {
+			fmt.Println("categories datafile parsing error")
+			return err
+		}
+		categoryIds := map[string]uuid.UUID{}
+		err = a.addDemoCategories(c.Context, demoCategories, nil, categoryIds)
+		if err != nil {
+			fmt.Println("category creation error")
+			return err
+		}
 		demoData := []Product{}
-		err := json.Unmarshal(data, &demoData)
+		err = json.Unmarshal(data, &demoData)
 		if err != nil {
 			fmt.Println("datafile parsing error")
 			return err
@@ -184,6 +230,9 @@ func (a *ActionsCli) AddDemoData() cli.ActionFunc {
 			}
 			for j := range demoData[i].Products {
 				demoData[i].Products[j].Producer = newProducer
+				if category := demoData[i].Products[j].Category; category != nil {
+					category.Id = categoryIds[category.Name]
+				}
 				_, err = a.productUseCase.Create(c.Context, demoData[i].Products[j])
 				if err != nil {
 					fmt.Println("product creation error")
@@ -196,6 +245,147 @@ func (a *ActionsCli) AddDemoData() cli.ActionFunc {
 	}
 }
 
+func (a *ActionsCli) addDemoCategories(ctx context.Context, categories []*entity.Category, parentId *uuid.UUID,
+	categoryIds map[string]uuid.UUID) error {
+	for _, category := range categories {
+		newCategory, err := a.categoryUseCase.Create(ctx, entity.Category{
+			Name:     category.Name,
+			ParentId: parentId,
+		})
+		if err != nil {
+			return err
+		}
+		categoryIds[newCategory.Name] = newCategory.Id
+		err = a.addDemoCategories(ctx, category.Children, &newCategory.Id, categoryIds)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ActionsCli) SetExchangeRate() cli.ActionFunc {
+	return func(c *cli.Context) error {
+		currency := c.Args().Get(0)
+		validate := validator.New()
+
+		err := validate.Var(currency, "required,len=3,alpha,uppercase")
+		if err != nil {
+			fmt.Println("validation error")
+			return err
+		}
+		rate, err := strconv.ParseFloat(c.Args().Get(1), 64)
+		if err != nil {
+			fmt.Println("validation error")
+			return err
+		}
+		err = validate.Var(rate, "gt=0")
+		if err != nil {
+			fmt.Println("validation error")
+			return err
+		}
+		newRate, err := a.exchangeRateUseCase.Set(c.Context, entity.ExchangeRate{
+			Currency: entity.Currency(currency),
+			Rate:     rate,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrBaseCurrencyRateIsFixed):
+				fmt.Println("exchange rate of the base currency can't be changed")
+				return nil
+			default:
+				fmt.Println("exchange rate setting error")
+				return err
+			}
+		}
+		fmt.Printf("exchange rate set. 1 %s = %g %s\n", a.exchangeRateUseCase.BaseCurrency(), newRate.Rate, newRate.Currency)
+		return nil
+	}
+}
+
+func (a *ActionsCli) ImportCompatibility() cli.ActionFunc {
+	return func(c *cli.Context) error {
+		path := c.Args().Get(0)
+		validate := validator.New()
+
+		err := validate.Var(path, "required,file")
+		if err != nil {
+			fmt.Println("validation error")
+			return err
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			fmt.Println("file opening error")
+			return err
+		}
+		defer file.Close()
+		records, err := csv.NewReader(file).ReadAll()
+		if err != nil {
+			fmt.Println("file parsing error")
+			return err
+		}
+		if len(records) == 0 || len(records[0]) < 2 ||
+			!strings.EqualFold(strings.TrimSpace(records[0][0]), "printer") ||
+			!strings.EqualFold(strings.TrimSpace(records[0][1]), "consumable") {
+			fmt.Println("file parsing error")
+			return errors.New("first row must be the printer,consumable header")
+		}
+		products := map[string][]*entity.Product{}
+		getProducts := func(name string) ([]*entity.Product, error) {
+			if found, ok := products[name]; ok {
+				return found, nil
+			}
+			found, err := a.productUseCase.GetByName(c.Context, name)
+			if err != nil {
+				return nil, err
+			}
+			products[name] = found
+			return found, nil
+		}
+		added := 0
+		notFound := map[string]bool{}
+		for i, record := range records {
+			if i == 0 || len(record) < 2 {
+				continue
+			}
+			printers, err := getProducts(strings.TrimSpace(record[0]))
+			if err != nil {
+				fmt.Println("product receiving error")
+				return err
+			}
+			consumables, err := getProducts(strings.TrimSpace(record[1]))
+			if err != nil {
+				fmt.Println("product receiving error")
+				return err
+			}
+			if len(printers) == 0 {
+				notFound[record[0]] = true
+			}
+			if len(consumables) == 0 {
+				notFound[record[1]] = true
+			}
+			for _, printer := range printers {
+				for _, consumable := range consumables {
+					err = a.productUseCase.AddCompatible(c.Context, printer.Id, consumable.Id)
+					if err != nil {
+						if errors.Is(err, usecase.ErrProductCompatibleWithItself) {
+							continue
+						}
+						fmt.Println("compatibility adding error")
+						return err
+					}
+					added++
+				}
+			}
+		}
+		for name := range notFound {
+			fmt.Printf("product not found: %s\n", name)
+		}
+		fmt.Printf("compatibility imported. Pairs processed: %d\n", added)
+		return nil
+	}
+}
+
 func initDB(ctx context.Context, cfg *config.Postgres) (*sql.DB, error) {
 	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable", cfg.Host, cfg.Port, cfg.UserName, cfg.Password, cfg.DBName)
 	var (
@@ -36,16 +36,73 @@ type (
 		HashSalt        string   `json:"hash_salt"`
 	}
 
+	// Reservation configures how long stock taken by an unpaid order is held
+	// and how often expired reservations are released.
+	Reservation struct {
+		TTL           Duration `json:"ttl"`
+		SweepInterval Duration `json:"sweep_interval"`
+	}
+
+	// GuestCart configures how long a cart of a guest is kept after its last change.
+	GuestCart struct {
+		TTL Duration `json:"ttl"`
+	}
+
 	Logging struct {
 		Level slog.Level `json:"level"`
 	}
 
+	Currency struct {
+		Base string `json:"base"`
+	}
+
+	// Tax configures VAT. Rates are in percent by tax class, products without a class
+	// are taxed at the rate of the default class. Product prices include the tax when
+	// PricesIncludeTax is set, otherwise the tax is added on top of them.
+	Tax struct {
+		PricesIncludeTax bool               `json:"prices_include_tax"`
+		DefaultClass     string             `json:"default_class"`
+		Rates            map[string]float64 `json:"rates"`
+	}
+
+	// Shipping lists the shipping methods. Prices are in minor units of the base currency
+	// and weights in grams.
+	Shipping struct {
+		Methods []ShippingMethod `json:"methods"`
+	}
+
+	// ShippingMethod is priced by the calculator: flat, weight_table or free_over_threshold.
+	// The first rate covering the region of the address is used.
+	ShippingMethod struct {
+		Code       string         `json:"code"`
+		Name       string         `json:"name"`
+		Calculator string         `json:"calculator"`
+		Rates      []ShippingRate `json:"rates"`
+	}
+
+	ShippingRate struct {
+		Regions   []string              `json:"regions"`
+		Price     int64                 `json:"price"`
+		Weights   []ShippingWeightPrice `json:"weights"`
+		Threshold int64                 `json:"threshold"`
+	}
+
+	ShippingWeightPrice struct {
+		MaxWeight int   `json:"max_weight"`
+		Price     int64 `json:"price"`
+	}
+
 	Config struct {
-		Postgres   Postgres   `json:"postgres"`
-		HttpServer HttpServer `json:"http_server"`
-		Redis      Redis      `json:"redis"`
-		Security   Security   `json:"security"`
-		Logging    Logging    `json:"logging"`
+		Postgres    Postgres    `json:"postgres"`
+		HttpServer  HttpServer  `json:"http_server"`
+		Redis       Redis       `json:"redis"`
+		Security    Security    `json:"security"`
+		Reservation Reservation `json:"reservation"`
+		GuestCart   GuestCart   `json:"guest_cart"`
+		Logging     Logging     `json:"logging"`
+		Currency    Currency    `json:"currency"`
+		Tax         Tax         `json:"tax"`
+		Shipping    Shipping    `json:"shipping"`
 	}
 )
 
@@ -26,6 +26,42 @@ const (
 	ErrUserIsUsedCode              = 18
 	ErrUserIsBlockedCode           = 19
 	ErrOrderCantBeDeletedCode      = 20
+	ErrOrderStatusTransitionCode   = 21
+	ErrOrderCantBeCancelledCode    = 22
+	ErrUnsupportedCurrencyCode     = 23
+	ErrExchangeRateNotFoundCode    = 24
+	ErrBaseCurrencyRateIsFixedCode = 25
+	ErrCategoryNotExistCode        = 26
+	ErrParentCategoryNotExistCode  = 27
+	ErrCategoryCycleCode           = 28
+	ErrCategoryIsUsedCode          = 29
+	ErrSelfCompatibilityCode       = 30
+	ErrInvalidCursorCode           = 31
+	ErrOutOfStockCode              = 32
+	ErrWarehouseNotExistCode       = 33
+	ErrWarehouseIsUsedCode         = 34
+	ErrProductNotInCartCode        = 35
+	ErrCartUnavailableCode         = 36
+	ErrInvalidCartTokenCode        = 37
+	ErrWishlistNameExistsCode      = 38
+	ErrProductNotInWishlistCode    = 39
+	ErrPromoCodeNotExistCode       = 40
+	ErrPromoCodeExistsCode         = 41
+	ErrPromoCodeIsUsedCode         = 42
+	ErrPromoCodeNotActiveCode      = 43
+	ErrPromoCodeLimitReachedCode   = 44
+	ErrPromoCodeNotApplicableCode  = 45
+	ErrPromoCodeMinAmountCode      = 46
+	ErrTaxClassNotExistCode        = 47
+	ErrAddressNotExistCode         = 48
+	ErrShippingMethodNotExistCode  = 49
+	ErrShippingNotAvailableCode    = 50
+	ErrOrderCantBeShippedCode      = 51
+	ErrShipmentNotInOrderCode      = 52
+	ErrShipmentExceedsOrderCode    = 53
+	ErrNoWarehouseCode             = 54
+	ErrOrderUnavailableCode        = 55
+	ErrOrderChangedCode            = 56
 
 	ErrInvalidTokenMessage            = "invalid token"
 	ErrInvalidRefreshTokenMessage     = "invalid refresh token"
@@ -47,7 +83,47 @@ const (
 	ErrUserIsUsedMessage              = "this user can't be deleted"
 	ErrUserIsBlockedMessage           = "user is blocked"
 	ErrOrderCantBeDeletedMessage      = "order can't be deleted"
+	ErrOrderStatusTransitionMessage   = "order can't be moved from its current status to the requested one"
+	ErrOrderCantBeCancelledMessage    = "order can't be cancelled"
+	ErrUnsupportedCurrencyMessage     = "unsupported currency"
+	ErrExchangeRateNotFoundMessage    = "exchange rate for this currency is not set"
+	ErrBaseCurrencyRateIsFixedMessage = "exchange rate of the base currency can't be changed"
+	ErrCategoryNotExistMessage        = "category with this id doesn't exist"
+	ErrParentCategoryNotExistMessage  = "parent category with this id doesn't exist"
+	ErrCategoryCycleMessage           = "category can't be moved into its own subcategory"
+	ErrCategoryIsUsedMessage          = "this category has subcategories or products and can't be deleted"
+	ErrSelfCompatibilityMessage       = "product can't be compatible with itself"
+	ErrInvalidCursorMessage           = "invalid or expired cursor"
+	ErrOutOfStockMessage              = "not enough products in stock"
+	ErrWarehouseNotExistMessage       = "warehouse with this id doesn't exist"
+	ErrWarehouseIsUsedMessage         = "this warehouse holds products or order allocations and can't be deleted"
+	ErrProductNotInCartMessage        = "product is not in the cart"
+	ErrCartUnavailableMessage         = "cart has products which are no longer available"
+	ErrInvalidCartTokenMessage        = "invalid cart token"
+	ErrWishlistNameExistsMessage      = "wishlist with this name already exists"
+	ErrProductNotInWishlistMessage    = "product is not in the wishlist"
+	ErrPromoCodeNotExistMessage       = "promo code doesn't exist"
+	ErrPromoCodeExistsMessage         = "promo code with this code already exists"
+	ErrPromoCodeIsUsedMessage         = "this promo code is used by orders and can't be deleted"
+	ErrPromoCodeNotActiveMessage      = "promo code is not active"
+	ErrPromoCodeLimitReachedMessage   = "promo code usage limit is reached"
+	ErrPromoCodeNotApplicableMessage  = "promo code doesn't apply to products in the cart"
+	ErrPromoCodeMinAmountMessage      = "order amount is less than the promo code minimum"
+	ErrTaxClassNotExistMessage        = "tax class with this name doesn't exist"
+	ErrAddressNotExistMessage         = "address doesn't exist"
+	ErrShippingMethodNotExistMessage  = "shipping method doesn't exist"
+	ErrShippingNotAvailableMessage    = "shipping method isn't available for this address or order"
+	ErrOrderCantBeShippedMessage      = "order can't be shipped in its status"
+	ErrShipmentNotInOrderMessage      = "shipped product isn't in the order"
+	ErrShipmentExceedsOrderMessage    = "shipped count exceeds the count left to ship"
+	ErrNoWarehouseMessage             = "there is no warehouse to place the stock in"
+	ErrOrderUnavailableMessage        = "order has products which are no longer available"
+	ErrOrderChangedMessage            = "order was changed meanwhile, get it and try again"
 
 	UserIdContextKey   string = "userId"
 	UserRoleContextKey string = "userRole"
+
+	// The cart of a guest is identified by the token in the header or the cookie.
+	CartTokenHeader string = "X-Cart-Token"
+	CartTokenCookie string = "cart_token"
 )
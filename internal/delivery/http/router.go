@@ -15,11 +15,24 @@ func CreateNewEchoServer(u *usecase.UseCases, r *config.RoleConf, baseUrl string
 	server.GET("health", HealthCheck())
 	g := server.Group(baseUrl)
 	v1.RegisterAuthRoutes(u.Auth, g.Group("auth"))
-	v1.RegisterCartRoutes(u.Cart, g.Group("cart", authMw.Handle))
-	v1.RegisterOrderRoutes(u.Order, g.Group("orders", authMw.Handle))
+	v1.RegisterCartRoutes(u.Cart, u.GuestCart, g.Group("cart", authMw.Handle))
+	v1.RegisterCategoryRoutes(u.Category, g.Group("categories", authMw.Handle))
+	v1.RegisterExchangeRateRoutes(u.ExchangeRate, g.Group("exchange-rates", authMw.Handle))
+	orders := g.Group("orders", authMw.Handle)
+	v1.RegisterOrderRoutes(u.Order, orders)
+	v1.RegisterShipmentRoutes(u.Shipment, orders)
 	v1.RegisterProducerRoutes(u.Producer, g.Group("producers", authMw.Handle))
-	v1.RegisterProductRoutes(u.Product, g.Group("products", authMw.Handle))
-	v1.RegisterProfileRoutes(u.User, g.Group("profile", authMw.Handle))
+	v1.RegisterPromoCodeRoutes(u.PromoCode, g.Group("promo-codes", authMw.Handle))
+	v1.RegisterPromotionRoutes(u.Promotion, g.Group("promotions", authMw.Handle))
+	products := g.Group("products", authMw.Handle)
+	v1.RegisterProductRoutes(u.Product, products)
+	v1.RegisterStockRoutes(u.Stock, products)
+	profile := g.Group("profile", authMw.Handle)
+	v1.RegisterProfileRoutes(u.User, profile)
+	v1.RegisterAddressRoutes(u.Address, profile)
+	v1.RegisterShippingRoutes(u.Shipping, g.Group("shipping", authMw.Handle))
 	v1.RegisterUserRoutes(u.User, g.Group("users", authMw.Handle))
+	v1.RegisterWarehouseRoutes(u.Warehouse, g.Group("warehouses", authMw.Handle))
+	v1.RegisterWishlistRoutes(u.Wishlist, g.Group("wishlists", authMw.Handle))
 	return server
 }
@@ -0,0 +1,255 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	. "github.com/krijebr/printer-shop/internal/delivery/http/common"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+type addressRequest struct {
+	Label      string `json:"label" validate:"max=50"`
+	Recipient  string `json:"recipient" validate:"required,max=100"`
+	Phone      string `json:"phone" validate:"required,max=20"`
+	Country    string `json:"country" validate:"required,max=60"`
+	Region     string `json:"region" validate:"max=100"`
+	City       string `json:"city" validate:"required,max=100"`
+	Street     string `json:"street" validate:"required,max=200"`
+	PostalCode string `json:"postal_code" validate:"required,max=20"`
+	IsDefault  bool   `json:"is_default"`
+}
+
+func (r *addressRequest) toEntity(userId uuid.UUID) entity.Address {
+	return entity.Address{
+		UserId:     userId,
+		Label:      r.Label,
+		Recipient:  r.Recipient,
+		Phone:      r.Phone,
+		Country:    r.Country,
+		Region:     r.Region,
+		City:       r.City,
+		Street:     r.Street,
+		PostalCode: r.PostalCode,
+		IsDefault:  r.IsDefault,
+	}
+}
+
+type AddressHandlers struct {
+	usecase usecase.Address
+}
+
+func NewAddressHandlers(u usecase.Address) *AddressHandlers {
+	return &AddressHandlers{usecase: u}
+}
+
+func (h *AddressHandlers) getAllAddresses() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		addresses, err := h.usecase.GetAll(c.Request().Context(), userId)
+		if err != nil {
+			slog.Error("addresses receiving error", slog.Any("error", err))
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		slog.Info("all addresses received")
+		return c.JSON(http.StatusOK, addresses)
+	}
+}
+
+func (h *AddressHandlers) createAddress() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		var requestData addressRequest
+		err := c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		err = validator.New().Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		newAddress, err := h.usecase.Create(c.Request().Context(), requestData.toEntity(userId))
+		if err != nil {
+			slog.Error("address creation error", slog.Any("error", err))
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		slog.Info("address created")
+		return c.JSON(http.StatusOK, newAddress)
+	}
+}
+
+func (h *AddressHandlers) getAddressById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		addressId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid address id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		address, err := h.usecase.GetById(c.Request().Context(), userId, addressId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrAddressNotFound):
+				slog.Debug("address not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("address receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("address received")
+		return c.JSON(http.StatusOK, address)
+	}
+}
+
+func (h *AddressHandlers) updateAddressById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		addressId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid address id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		var requestData addressRequest
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		err = validator.New().Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		address := requestData.toEntity(userId)
+		address.Id = addressId
+		updatedAddress, err := h.usecase.Update(c.Request().Context(), address)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrAddressNotFound):
+				slog.Debug("address not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("address updating error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("address updated")
+		return c.JSON(http.StatusOK, updatedAddress)
+	}
+}
+
+func (h *AddressHandlers) deleteAddressById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		addressId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid address id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		err = h.usecase.DeleteById(c.Request().Context(), userId, addressId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrAddressNotFound):
+				slog.Debug("address not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("address deleting error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("address deleted")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func RegisterAddressRoutes(u usecase.Address, g *echo.Group) {
+	a := NewAddressHandlers(u)
+	g.GET("/addresses", a.getAllAddresses())
+	g.POST("/addresses", a.createAddress())
+	g.GET("/addresses/:id", a.getAddressById())
+	g.PUT("/addresses/:id", a.updateAddressById())
+	g.DELETE("/addresses/:id", a.deleteAddressById())
+}
@@ -54,7 +54,15 @@ func (a *AuthHandlers) register() echo.HandlerFunc {
 			PasswordHash: requestData.Password,
 		}
 
-		newUser, err := a.usecase.Register(c.Request().Context(), user)
+		cartToken, err := getCartToken(c)
+		if err != nil {
+			slog.Debug("invalid cart token", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidCartTokenCode,
+				Message: ErrInvalidCartTokenMessage,
+			})
+		}
+		newUser, cartMerged, err := a.usecase.Register(c.Request().Context(), user, cartToken)
 		if err != nil {
 			switch {
 			case errors.Is(err, usecase.ErrEmailAlreadyExists):
@@ -71,6 +79,9 @@ func (a *AuthHandlers) register() echo.HandlerFunc {
 				})
 			}
 		}
+		if cartMerged {
+			deleteCartToken(c)
+		}
 		slog.Info("new user registered")
 		return c.JSON(http.StatusOK, newUser)
 	}
@@ -104,7 +115,15 @@ func (a *AuthHandlers) login() echo.HandlerFunc {
 				Message: ErrValidationErrorMessage,
 			})
 		}
-		token, refreshToken, err := a.usecase.Login(c.Request().Context(), requestData.Email, requestData.Password)
+		cartToken, err := getCartToken(c)
+		if err != nil {
+			slog.Debug("invalid cart token", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidCartTokenCode,
+				Message: ErrInvalidCartTokenMessage,
+			})
+		}
+		token, refreshToken, cartMerged, err := a.usecase.Login(c.Request().Context(), requestData.Email, requestData.Password, cartToken)
 		if err != nil {
 			switch {
 			case errors.Is(err, usecase.ErrUserNotFound) || errors.Is(err, usecase.ErrWrongPassword):
@@ -131,6 +150,9 @@ func (a *AuthHandlers) login() echo.HandlerFunc {
 			Token:        token,
 			RefreshToken: refreshToken,
 		}
+		if cartMerged {
+			deleteCartToken(c)
+		}
 		slog.Info("user logged in")
 		return c.JSON(http.StatusOK, responseData)
 	}
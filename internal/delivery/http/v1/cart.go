@@ -8,55 +8,135 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	. "github.com/krijebr/printer-shop/internal/delivery/http/common"
+	"github.com/krijebr/printer-shop/internal/entity"
 	"github.com/krijebr/printer-shop/internal/usecase"
 	"github.com/labstack/echo/v4"
 )
 
+// CartHandlers serve the cart of the user, or of the guest identified by the cart token.
 type CartHandlers struct {
-	usecase usecase.Cart
+	usecase   usecase.Cart
+	guestCart usecase.GuestCart
 }
 
-func NewCartHandlers(u usecase.Cart) *CartHandlers {
+func NewCartHandlers(u usecase.Cart, g usecase.GuestCart) *CartHandlers {
 	return &CartHandlers{
-		usecase: u,
+		usecase:   u,
+		guestCart: g,
 	}
 }
 
+// getCartToken returns the guest cart token from the header or the cookie, nil if there is none.
+func getCartToken(c echo.Context) (*uuid.UUID, error) {
+	value := c.Request().Header.Get(CartTokenHeader)
+	if value == "" {
+		cookie, err := c.Cookie(CartTokenCookie)
+		if err != nil {
+			return nil, nil
+		}
+		value = cookie.Value
+	}
+	token, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	// uuid.Nil stands for a guest without a cart, it's never a cart of its own.
+	if token == uuid.Nil {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// guestCartToken returns the cart token of the guest. A guest without a token gets
+// a new one if create is set, otherwise uuid.Nil which is an empty cart.
+func guestCartToken(c echo.Context, create bool) (uuid.UUID, error) {
+	token, err := getCartToken(c)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if token != nil {
+		return *token, nil
+	}
+	if !create {
+		return uuid.Nil, nil
+	}
+	newToken := uuid.New()
+	c.Response().Header().Set(CartTokenHeader, newToken.String())
+	c.SetCookie(&http.Cookie{
+		Name:     CartTokenCookie,
+		Value:    newToken.String(),
+		Path:     "/",
+		HttpOnly: true,
+	})
+	return newToken, nil
+}
+
+// deleteCartToken removes the cookie of the guest cart which was merged into the user's cart.
+func deleteCartToken(c echo.Context) {
+	c.SetCookie(&http.Cookie{
+		Name:   CartTokenCookie,
+		Path:   "/",
+		MaxAge: -1,
+	})
+}
+
 func (h *CartHandlers) getAllProductsInCart() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
-		if !ok {
-			return c.JSON(http.StatusInternalServerError, ErrResponse{
-				Error:   ErrInternalErrorCode,
-				Message: ErrInternalErrorMessage,
+		userId, isUser := c.Get(UserIdContextKey).(uuid.UUID)
+		currency := entity.Currency(c.QueryParam("currency"))
+		err := validator.New().Var(currency, "omitempty,len=3,alpha,uppercase")
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
 			})
 		}
-		productsInCart, err := h.usecase.GetAllProducts(c.Request().Context(), userId)
+		var cartToken uuid.UUID
+		if !isUser {
+			cartToken, err = guestCartToken(c, false)
+			if err != nil {
+				slog.Debug("invalid cart token", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrInvalidCartTokenCode,
+					Message: ErrInvalidCartTokenMessage,
+				})
+			}
+		}
+		var summary *entity.CartSummary
+		if isUser {
+			summary, err = h.usecase.GetSummary(c.Request().Context(), userId, currency)
+		} else {
+			summary, err = h.guestCart.GetSummary(c.Request().Context(), cartToken, currency)
+		}
 		if err != nil {
-			slog.Error("cart receiving error", slog.Any("error", err))
-			return c.JSON(http.StatusInternalServerError, ErrResponse{
-				Error:   ErrInternalErrorCode,
-				Message: ErrInternalErrorMessage,
-			})
+			switch {
+			case errors.Is(err, usecase.ErrExchangeRateNotFound):
+				slog.Debug("exchange rate not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrExchangeRateNotFoundCode,
+					Message: ErrExchangeRateNotFoundMessage,
+				})
+			default:
+				slog.Error("cart receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
 		}
 		slog.Info("cart received")
-		return c.JSON(http.StatusOK, productsInCart)
+		return c.JSON(http.StatusOK, summary)
 	}
 }
 
 func (h *CartHandlers) addProductToCart() echo.HandlerFunc {
 	type request struct {
 		ProductId uuid.UUID `json:"product_id" validate:"required,uuid"`
-		Count     int       `json:"count" validate:"min=0"`
+		Count     int       `json:"count" validate:"required,min=1"`
 	}
 	return func(c echo.Context) error {
-		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
-		if !ok {
-			return c.JSON(http.StatusInternalServerError, ErrResponse{
-				Error:   ErrInternalErrorCode,
-				Message: ErrInternalErrorMessage,
-			})
-		}
+		userId, isUser := c.Get(UserIdContextKey).(uuid.UUID)
 		var requestData request
 		err := c.Bind(&requestData)
 		if err != nil {
@@ -75,7 +155,22 @@ func (h *CartHandlers) addProductToCart() echo.HandlerFunc {
 				Message: ErrValidationErrorMessage,
 			})
 		}
-		err = h.usecase.AddProduct(c.Request().Context(), userId, requestData.ProductId, requestData.Count)
+		var cartToken uuid.UUID
+		if !isUser {
+			cartToken, err = guestCartToken(c, true)
+			if err != nil {
+				slog.Debug("invalid cart token", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrInvalidCartTokenCode,
+					Message: ErrInvalidCartTokenMessage,
+				})
+			}
+		}
+		if isUser {
+			err = h.usecase.AddProduct(c.Request().Context(), userId, requestData.ProductId, requestData.Count)
+		} else {
+			err = h.guestCart.AddProduct(c.Request().Context(), cartToken, requestData.ProductId, requestData.Count)
+		}
 		if err != nil {
 			switch {
 			case errors.Is(err, usecase.ErrProductNotFound):
@@ -84,6 +179,12 @@ func (h *CartHandlers) addProductToCart() echo.HandlerFunc {
 					Error:   ErrProductNotExistCode,
 					Message: ErrProductNotExistMessage,
 				})
+			case errors.Is(err, usecase.ErrOutOfStock):
+				slog.Debug("not enough stock", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrOutOfStockCode,
+					Message: ErrOutOfStockMessage,
+				})
 			case errors.Is(err, usecase.ErrProductIsHidden):
 				slog.Debug("product is hidden", slog.Any("error", err))
 				return c.JSON(http.StatusBadRequest, ErrResponse{
@@ -103,8 +204,287 @@ func (h *CartHandlers) addProductToCart() echo.HandlerFunc {
 	}
 }
 
-func RegisterCartRoutes(u usecase.Cart, g *echo.Group) {
-	a := NewCartHandlers(u)
+func (h *CartHandlers) updateProductCount() echo.HandlerFunc {
+	type request struct {
+		Count int `json:"count" validate:"min=0"`
+	}
+	return func(c echo.Context) error {
+		userId, isUser := c.Get(UserIdContextKey).(uuid.UUID)
+		productId, err := uuid.Parse(c.Param("productId"))
+		if err != nil {
+			slog.Debug("invalid product id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		var requestData request
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		validate := validator.New()
+		err = validate.Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		var cartToken uuid.UUID
+		if !isUser {
+			cartToken, err = guestCartToken(c, true)
+			if err != nil {
+				slog.Debug("invalid cart token", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrInvalidCartTokenCode,
+					Message: ErrInvalidCartTokenMessage,
+				})
+			}
+		}
+		if isUser {
+			err = h.usecase.UpdateCount(c.Request().Context(), userId, productId, requestData.Count)
+		} else {
+			err = h.guestCart.UpdateCount(c.Request().Context(), cartToken, productId, requestData.Count)
+		}
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrProductNotFound), errors.Is(err, usecase.ErrProductIsHidden):
+				slog.Debug("product not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrProductNotInCart):
+				slog.Debug("product is not in cart", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrProductNotInCartCode,
+					Message: ErrProductNotInCartMessage,
+				})
+			case errors.Is(err, usecase.ErrOutOfStock):
+				slog.Debug("not enough stock", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrOutOfStockCode,
+					Message: ErrOutOfStockMessage,
+				})
+			default:
+				slog.Error("updating product count in cart error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("product count in cart updated")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func (h *CartHandlers) deleteProductFromCart() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, isUser := c.Get(UserIdContextKey).(uuid.UUID)
+		productId, err := uuid.Parse(c.Param("productId"))
+		if err != nil {
+			slog.Debug("invalid product id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		var cartToken uuid.UUID
+		if !isUser {
+			cartToken, err = guestCartToken(c, false)
+			if err != nil {
+				slog.Debug("invalid cart token", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrInvalidCartTokenCode,
+					Message: ErrInvalidCartTokenMessage,
+				})
+			}
+		}
+		if isUser {
+			err = h.usecase.DeleteProduct(c.Request().Context(), userId, productId)
+		} else {
+			err = h.guestCart.DeleteProduct(c.Request().Context(), cartToken, productId)
+		}
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrProductNotInCart):
+				slog.Debug("product is not in cart", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrProductNotInCartCode,
+					Message: ErrProductNotInCartMessage,
+				})
+			default:
+				slog.Error("deleting product from cart error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("product deleted from cart")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func (h *CartHandlers) clearCart() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, isUser := c.Get(UserIdContextKey).(uuid.UUID)
+		var err error
+		var cartToken uuid.UUID
+		if !isUser {
+			cartToken, err = guestCartToken(c, false)
+			if err != nil {
+				slog.Debug("invalid cart token", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrInvalidCartTokenCode,
+					Message: ErrInvalidCartTokenMessage,
+				})
+			}
+		}
+		if isUser {
+			err = h.usecase.Clear(c.Request().Context(), userId)
+		} else {
+			err = h.guestCart.Clear(c.Request().Context(), cartToken)
+		}
+		if err != nil {
+			slog.Error("cart clearing error", slog.Any("error", err))
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		slog.Info("cart cleared")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// promoCodeErrResponse explains why the promo code can't be applied to the cart.
+func promoCodeErrResponse(err error) ErrResponse {
+	switch {
+	case errors.Is(err, usecase.ErrPromoCodeNotActive):
+		return ErrResponse{Error: ErrPromoCodeNotActiveCode, Message: ErrPromoCodeNotActiveMessage}
+	case errors.Is(err, usecase.ErrPromoCodeUsageLimitReached):
+		return ErrResponse{Error: ErrPromoCodeLimitReachedCode, Message: ErrPromoCodeLimitReachedMessage}
+	case errors.Is(err, usecase.ErrPromoCodeMinOrderAmount):
+		return ErrResponse{Error: ErrPromoCodeMinAmountCode, Message: ErrPromoCodeMinAmountMessage}
+	default:
+		return ErrResponse{Error: ErrPromoCodeNotApplicableCode, Message: ErrPromoCodeNotApplicableMessage}
+	}
+}
+
+func (h *CartHandlers) applyPromoCode() echo.HandlerFunc {
+	type request struct {
+		Code string `json:"code" validate:"required,max=32"`
+	}
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		currency := entity.Currency(c.QueryParam("currency"))
+		err := validator.New().Var(currency, "omitempty,len=3,alpha,uppercase")
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		var requestData request
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		validate := validator.New()
+		err = validate.Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		summary, err := h.usecase.ApplyPromoCode(c.Request().Context(), userId, requestData.Code, currency)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrPromoCodeNotFound):
+				slog.Debug("promo code not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrPromoCodeNotExistCode,
+					Message: ErrPromoCodeNotExistMessage,
+				})
+			case errors.Is(err, usecase.ErrCartIsEmpty):
+				slog.Debug("cart is empty", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrCartIsEmptyCode,
+					Message: ErrCartIsEmptyMessage,
+				})
+			case errors.Is(err, usecase.ErrExchangeRateNotFound):
+				slog.Debug("exchange rate not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrExchangeRateNotFoundCode,
+					Message: ErrExchangeRateNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrPromoCodeNotApplicable) || errors.Is(err, usecase.ErrPromoCodeMinOrderAmount) ||
+				errors.Is(err, usecase.ErrPromoCodeNotActive) || errors.Is(err, usecase.ErrPromoCodeUsageLimitReached):
+				slog.Debug("promo code can't be applied", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, promoCodeErrResponse(err))
+			default:
+				slog.Error("promo code applying error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("promo code applied")
+		return c.JSON(http.StatusOK, summary)
+	}
+}
+
+func (h *CartHandlers) removePromoCode() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		err := h.usecase.RemovePromoCode(c.Request().Context(), userId)
+		if err != nil {
+			slog.Error("promo code removing error", slog.Any("error", err))
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		slog.Info("promo code removed")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func RegisterCartRoutes(u usecase.Cart, guestCart usecase.GuestCart, g *echo.Group) {
+	a := NewCartHandlers(u, guestCart)
 	g.GET("", a.getAllProductsInCart())
 	g.POST("", a.addProductToCart())
+	g.DELETE("", a.clearCart())
+	g.POST("/promo", a.applyPromoCode())
+	g.DELETE("/promo", a.removePromoCode())
+	g.PUT("/:productId", a.updateProductCount())
+	g.DELETE("/:productId", a.deleteProductFromCart())
 }
@@ -0,0 +1,364 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/krijebr/printer-shop/internal/delivery/http/common"
+	"github.com/krijebr/printer-shop/internal/usecase"
+	mock_usecase "github.com/krijebr/printer-shop/internal/usecase/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+var cartUserId = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+var cartProductId = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+func newCartTestServer(h *CartHandlers) *echo.Echo {
+	r := echo.New()
+	g := r.Group("/cart", func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(UserIdContextKey, cartUserId)
+			return next(c)
+		}
+	})
+	g.POST("", h.addProductToCart())
+	g.DELETE("", h.clearCart())
+	g.PUT("/:productId", h.updateProductCount())
+	g.DELETE("/:productId", h.deleteProductFromCart())
+	return r
+}
+
+func TestCartHandlers_addProductToCart(t *testing.T) {
+	type mockBehavior func(s *mock_usecase.MockCart, ctx context.Context)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"product_id":"00000000-0000-0000-0000-000000000002","count":2}`,
+			mockBehavior: func(s *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().AddProduct(ctx, cartUserId, cartProductId, 2).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: ``,
+		},
+		{
+			name:                 "zero count",
+			inputBody:            `{"product_id":"00000000-0000-0000-0000-000000000002","count":0}`,
+			mockBehavior:         func(s *mock_usecase.MockCart, ctx context.Context) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":8,"message":"validation error"}`,
+		},
+		{
+			name:      "not enough stock",
+			inputBody: `{"product_id":"00000000-0000-0000-0000-000000000002","count":5}`,
+			mockBehavior: func(s *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().AddProduct(ctx, cartUserId, cartProductId, 5).Return(usecase.ErrOutOfStock)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":32,"message":"not enough products in stock"}`,
+		},
+		{
+			name:      "some internal error",
+			inputBody: `{"product_id":"00000000-0000-0000-0000-000000000002","count":1}`,
+			mockBehavior: func(s *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().AddProduct(ctx, cartUserId, cartProductId, 1).Return(someErr)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":4,"message":"internal error"}`,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			cart := mock_usecase.NewMockCart(c)
+			testCase.mockBehavior(cart, context.Background())
+
+			r := newCartTestServer(NewCartHandlers(cart, mock_usecase.NewMockGuestCart(c)))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/cart", bytes.NewBufferString(testCase.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestCartHandlers_updateProductCount(t *testing.T) {
+	type mockBehavior func(s *mock_usecase.MockCart, ctx context.Context)
+
+	testTable := []struct {
+		name                 string
+		inputProductId       string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:           "OK",
+			inputProductId: cartProductId.String(),
+			inputBody:      `{"count":3}`,
+			mockBehavior: func(s *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().UpdateCount(ctx, cartUserId, cartProductId, 3).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: ``,
+		},
+		{
+			name:           "OK zero count removes product",
+			inputProductId: cartProductId.String(),
+			inputBody:      `{"count":0}`,
+			mockBehavior: func(s *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().UpdateCount(ctx, cartUserId, cartProductId, 0).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: ``,
+		},
+		{
+			name:                 "negative count",
+			inputProductId:       cartProductId.String(),
+			inputBody:            `{"count":-1}`,
+			mockBehavior:         func(s *mock_usecase.MockCart, ctx context.Context) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":8,"message":"validation error"}`,
+		},
+		{
+			name:                 "invalid product id",
+			inputProductId:       "123",
+			inputBody:            `{"count":3}`,
+			mockBehavior:         func(s *mock_usecase.MockCart, ctx context.Context) {},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":3,"message":"resource not found"}`,
+		},
+		{
+			name:           "product is not in cart",
+			inputProductId: cartProductId.String(),
+			inputBody:      `{"count":0}`,
+			mockBehavior: func(s *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().UpdateCount(ctx, cartUserId, cartProductId, 0).Return(usecase.ErrProductNotInCart)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":35,"message":"product is not in the cart"}`,
+		},
+		{
+			name:           "not enough stock",
+			inputProductId: cartProductId.String(),
+			inputBody:      `{"count":30}`,
+			mockBehavior: func(s *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().UpdateCount(ctx, cartUserId, cartProductId, 30).Return(usecase.ErrOutOfStock)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":32,"message":"not enough products in stock"}`,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			cart := mock_usecase.NewMockCart(c)
+			testCase.mockBehavior(cart, context.Background())
+
+			r := newCartTestServer(NewCartHandlers(cart, mock_usecase.NewMockGuestCart(c)))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/cart/"+testCase.inputProductId, bytes.NewBufferString(testCase.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestCartHandlers_deleteProductFromCart(t *testing.T) {
+	type mockBehavior func(s *mock_usecase.MockCart, ctx context.Context)
+
+	testTable := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(s *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().DeleteProduct(ctx, cartUserId, cartProductId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: ``,
+		},
+		{
+			name: "product is not in cart",
+			mockBehavior: func(s *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().DeleteProduct(ctx, cartUserId, cartProductId).Return(usecase.ErrProductNotInCart)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":35,"message":"product is not in the cart"}`,
+		},
+		{
+			name: "some internal error",
+			mockBehavior: func(s *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().DeleteProduct(ctx, cartUserId, cartProductId).Return(someErr)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":4,"message":"internal error"}`,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			cart := mock_usecase.NewMockCart(c)
+			testCase.mockBehavior(cart, context.Background())
+
+			r := newCartTestServer(NewCartHandlers(cart, mock_usecase.NewMockGuestCart(c)))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/cart/"+cartProductId.String(), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestCartHandlers_clearCart(t *testing.T) {
+	type mockBehavior func(s *mock_usecase.MockCart, ctx context.Context)
+
+	testTable := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(s *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().Clear(ctx, cartUserId).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: ``,
+		},
+		{
+			name: "some internal error",
+			mockBehavior: func(s *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().Clear(ctx, cartUserId).Return(someErr)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":4,"message":"internal error"}`,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			cart := mock_usecase.NewMockCart(c)
+			testCase.mockBehavior(cart, context.Background())
+
+			r := newCartTestServer(NewCartHandlers(cart, mock_usecase.NewMockGuestCart(c)))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/cart", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestCartHandlers_addProductToGuestCart(t *testing.T) {
+	type mockBehavior func(s *mock_usecase.MockGuestCart, ctx context.Context)
+
+	cartToken := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	testTable := []struct {
+		name                 string
+		inputCartToken       string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedNewToken     bool
+	}{
+		{
+			name:           "OK existing cart",
+			inputCartToken: cartToken.String(),
+			mockBehavior: func(s *mock_usecase.MockGuestCart, ctx context.Context) {
+				s.EXPECT().AddProduct(ctx, cartToken, cartProductId, 2).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: ``,
+			expectedNewToken:     false,
+		},
+		{
+			name:           "OK new cart",
+			inputCartToken: "",
+			mockBehavior: func(s *mock_usecase.MockGuestCart, ctx context.Context) {
+				s.EXPECT().AddProduct(ctx, gomock.Any(), cartProductId, 2).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: ``,
+			expectedNewToken:     true,
+		},
+		{
+			name:                 "invalid cart token",
+			inputCartToken:       "not a token",
+			mockBehavior:         func(s *mock_usecase.MockGuestCart, ctx context.Context) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":37,"message":"invalid cart token"}`,
+			expectedNewToken:     false,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			guestCart := mock_usecase.NewMockGuestCart(c)
+			testCase.mockBehavior(guestCart, context.Background())
+
+			h := NewCartHandlers(mock_usecase.NewMockCart(c), guestCart)
+			r := echo.New()
+			r.POST("/cart", h.addProductToCart())
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/cart", bytes.NewBufferString(`{"product_id":"00000000-0000-0000-0000-000000000002","count":2}`))
+			req.Header.Set("Content-Type", "application/json")
+			if testCase.inputCartToken != "" {
+				req.Header.Set(CartTokenHeader, testCase.inputCartToken)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, strings.TrimRight(w.Body.String(), "\n"))
+			assert.Equal(t, testCase.expectedNewToken, w.Header().Get(CartTokenHeader) != "")
+		})
+	}
+}
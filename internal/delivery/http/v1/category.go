@@ -0,0 +1,253 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	. "github.com/krijebr/printer-shop/internal/delivery/http/common"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+type CategoryHandlers struct {
+	usecase usecase.Category
+}
+
+func NewCategoryHandlers(u usecase.Category) *CategoryHandlers {
+	return &CategoryHandlers{usecase: u}
+}
+
+func (h *CategoryHandlers) getAllCategories() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		categories, err := h.usecase.GetAll(c.Request().Context())
+		if err != nil {
+			slog.Error("categories receiving error", slog.Any("error", err))
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		slog.Info("all categories received")
+		return c.JSON(http.StatusOK, categories)
+	}
+}
+
+func (h *CategoryHandlers) getCategoryTree() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		categories, err := h.usecase.GetTree(c.Request().Context())
+		if err != nil {
+			slog.Error("category tree receiving error", slog.Any("error", err))
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		slog.Info("category tree received")
+		return c.JSON(http.StatusOK, categories)
+	}
+}
+
+func (h *CategoryHandlers) createCategory() echo.HandlerFunc {
+	type request struct {
+		Name     string     `json:"name" validate:"required,max=50,min=2"`
+		ParentId *uuid.UUID `json:"parent_id"`
+	}
+	return func(c echo.Context) error {
+		var requestData request
+		err := c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		validate := validator.New()
+		err = validate.Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		category := entity.Category{
+			Name:     requestData.Name,
+			ParentId: requestData.ParentId,
+		}
+		newCategory, err := h.usecase.Create(c.Request().Context(), category)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrParentCategoryNotFound):
+				slog.Debug("parent category not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrParentCategoryNotExistCode,
+					Message: ErrParentCategoryNotExistMessage,
+				})
+			default:
+				slog.Error("category creation error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("category created")
+		return c.JSON(http.StatusOK, newCategory)
+	}
+}
+
+func (h *CategoryHandlers) getCategoryById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		categoryId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid category id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		category, err := h.usecase.GetById(c.Request().Context(), categoryId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrCategoryNotFound):
+				slog.Debug("category not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("category receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("category received")
+		return c.JSON(http.StatusOK, category)
+	}
+}
+
+func (h *CategoryHandlers) updateCategoryById() echo.HandlerFunc {
+	type request struct {
+		Name     string     `json:"name" validate:"required,max=50,min=2"`
+		ParentId *uuid.UUID `json:"parent_id"`
+	}
+	return func(c echo.Context) error {
+		categoryId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid category id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		var requestData request
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		validate := validator.New()
+		err = validate.Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		category := entity.Category{
+			Id:       categoryId,
+			Name:     requestData.Name,
+			ParentId: requestData.ParentId,
+		}
+		updatedCategory, err := h.usecase.Update(c.Request().Context(), category)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrCategoryNotFound):
+				slog.Debug("category not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrParentCategoryNotFound):
+				slog.Debug("parent category not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrParentCategoryNotExistCode,
+					Message: ErrParentCategoryNotExistMessage,
+				})
+			case errors.Is(err, usecase.ErrCategoryCycle):
+				slog.Debug("category cycle", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrCategoryCycleCode,
+					Message: ErrCategoryCycleMessage,
+				})
+			default:
+				slog.Error("category updating error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("category updated")
+		return c.JSON(http.StatusOK, updatedCategory)
+	}
+}
+
+func (h *CategoryHandlers) deleteCategoryById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		categoryId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid category id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		err = h.usecase.DeleteById(c.Request().Context(), categoryId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrCategoryNotFound):
+				slog.Debug("category not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrCategoryIsUsed):
+				slog.Debug("category is used", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrCategoryIsUsedCode,
+					Message: ErrCategoryIsUsedMessage,
+				})
+			default:
+				slog.Error("category delete error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("category deleted")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func RegisterCategoryRoutes(u usecase.Category, g *echo.Group) {
+	h := NewCategoryHandlers(u)
+	g.GET("", h.getAllCategories())
+	g.POST("", h.createCategory())
+	g.GET("/tree", h.getCategoryTree())
+	g.GET("/:id", h.getCategoryById())
+	g.PUT("/:id", h.updateCategoryById())
+	g.DELETE("/:id", h.deleteCategoryById())
+}
@@ -0,0 +1,158 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	. "github.com/krijebr/printer-shop/internal/delivery/http/common"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+type ExchangeRateHandlers struct {
+	usecase usecase.ExchangeRate
+}
+
+func NewExchangeRateHandlers(u usecase.ExchangeRate) *ExchangeRateHandlers {
+	return &ExchangeRateHandlers{usecase: u}
+}
+
+func (e *ExchangeRateHandlers) getAllExchangeRates() echo.HandlerFunc {
+	type response struct {
+		Base  entity.Currency        `json:"base"`
+		Rates []*entity.ExchangeRate `json:"rates"`
+	}
+	return func(c echo.Context) error {
+		rates, err := e.usecase.GetAll(c.Request().Context())
+		if err != nil {
+			slog.Error("exchange rates receiving error", slog.Any("error", err))
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		slog.Info("all exchange rates received")
+		return c.JSON(http.StatusOK, response{
+			Base:  e.usecase.BaseCurrency(),
+			Rates: rates,
+		})
+	}
+}
+
+func (e *ExchangeRateHandlers) getExchangeRateByCurrency() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currency := entity.Currency(c.Param("currency"))
+		rate, err := e.usecase.GetByCurrency(c.Request().Context(), currency)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrExchangeRateNotFound):
+				slog.Debug("exchange rate not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("exchange rate receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("exchange rate received")
+		return c.JSON(http.StatusOK, rate)
+	}
+}
+
+func (e *ExchangeRateHandlers) setExchangeRate() echo.HandlerFunc {
+	type request struct {
+		Rate float64 `json:"rate" validate:"required,gt=0"`
+	}
+	return func(c echo.Context) error {
+		currency := entity.Currency(c.Param("currency"))
+		var requestData request
+		err := c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		validate := validator.New()
+		err = validate.Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		err = validate.Var(currency, "len=3,alpha,uppercase")
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrUnsupportedCurrencyCode,
+				Message: ErrUnsupportedCurrencyMessage,
+			})
+		}
+		rate, err := e.usecase.Set(c.Request().Context(), entity.ExchangeRate{
+			Currency: currency,
+			Rate:     requestData.Rate,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrBaseCurrencyRateIsFixed):
+				slog.Debug("base currency rate can't be changed", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrBaseCurrencyRateIsFixedCode,
+					Message: ErrBaseCurrencyRateIsFixedMessage,
+				})
+			default:
+				slog.Error("exchange rate setting error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("exchange rate set")
+		return c.JSON(http.StatusOK, rate)
+	}
+}
+
+func (e *ExchangeRateHandlers) deleteExchangeRate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currency := entity.Currency(c.Param("currency"))
+		err := e.usecase.DeleteByCurrency(c.Request().Context(), currency)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrExchangeRateNotFound):
+				slog.Debug("exchange rate not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("exchange rate delete error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("exchange rate deleted")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func RegisterExchangeRateRoutes(u usecase.ExchangeRate, g *echo.Group) {
+	e := NewExchangeRateHandlers(u)
+	g.GET("", e.getAllExchangeRates())
+	g.GET("/:currency", e.getExchangeRateByCurrency())
+	g.PUT("/:currency", e.setExchangeRate())
+	g.DELETE("/:currency", e.deleteExchangeRate())
+}
@@ -71,7 +71,7 @@ func (o *OrderHandlers) getAllOrders() echo.HandlerFunc {
 
 		if c.QueryParam("order_status") != "" {
 			validate := validator.New()
-			err := validate.Var(c.QueryParam("order_status"), "oneof=new in_progress done")
+			err := validate.Var(c.QueryParam("order_status"), "oneof=new paid in_progress shipped delivered done cancelled refunded")
 			if err != nil {
 				slog.Debug("validation error", slog.Any("error", err))
 				return c.JSON(http.StatusBadRequest, ErrResponse{
@@ -83,20 +83,42 @@ func (o *OrderHandlers) getAllOrders() echo.HandlerFunc {
 			filter.Status = &orderStatus
 		}
 
-		orders, err := o.usecase.GetAll(c.Request().Context(), filter)
+		pagination, err := parsePagination(c, "created_at price")
 		if err != nil {
-			slog.Error("orders receiving error", slog.Any("error", err))
-			return c.JSON(http.StatusInternalServerError, ErrResponse{
-				Error:   ErrInternalErrorCode,
-				Message: ErrInternalErrorMessage,
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
 			})
 		}
+		orders, err := o.usecase.GetAll(c.Request().Context(), filter, pagination)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrInvalidCursor):
+				slog.Debug("invalid cursor", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrInvalidCursorCode,
+					Message: ErrInvalidCursorMessage,
+				})
+			default:
+				slog.Error("orders receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
 		slog.Info("all orders received")
 		return c.JSON(http.StatusOK, orders)
 	}
 }
 
 func (o *OrderHandlers) createOrder() echo.HandlerFunc {
+	type request struct {
+		ShippingAddressId uuid.UUID  `json:"shipping_address_id" validate:"required"`
+		BillingAddressId  *uuid.UUID `json:"billing_address_id"`
+		ShippingMethod    string     `json:"shipping_method" validate:"required,max=50"`
+	}
 	return func(c echo.Context) error {
 		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
 		if !ok {
@@ -105,15 +127,82 @@ func (o *OrderHandlers) createOrder() echo.HandlerFunc {
 				Message: ErrInternalErrorMessage,
 			})
 		}
-		order, err := o.usecase.Create(c.Request().Context(), userId)
+		currency := entity.Currency(c.QueryParam("currency"))
+		err := validator.New().Var(currency, "omitempty,len=3,alpha,uppercase")
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		var requestData request
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		err = validator.New().Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		order, err := o.usecase.Create(c.Request().Context(), userId, currency, requestData.ShippingAddressId, requestData.BillingAddressId,
+			requestData.ShippingMethod)
 		if err != nil {
 			switch {
+			case errors.Is(err, usecase.ErrAddressNotFound):
+				slog.Debug("address not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrAddressNotExistCode,
+					Message: ErrAddressNotExistMessage,
+				})
+			case errors.Is(err, usecase.ErrShippingMethodNotFound):
+				slog.Debug("shipping method not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrShippingMethodNotExistCode,
+					Message: ErrShippingMethodNotExistMessage,
+				})
+			case errors.Is(err, usecase.ErrShippingNotAvailable):
+				slog.Debug("shipping method not available", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrShippingNotAvailableCode,
+					Message: ErrShippingNotAvailableMessage,
+				})
+			case errors.Is(err, usecase.ErrExchangeRateNotFound):
+				slog.Debug("exchange rate not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrExchangeRateNotFoundCode,
+					Message: ErrExchangeRateNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrOutOfStock):
+				slog.Debug("not enough stock", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrOutOfStockCode,
+					Message: ErrOutOfStockMessage,
+				})
 			case errors.Is(err, usecase.ErrCartIsEmpty):
 				slog.Debug("producer not found", slog.Any("error", err))
 				return c.JSON(http.StatusBadRequest, ErrResponse{
 					Error:   ErrCartIsEmptyCode,
 					Message: ErrCartIsEmptyMessage,
 				})
+			case errors.Is(err, usecase.ErrCartHasUnavailableProducts):
+				slog.Debug("cart has unavailable products", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrCartUnavailableCode,
+					Message: ErrCartUnavailableMessage,
+				})
+			case errors.Is(err, usecase.ErrPromoCodeNotApplicable) || errors.Is(err, usecase.ErrPromoCodeMinOrderAmount) ||
+				errors.Is(err, usecase.ErrPromoCodeNotActive) || errors.Is(err, usecase.ErrPromoCodeUsageLimitReached):
+				slog.Debug("promo code can't be applied", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, promoCodeErrResponse(err))
 			default:
 				slog.Error("order creation error", slog.Any("error", err))
 				return c.JSON(http.StatusInternalServerError, ErrResponse{
@@ -186,6 +275,77 @@ func (o *OrderHandlers) getOrderById() echo.HandlerFunc {
 	}
 }
 
+func (o *OrderHandlers) getOrderHistory() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		orderId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid order id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		order, err := o.usecase.GetById(c.Request().Context(), orderId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrOrderNotFound):
+				slog.Debug("order not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("order receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		userRole, ok := c.Get(UserRoleContextKey).(entity.UserRole)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		if userRole != entity.UserRoleAdmin {
+			userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+			if !ok {
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+			if userId != order.UserId {
+				return c.JSON(http.StatusForbidden, ErrResponse{
+					Error:   ErrForbiddenCode,
+					Message: ErrForbiddenMessage,
+				})
+			}
+		}
+		events, err := o.usecase.GetHistory(c.Request().Context(), orderId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrOrderNotFound):
+				slog.Debug("order not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("order history receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("order history received")
+		return c.JSON(http.StatusOK, events)
+	}
+}
+
 func (o *OrderHandlers) updateOrderById() echo.HandlerFunc {
 	type (
 		Product struct {
@@ -193,8 +353,9 @@ func (o *OrderHandlers) updateOrderById() echo.HandlerFunc {
 			Count int       `json:"count" validate:"required,gt=0"`
 		}
 		request struct {
-			Status   entity.OrderStatus `json:"status" validate:"omitempty,oneof=new in_progress done"`
+			Status   entity.OrderStatus `json:"status" validate:"omitempty,oneof=new paid in_progress shipped delivered done cancelled refunded"`
 			Products []*Product         `json:"products"`
+			Comment  string             `json:"comment" validate:"max=500"`
 		}
 	)
 	return func(c echo.Context) error {
@@ -266,7 +427,14 @@ func (o *OrderHandlers) updateOrderById() echo.HandlerFunc {
 				order.Products = append(order.Products, product)
 			}
 		}
-		updatedOrder, err := o.usecase.UpdateById(c.Request().Context(), order)
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		updatedOrder, err := o.usecase.UpdateById(c.Request().Context(), userId, order, requestData.Comment)
 		if err != nil {
 			switch {
 			case errors.Is(err, usecase.ErrOrderNotFound):
@@ -281,12 +449,36 @@ func (o *OrderHandlers) updateOrderById() echo.HandlerFunc {
 					Error:   ErrProductNotExistCode,
 					Message: ErrProductNotExistMessage,
 				})
+			case errors.Is(err, usecase.ErrOrderHasUnavailableProducts):
+				slog.Debug("order has unavailable products", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrOrderUnavailableCode,
+					Message: ErrOrderUnavailableMessage,
+				})
+			case errors.Is(err, usecase.ErrOutOfStock):
+				slog.Debug("not enough stock", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrOutOfStockCode,
+					Message: ErrOutOfStockMessage,
+				})
 			case errors.Is(err, usecase.ErrOrderCantBeUpdated):
 				slog.Debug("order can't be updated", slog.Any("error", err))
 				return c.JSON(http.StatusBadRequest, ErrResponse{
 					Error:   ErrOrderCantBeUpdatedCode,
 					Message: ErrOrderCantBeUpdatedMessage,
 				})
+			case errors.Is(err, usecase.ErrOrderStatusTransitionNotAllowed):
+				slog.Debug("order status transition is not allowed", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrOrderStatusTransitionCode,
+					Message: ErrOrderStatusTransitionMessage,
+				})
+			case errors.Is(err, usecase.ErrOrderChanged):
+				slog.Debug("order was changed meanwhile", slog.Any("error", err))
+				return c.JSON(http.StatusConflict, ErrResponse{
+					Error:   ErrOrderChangedCode,
+					Message: ErrOrderChangedMessage,
+				})
 			default:
 				slog.Error("order updating error", slog.Any("error", err))
 				return c.JSON(http.StatusInternalServerError, ErrResponse{
@@ -300,6 +492,85 @@ func (o *OrderHandlers) updateOrderById() echo.HandlerFunc {
 	}
 }
 
+func (o *OrderHandlers) cancelOrder() echo.HandlerFunc {
+	type request struct {
+		Comment string `json:"comment" validate:"max=500"`
+	}
+	return func(c echo.Context) error {
+		orderId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid order id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		var requestData request
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		validate := validator.New()
+		err = validate.Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		userRole, ok := c.Get(UserRoleContextKey).(entity.UserRole)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		cancelledOrder, err := o.usecase.Cancel(c.Request().Context(), userId, orderId, requestData.Comment, userRole == entity.UserRoleAdmin)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrOrderNotFound):
+				slog.Debug("order not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrOrderOfAnotherUser):
+				slog.Debug("order of another user", slog.Any("error", err))
+				return c.JSON(http.StatusForbidden, ErrResponse{
+					Error:   ErrForbiddenCode,
+					Message: ErrForbiddenMessage,
+				})
+			case errors.Is(err, usecase.ErrOrderCantBeCancelled):
+				slog.Debug("order can't be cancelled", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrOrderCantBeCancelledCode,
+					Message: ErrOrderCantBeCancelledMessage,
+				})
+			default:
+				slog.Error("order cancelling error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("order cancelled")
+		return c.JSON(http.StatusOK, cancelledOrder)
+	}
+}
+
 func (o *OrderHandlers) deleteOrderById() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		orderId, err := uuid.Parse(c.Param("id"))
@@ -343,6 +614,8 @@ func RegisterOrderRoutes(u usecase.Order, g *echo.Group) {
 	g.GET("", a.getAllOrders())
 	g.POST("", a.createOrder())
 	g.GET("/:id", a.getOrderById())
+	g.GET("/:id/history", a.getOrderHistory())
+	g.POST("/:id/cancel", a.cancelOrder())
 	g.PUT("/:id", a.updateOrderById())
 	g.DELETE("/:id", a.deleteOrderById())
 }
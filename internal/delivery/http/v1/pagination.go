@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+// parsePagination reads limit, cursor, sort_by and order query parameters.
+// sortFields lists the fields the list can be sorted by, separated by spaces.
+func parsePagination(c echo.Context, sortFields string) (entity.Pagination, error) {
+	validate := validator.New()
+	pagination := entity.Pagination{
+		Limit:  entity.DefaultPageLimit,
+		Cursor: c.QueryParam("cursor"),
+	}
+	if c.QueryParam("limit") != "" {
+		limit, err := strconv.Atoi(c.QueryParam("limit"))
+		if err != nil {
+			return pagination, err
+		}
+		err = validate.Var(limit, "min=1,max="+strconv.Itoa(entity.MaxPageLimit))
+		if err != nil {
+			return pagination, err
+		}
+		pagination.Limit = limit
+	}
+	if c.QueryParam("sort_by") != "" {
+		err := validate.Var(c.QueryParam("sort_by"), "oneof="+sortFields)
+		if err != nil {
+			return pagination, err
+		}
+		pagination.SortBy = entity.SortBy(c.QueryParam("sort_by"))
+	}
+	if c.QueryParam("order") != "" {
+		err := validate.Var(c.QueryParam("order"), "oneof=asc desc")
+		if err != nil {
+			return pagination, err
+		}
+		pagination.Order = entity.SortOrder(c.QueryParam("order"))
+	}
+	return pagination, nil
+}
@@ -23,14 +23,31 @@ func NewProducerHandlers(u usecase.Producer) *ProducerHandlers {
 
 func (p *ProducerHandlers) getAllProducers() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		producers, err := p.usecase.GetAll(c.Request().Context())
+		pagination, err := parsePagination(c, "created_at name")
 		if err != nil {
-			slog.Error("producers receiving error", slog.Any("error", err))
-			return c.JSON(http.StatusInternalServerError, ErrResponse{
-				Error:   ErrInternalErrorCode,
-				Message: ErrInternalErrorMessage,
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
 			})
 		}
+		producers, err := p.usecase.GetAll(c.Request().Context(), pagination)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrInvalidCursor):
+				slog.Debug("invalid cursor", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrInvalidCursorCode,
+					Message: ErrInvalidCursorMessage,
+				})
+			default:
+				slog.Error("producers receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
 		slog.Info("all producers received")
 		return c.JSON(http.StatusOK, producers)
 	}
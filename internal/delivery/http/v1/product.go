@@ -1,9 +1,13 @@
 package v1
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -13,6 +17,61 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+type productSpecsRequest struct {
+	PrintTechnology entity.PrintTechnology `json:"print_technology" validate:"required,oneof=laser inkjet thermal"`
+	Color           bool                   `json:"color"`
+	MaxFormat       entity.PaperFormat     `json:"max_format" validate:"required,oneof=A4 A3"`
+	Dpi             int                    `json:"dpi" validate:"required,min=1,max=10000"`
+	PagesPerMinute  int                    `json:"pages_per_minute" validate:"required,min=1,max=300"`
+	Duplex          bool                   `json:"duplex"`
+	WiFi            bool                   `json:"wifi"`
+	Ethernet        bool                   `json:"ethernet"`
+	Scan            bool                   `json:"scan"`
+	Copy            bool                   `json:"copy"`
+}
+
+func (r *productSpecsRequest) toEntity() *entity.ProductSpecs {
+	if r == nil {
+		return nil
+	}
+	specs := entity.ProductSpecs(*r)
+	return &specs
+}
+
+// dimensionsRequest is the size of the packed product in millimetres.
+type dimensionsRequest struct {
+	Length int `json:"length" validate:"required,min=1,max=10000"`
+	Width  int `json:"width" validate:"required,min=1,max=10000"`
+	Height int `json:"height" validate:"required,min=1,max=10000"`
+}
+
+func (r *dimensionsRequest) toEntity() *entity.Dimensions {
+	if r == nil {
+		return nil
+	}
+	dimensions := entity.Dimensions(*r)
+	return &dimensions
+}
+
+// priceRequest is the product price. It's a Money object or, as before prices were kept in
+// minor units, a number of major units of the base currency.
+type priceRequest entity.Money
+
+func (r *priceRequest) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '{' {
+		return json.Unmarshal(b, (*entity.Money)(r))
+	}
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	var major float64
+	if err := json.Unmarshal(b, &major); err != nil {
+		return err
+	}
+	*r = priceRequest(entity.NewMoney(int64(math.Round(major*100)), ""))
+	return nil
+}
+
 type ProductHandlers struct {
 	usecase usecase.Product
 }
@@ -23,10 +82,18 @@ func NewProductHandlers(u usecase.Product) *ProductHandlers {
 
 func (p *ProductHandlers) getAllProducts() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		var filter *entity.ProductFilter
-		filter = nil
-		if c.QueryParam("producer_id") != "" || c.QueryParam("product_status") != "" {
-			filter = new(entity.ProductFilter)
+		filter := new(entity.ProductFilter)
+		if c.QueryParam("q") != "" {
+			query := c.QueryParam("q")
+			err := validator.New().Var(query, "max=200")
+			if err != nil {
+				slog.Debug("validation error", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrValidationErrorCode,
+					Message: ErrValidationErrorMessage,
+				})
+			}
+			filter.Query = &query
 		}
 		if c.QueryParam("producer_id") != "" {
 			producerId, err := uuid.Parse(c.QueryParam("producer_id"))
@@ -52,6 +119,25 @@ func (p *ProductHandlers) getAllProducts() echo.HandlerFunc {
 			productStatus := entity.ProductStatus(c.QueryParam("product_status"))
 			filter.Status = &productStatus
 		}
+		if c.QueryParam("category_id") != "" {
+			categoryId, err := uuid.Parse(c.QueryParam("category_id"))
+			if err != nil {
+				slog.Debug("invalid category id", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrInvalidRequestCode,
+					Message: ErrInvalidRequestMessage,
+				})
+			}
+			filter.CategoryId = &categoryId
+		}
+		err := parseProductSpecsFilter(c, filter)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
 		var (
 			userRole entity.UserRole
 			ok       bool
@@ -64,30 +150,60 @@ func (p *ProductHandlers) getAllProducts() echo.HandlerFunc {
 			})
 		}
 		if userRole != entity.UserRoleAdmin {
-			if filter != nil {
-				if filter.Status != nil {
-					if *filter.Status == entity.ProductStatusHidden {
-						return c.JSON(http.StatusForbidden, ErrResponse{
-							Error:   ErrForbiddenCode,
-							Message: ErrForbiddenMessage,
-						})
-					}
+			if filter.Status != nil {
+				if *filter.Status == entity.ProductStatusHidden {
+					return c.JSON(http.StatusForbidden, ErrResponse{
+						Error:   ErrForbiddenCode,
+						Message: ErrForbiddenMessage,
+					})
 				}
 			}
-			if filter == nil {
-				filter = new(entity.ProductFilter)
-			}
 			publishedStatus := entity.ProductStatusPublished
 			filter.Status = &publishedStatus
 		}
-		products, err := p.usecase.GetAll(c.Request().Context(), filter)
+		currency := entity.Currency(c.QueryParam("currency"))
+		err = validator.New().Var(currency, "omitempty,len=3,alpha,uppercase")
 		if err != nil {
-			slog.Error("products receiving error", slog.Any("error", err))
-			return c.JSON(http.StatusInternalServerError, ErrResponse{
-				Error:   ErrInternalErrorCode,
-				Message: ErrInternalErrorMessage,
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		pagination, err := parsePagination(c, "created_at price name")
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
 			})
 		}
+		products, err := p.usecase.GetAll(c.Request().Context(), filter, pagination, currency)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrInvalidCursor):
+				slog.Debug("invalid cursor", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrInvalidCursorCode,
+					Message: ErrInvalidCursorMessage,
+				})
+			case errors.Is(err, usecase.ErrExchangeRateNotFound):
+				slog.Debug("exchange rate not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrExchangeRateNotFoundCode,
+					Message: ErrExchangeRateNotFoundMessage,
+				})
+			default:
+				slog.Error("products receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		if userRole != entity.UserRoleAdmin {
+			hideStock(products.Items)
+		}
 		slog.Info("all products received")
 		return c.JSON(http.StatusOK, products)
 	}
@@ -96,9 +212,15 @@ func (p *ProductHandlers) getAllProducts() echo.HandlerFunc {
 func (p *ProductHandlers) createProduct() echo.HandlerFunc {
 	type request struct {
 		Name       string               `json:"name" validate:"required,max=100,min=3"`
-		Price      float32              `json:"price" validate:"required"`
+		Price      priceRequest         `json:"price"`
 		ProducerId uuid.UUID            `json:"producer_id" validate:"required,uuid"`
+		CategoryId *uuid.UUID           `json:"category_id"`
 		Status     entity.ProductStatus `json:"status" validate:"required,oneof=published hidden"`
+		Specs      *productSpecsRequest `json:"specs"`
+		Stock      *int                 `json:"stock" validate:"omitempty,min=0"`
+		TaxClass   string               `json:"tax_class" validate:"omitempty,max=20"`
+		Weight     int                  `json:"weight" validate:"min=0,max=1000000"`
+		Dimensions *dimensionsRequest   `json:"dimensions"`
 	}
 	return func(c echo.Context) error {
 		var requestData request
@@ -119,13 +241,30 @@ func (p *ProductHandlers) createProduct() echo.HandlerFunc {
 				Message: ErrValidationErrorMessage,
 			})
 		}
+		if requestData.Price.Amount <= 0 {
+			slog.Debug("validation error")
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
 		product := entity.Product{
 			Name:  requestData.Name,
-			Price: requestData.Price,
+			Price: entity.Money(requestData.Price),
 			Producer: &entity.Producer{
 				Id: requestData.ProducerId,
 			},
-			Status: requestData.Status,
+			Status:     requestData.Status,
+			Specs:      requestData.Specs.toEntity(),
+			Stock:      requestData.Stock,
+			TaxClass:   requestData.TaxClass,
+			Weight:     requestData.Weight,
+			Dimensions: requestData.Dimensions.toEntity(),
+		}
+		if requestData.CategoryId != nil {
+			product.Category = &entity.Category{
+				Id: *requestData.CategoryId,
+			}
 		}
 		newProduct, err := p.usecase.Create(c.Request().Context(), product)
 		if err != nil {
@@ -136,6 +275,30 @@ func (p *ProductHandlers) createProduct() echo.HandlerFunc {
 					Error:   ErrProducerNotExistCode,
 					Message: ErrProducerNotExistMessage,
 				})
+			case errors.Is(err, usecase.ErrCategoryNotFound):
+				slog.Debug("category not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrCategoryNotExistCode,
+					Message: ErrCategoryNotExistMessage,
+				})
+			case errors.Is(err, usecase.ErrUnsupportedCurrency):
+				slog.Debug("unsupported currency", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrUnsupportedCurrencyCode,
+					Message: ErrUnsupportedCurrencyMessage,
+				})
+			case errors.Is(err, usecase.ErrTaxClassNotFound):
+				slog.Debug("tax class not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrTaxClassNotExistCode,
+					Message: ErrTaxClassNotExistMessage,
+				})
+			case errors.Is(err, usecase.ErrNoWarehouse):
+				slog.Debug("no warehouse for the stock", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrNoWarehouseCode,
+					Message: ErrNoWarehouseMessage,
+				})
 			default:
 				slog.Error("product creation error", slog.Any("error", err))
 				return c.JSON(http.StatusInternalServerError, ErrResponse{
@@ -159,9 +322,24 @@ func (p *ProductHandlers) getProductById() echo.HandlerFunc {
 				Message: ErrResourceNotFoundMessage,
 			})
 		}
-		product, err := p.usecase.GetById(c.Request().Context(), productId)
+		currency := entity.Currency(c.QueryParam("currency"))
+		err = validator.New().Var(currency, "omitempty,len=3,alpha,uppercase")
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		product, err := p.usecase.GetById(c.Request().Context(), productId, currency)
 		if err != nil {
 			switch {
+			case errors.Is(err, usecase.ErrExchangeRateNotFound):
+				slog.Debug("exchange rate not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrExchangeRateNotFoundCode,
+					Message: ErrExchangeRateNotFoundMessage,
+				})
 			case errors.Is(err, usecase.ErrProductNotFound):
 				slog.Debug("product not found", slog.Any("error", err))
 				return c.JSON(http.StatusNotFound, ErrResponse{
@@ -194,6 +372,7 @@ func (p *ProductHandlers) getProductById() echo.HandlerFunc {
 					Message: ErrForbiddenMessage,
 				})
 			}
+			hideStock([]*entity.Product{product})
 		}
 		slog.Info("product received")
 		return c.JSON(http.StatusOK, product)
@@ -203,9 +382,14 @@ func (p *ProductHandlers) getProductById() echo.HandlerFunc {
 func (p *ProductHandlers) updateProductById() echo.HandlerFunc {
 	type request struct {
 		Name       string               `json:"name" validate:"omitempty,max=100,min=3"`
-		Price      float32              `json:"price" validate:"omitempty"`
+		Price      priceRequest         `json:"price"`
 		ProducerId uuid.UUID            `json:"producer_id" validate:"omitempty,uuid"`
+		CategoryId *uuid.UUID           `json:"category_id"`
 		Status     entity.ProductStatus `json:"status" validate:"omitempty,oneof=published hidden"`
+		Specs      *productSpecsRequest `json:"specs"`
+		TaxClass   string               `json:"tax_class" validate:"omitempty,max=20"`
+		Weight     int                  `json:"weight" validate:"min=0,max=1000000"`
+		Dimensions *dimensionsRequest   `json:"dimensions"`
 	}
 	return func(c echo.Context) error {
 		productId, err := uuid.Parse(c.Param("id"))
@@ -234,7 +418,16 @@ func (p *ProductHandlers) updateProductById() echo.HandlerFunc {
 				Message: ErrValidationErrorMessage,
 			})
 		}
-		if requestData.Name == "" && requestData.Price == 0 && requestData.ProducerId == uuid.Nil && requestData.Status == "" {
+		if requestData.Price.Amount < 0 {
+			slog.Debug("validation error")
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		if requestData.Name == "" && requestData.Price.Amount == 0 && requestData.ProducerId == uuid.Nil && requestData.Status == "" &&
+			requestData.Specs == nil && requestData.CategoryId == nil && requestData.TaxClass == "" && requestData.Weight == 0 &&
+			requestData.Dimensions == nil {
 			return c.JSON(http.StatusBadRequest, ErrResponse{
 				Error:   ErrValidationErrorCode,
 				Message: ErrValidationErrorMessage,
@@ -243,11 +436,20 @@ func (p *ProductHandlers) updateProductById() echo.HandlerFunc {
 		product := entity.Product{
 			Id:    productId,
 			Name:  requestData.Name,
-			Price: requestData.Price,
+			Price: entity.Money(requestData.Price),
 			Producer: &entity.Producer{
 				Id: requestData.ProducerId,
 			},
-			Status: requestData.Status,
+			Status:     requestData.Status,
+			Specs:      requestData.Specs.toEntity(),
+			TaxClass:   requestData.TaxClass,
+			Weight:     requestData.Weight,
+			Dimensions: requestData.Dimensions.toEntity(),
+		}
+		if requestData.CategoryId != nil {
+			product.Category = &entity.Category{
+				Id: *requestData.CategoryId,
+			}
 		}
 		updatedProduct, err := p.usecase.Update(c.Request().Context(), product)
 		if err != nil {
@@ -264,6 +466,24 @@ func (p *ProductHandlers) updateProductById() echo.HandlerFunc {
 					Error:   ErrProducerNotExistCode,
 					Message: ErrProducerNotExistMessage,
 				})
+			case errors.Is(err, usecase.ErrCategoryNotFound):
+				slog.Debug("category not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrCategoryNotExistCode,
+					Message: ErrCategoryNotExistMessage,
+				})
+			case errors.Is(err, usecase.ErrUnsupportedCurrency):
+				slog.Debug("unsupported currency", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrUnsupportedCurrencyCode,
+					Message: ErrUnsupportedCurrencyMessage,
+				})
+			case errors.Is(err, usecase.ErrTaxClassNotFound):
+				slog.Debug("tax class not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrTaxClassNotExistCode,
+					Message: ErrTaxClassNotExistMessage,
+				})
 			default:
 				slog.Error("product updating error", slog.Any("error", err))
 				return c.JSON(http.StatusInternalServerError, ErrResponse{
@@ -315,6 +535,235 @@ func (p *ProductHandlers) deleteProductById() echo.HandlerFunc {
 	}
 }
 
+func (p *ProductHandlers) getCompatibleProducts() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		productId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid product id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		currency := entity.Currency(c.QueryParam("currency"))
+		err = validator.New().Var(currency, "omitempty,len=3,alpha,uppercase")
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		userRole, ok := c.Get(UserRoleContextKey).(entity.UserRole)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		var filter *entity.ProductFilter
+		if userRole != entity.UserRoleAdmin {
+			publishedStatus := entity.ProductStatusPublished
+			filter = &entity.ProductFilter{
+				Status: &publishedStatus,
+			}
+		}
+		products, err := p.usecase.GetCompatible(c.Request().Context(), productId, filter, currency)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrProductNotFound):
+				slog.Debug("product not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrExchangeRateNotFound):
+				slog.Debug("exchange rate not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrExchangeRateNotFoundCode,
+					Message: ErrExchangeRateNotFoundMessage,
+				})
+			default:
+				slog.Error("compatible products receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		if userRole != entity.UserRoleAdmin {
+			hideStock(products)
+		}
+		slog.Info("compatible products received")
+		return c.JSON(http.StatusOK, products)
+	}
+}
+
+func (p *ProductHandlers) addCompatibleProduct() echo.HandlerFunc {
+	type request struct {
+		ProductId uuid.UUID `json:"product_id" validate:"required,uuid"`
+	}
+	return func(c echo.Context) error {
+		productId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid product id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		var requestData request
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		validate := validator.New()
+		err = validate.Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		err = p.usecase.AddCompatible(c.Request().Context(), productId, requestData.ProductId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrProductNotFound):
+				slog.Debug("product not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrCompatibleProductNotFound):
+				slog.Debug("compatible product not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrProductNotExistCode,
+					Message: ErrProductNotExistMessage,
+				})
+			case errors.Is(err, usecase.ErrProductCompatibleWithItself):
+				slog.Debug("product compatible with itself", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrSelfCompatibilityCode,
+					Message: ErrSelfCompatibilityMessage,
+				})
+			default:
+				slog.Error("compatible product adding error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("compatible product added")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func (p *ProductHandlers) deleteCompatibleProduct() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		productId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid product id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		compatibleId, err := uuid.Parse(c.Param("compatibleId"))
+		if err != nil {
+			slog.Debug("invalid compatible product id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		err = p.usecase.DeleteCompatible(c.Request().Context(), productId, compatibleId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrCompatibilityNotFound):
+				slog.Debug("compatibility not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("compatible product delete error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("compatible product deleted")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func parseProductSpecsFilter(c echo.Context, filter *entity.ProductFilter) error {
+	validate := validator.New()
+	if c.QueryParam("print_technology") != "" {
+		err := validate.Var(c.QueryParam("print_technology"), "oneof=laser inkjet thermal")
+		if err != nil {
+			return err
+		}
+		printTechnology := entity.PrintTechnology(c.QueryParam("print_technology"))
+		filter.PrintTechnology = &printTechnology
+	}
+	if c.QueryParam("max_format") != "" {
+		err := validate.Var(c.QueryParam("max_format"), "oneof=A4 A3")
+		if err != nil {
+			return err
+		}
+		maxFormat := entity.PaperFormat(c.QueryParam("max_format"))
+		filter.MaxFormat = &maxFormat
+	}
+	ints := map[string]**int{
+		"min_dpi":              &filter.MinDpi,
+		"min_pages_per_minute": &filter.MinPagesPerMinute,
+	}
+	for name, field := range ints {
+		if c.QueryParam(name) == "" {
+			continue
+		}
+		value, err := strconv.Atoi(c.QueryParam(name))
+		if err != nil {
+			return err
+		}
+		*field = &value
+	}
+	bools := map[string]**bool{
+		"color":    &filter.Color,
+		"duplex":   &filter.Duplex,
+		"wifi":     &filter.WiFi,
+		"ethernet": &filter.Ethernet,
+		"scan":     &filter.Scan,
+		"copy":     &filter.Copy,
+	}
+	for name, field := range bools {
+		if c.QueryParam(name) == "" {
+			continue
+		}
+		value, err := strconv.ParseBool(c.QueryParam(name))
+		if err != nil {
+			return err
+		}
+		*field = &value
+	}
+	return nil
+}
+
+// hideStock leaves only the stock status for customers, exact stock levels are seen by admins.
+func hideStock(products []*entity.Product) {
+	for _, product := range products {
+		product.Stock = nil
+	}
+}
+
 func RegisterProductRoutes(u usecase.Product, g *echo.Group) {
 	a := NewProductHandlers(u)
 	g.GET("", a.getAllProducts())
@@ -322,4 +771,7 @@ func RegisterProductRoutes(u usecase.Product, g *echo.Group) {
 	g.GET("/:id", a.getProductById())
 	g.PUT("/:id", a.updateProductById())
 	g.DELETE("/:id", a.deleteProductById())
+	g.GET("/:id/compatible", a.getCompatibleProducts())
+	g.POST("/:id/compatible", a.addCompatibleProduct())
+	g.DELETE("/:id/compatible/:compatibleId", a.deleteCompatibleProduct())
 }
@@ -0,0 +1,283 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	. "github.com/krijebr/printer-shop/internal/delivery/http/common"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+type promoCodeScopeRequest struct {
+	Type entity.PromoCodeScopeType `json:"type" validate:"required,oneof=product producer category"`
+	Id   uuid.UUID                 `json:"id" validate:"required"`
+}
+
+type promoCodeRequest struct {
+	Code              string                  `json:"code" validate:"required,min=3,max=32,alphanum"`
+	Description       string                  `json:"description" validate:"max=255"`
+	DiscountType      entity.DiscountType     `json:"discount_type" validate:"required,oneof=percentage fixed"`
+	DiscountValue     int64                   `json:"discount_value" validate:"required,min=1"`
+	MinOrderAmount    int64                   `json:"min_order_amount" validate:"min=0"`
+	Currency          entity.Currency         `json:"currency"`
+	StartsAt          *time.Time              `json:"starts_at"`
+	EndsAt            *time.Time              `json:"ends_at"`
+	UsageLimit        *int                    `json:"usage_limit" validate:"omitempty,min=1"`
+	UsageLimitPerUser *int                    `json:"usage_limit_per_user" validate:"omitempty,min=1"`
+	Scopes            []promoCodeScopeRequest `json:"scopes" validate:"dive"`
+}
+
+// validate checks the request fields which depend on each other.
+func (r *promoCodeRequest) validate() error {
+	err := validator.New().Struct(r)
+	if err != nil {
+		return err
+	}
+	if r.DiscountType == entity.DiscountTypePercentage && r.DiscountValue > 100 {
+		return errors.New("percentage discount is over 100")
+	}
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+		return errors.New("promo code ends before it starts")
+	}
+	return nil
+}
+
+func (r *promoCodeRequest) toEntity() entity.PromoCode {
+	promoCode := entity.PromoCode{
+		Code:              r.Code,
+		Description:       r.Description,
+		DiscountType:      r.DiscountType,
+		DiscountValue:     r.DiscountValue,
+		MinOrderAmount:    r.MinOrderAmount,
+		Currency:          r.Currency,
+		StartsAt:          r.StartsAt,
+		EndsAt:            r.EndsAt,
+		UsageLimit:        r.UsageLimit,
+		UsageLimitPerUser: r.UsageLimitPerUser,
+		Scopes:            []*entity.PromoCodeScope{},
+	}
+	for _, scope := range r.Scopes {
+		promoCode.Scopes = append(promoCode.Scopes, &entity.PromoCodeScope{
+			Type: scope.Type,
+			Id:   scope.Id,
+		})
+	}
+	return promoCode
+}
+
+type PromoCodeHandlers struct {
+	usecase usecase.PromoCode
+}
+
+func NewPromoCodeHandlers(u usecase.PromoCode) *PromoCodeHandlers {
+	return &PromoCodeHandlers{usecase: u}
+}
+
+// promoCodeCheckErrResponse maps the errors of checking a created or updated promo code.
+func promoCodeCheckErrResponse(err error) (ErrResponse, bool) {
+	switch {
+	case errors.Is(err, usecase.ErrPromoCodeExists):
+		return ErrResponse{Error: ErrPromoCodeExistsCode, Message: ErrPromoCodeExistsMessage}, true
+	case errors.Is(err, usecase.ErrUnsupportedCurrency):
+		return ErrResponse{Error: ErrUnsupportedCurrencyCode, Message: ErrUnsupportedCurrencyMessage}, true
+	case errors.Is(err, usecase.ErrProductNotFound):
+		return ErrResponse{Error: ErrProductNotExistCode, Message: ErrProductNotExistMessage}, true
+	case errors.Is(err, usecase.ErrProducerNotFound):
+		return ErrResponse{Error: ErrProducerNotExistCode, Message: ErrProducerNotExistMessage}, true
+	case errors.Is(err, usecase.ErrCategoryNotFound):
+		return ErrResponse{Error: ErrCategoryNotExistCode, Message: ErrCategoryNotExistMessage}, true
+	default:
+		return ErrResponse{}, false
+	}
+}
+
+func (h *PromoCodeHandlers) getAllPromoCodes() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		promoCodes, err := h.usecase.GetAll(c.Request().Context())
+		if err != nil {
+			slog.Error("promo codes receiving error", slog.Any("error", err))
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		slog.Info("all promo codes received")
+		return c.JSON(http.StatusOK, promoCodes)
+	}
+}
+
+func (h *PromoCodeHandlers) createPromoCode() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var requestData promoCodeRequest
+		err := c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		err = requestData.validate()
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		newPromoCode, err := h.usecase.Create(c.Request().Context(), requestData.toEntity())
+		if err != nil {
+			if response, ok := promoCodeCheckErrResponse(err); ok {
+				slog.Debug("invalid promo code", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, response)
+			}
+			slog.Error("promo code creation error", slog.Any("error", err))
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		slog.Info("promo code created")
+		return c.JSON(http.StatusOK, newPromoCode)
+	}
+}
+
+func (h *PromoCodeHandlers) getPromoCodeById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		promoCodeId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid promo code id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		promoCode, err := h.usecase.GetById(c.Request().Context(), promoCodeId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrPromoCodeNotFound):
+				slog.Debug("promo code not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("promo code receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("promo code received")
+		return c.JSON(http.StatusOK, promoCode)
+	}
+}
+
+func (h *PromoCodeHandlers) updatePromoCodeById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		promoCodeId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid promo code id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		var requestData promoCodeRequest
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		err = requestData.validate()
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		promoCode := requestData.toEntity()
+		promoCode.Id = promoCodeId
+		updatedPromoCode, err := h.usecase.Update(c.Request().Context(), promoCode)
+		if err != nil {
+			if response, ok := promoCodeCheckErrResponse(err); ok {
+				slog.Debug("invalid promo code", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, response)
+			}
+			switch {
+			case errors.Is(err, usecase.ErrPromoCodeNotFound):
+				slog.Debug("promo code not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("promo code updating error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("promo code updated")
+		return c.JSON(http.StatusOK, updatedPromoCode)
+	}
+}
+
+func (h *PromoCodeHandlers) deletePromoCodeById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		promoCodeId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid promo code id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		err = h.usecase.DeleteById(c.Request().Context(), promoCodeId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrPromoCodeNotFound):
+				slog.Debug("promo code not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrPromoCodeIsUsed):
+				slog.Debug("promo code is used", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrPromoCodeIsUsedCode,
+					Message: ErrPromoCodeIsUsedMessage,
+				})
+			default:
+				slog.Error("promo code delete error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("promo code deleted")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func RegisterPromoCodeRoutes(u usecase.PromoCode, g *echo.Group) {
+	h := NewPromoCodeHandlers(u)
+	g.GET("", h.getAllPromoCodes())
+	g.POST("", h.createPromoCode())
+	g.GET("/:id", h.getPromoCodeById())
+	g.PUT("/:id", h.updatePromoCodeById())
+	g.DELETE("/:id", h.deletePromoCodeById())
+}
@@ -0,0 +1,311 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	. "github.com/krijebr/printer-shop/internal/delivery/http/common"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+type promotionProductRequest struct {
+	ProductId uuid.UUID `json:"product_id" validate:"required"`
+	Count     int       `json:"count" validate:"min=0"`
+}
+
+type promotionTierRequest struct {
+	MinCount int   `json:"min_count" validate:"required,min=1"`
+	Price    int64 `json:"price" validate:"min=0"`
+}
+
+type promotionRequest struct {
+	Name      string                    `json:"name" validate:"required,max=100"`
+	Type      entity.PromotionType      `json:"type" validate:"required,oneof=bundle buy_x_get_y volume_tier"`
+	Priority  int                       `json:"priority"`
+	Products  []promotionProductRequest `json:"products" validate:"required,min=1,unique=ProductId,dive"`
+	Percent   int                       `json:"percent" validate:"min=0,max=100"`
+	BuyCount  int                       `json:"buy_count" validate:"min=0"`
+	FreeCount int                       `json:"free_count" validate:"min=0"`
+	Tiers     []promotionTierRequest    `json:"tiers" validate:"unique=MinCount,dive"`
+	Currency  entity.Currency           `json:"currency"`
+	Active    *bool                     `json:"active"`
+	StartsAt  *time.Time                `json:"starts_at"`
+	EndsAt    *time.Time                `json:"ends_at"`
+}
+
+// validate checks the request fields which depend on each other and the rule of the promotion type.
+func (r *promotionRequest) validate() error {
+	err := validator.New().Struct(r)
+	if err != nil {
+		return err
+	}
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+		return errors.New("promotion ends before it starts")
+	}
+	switch r.Type {
+	case entity.PromotionTypeBundle:
+		if r.Percent == 0 {
+			return errors.New("bundle has no percent")
+		}
+		items := 0
+		for _, product := range r.Products {
+			items += max(product.Count, 1)
+		}
+		if items < 2 {
+			return errors.New("bundle has less than 2 items")
+		}
+	case entity.PromotionTypeBuyXGetY:
+		if r.BuyCount == 0 || r.FreeCount == 0 {
+			return errors.New("buy x get y has no buy or free count")
+		}
+	case entity.PromotionTypeVolumeTier:
+		if len(r.Tiers) == 0 {
+			return errors.New("volume tier promotion has no tiers")
+		}
+	}
+	return nil
+}
+
+// toEntity keeps only the fields the rule of the promotion type uses.
+func (r *promotionRequest) toEntity() entity.Promotion {
+	promotion := entity.Promotion{
+		Name:     r.Name,
+		Type:     r.Type,
+		Priority: r.Priority,
+		Products: []*entity.PromotionProduct{},
+		Currency: r.Currency,
+		Active:   r.Active == nil || *r.Active,
+		StartsAt: r.StartsAt,
+		EndsAt:   r.EndsAt,
+	}
+	for _, product := range r.Products {
+		count := 1
+		if r.Type == entity.PromotionTypeBundle && product.Count > 0 {
+			count = product.Count
+		}
+		promotion.Products = append(promotion.Products, &entity.PromotionProduct{
+			ProductId: product.ProductId,
+			Count:     count,
+		})
+	}
+	switch r.Type {
+	case entity.PromotionTypeBundle:
+		promotion.Percent = r.Percent
+	case entity.PromotionTypeBuyXGetY:
+		promotion.BuyCount = r.BuyCount
+		promotion.FreeCount = r.FreeCount
+	case entity.PromotionTypeVolumeTier:
+		for _, tier := range r.Tiers {
+			promotion.Tiers = append(promotion.Tiers, &entity.PromotionTier{
+				MinCount: tier.MinCount,
+				Price:    tier.Price,
+			})
+		}
+	}
+	return promotion
+}
+
+type PromotionHandlers struct {
+	usecase usecase.Promotion
+}
+
+func NewPromotionHandlers(u usecase.Promotion) *PromotionHandlers {
+	return &PromotionHandlers{usecase: u}
+}
+
+// promotionCheckErrResponse maps the errors of checking a created or updated promotion.
+func promotionCheckErrResponse(err error) (ErrResponse, bool) {
+	switch {
+	case errors.Is(err, usecase.ErrUnsupportedCurrency):
+		return ErrResponse{Error: ErrUnsupportedCurrencyCode, Message: ErrUnsupportedCurrencyMessage}, true
+	case errors.Is(err, usecase.ErrProductNotFound):
+		return ErrResponse{Error: ErrProductNotExistCode, Message: ErrProductNotExistMessage}, true
+	default:
+		return ErrResponse{}, false
+	}
+}
+
+func (h *PromotionHandlers) getAllPromotions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		promotions, err := h.usecase.GetAll(c.Request().Context())
+		if err != nil {
+			slog.Error("promotions receiving error", slog.Any("error", err))
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		slog.Info("all promotions received")
+		return c.JSON(http.StatusOK, promotions)
+	}
+}
+
+func (h *PromotionHandlers) createPromotion() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var requestData promotionRequest
+		err := c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		err = requestData.validate()
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		newPromotion, err := h.usecase.Create(c.Request().Context(), requestData.toEntity())
+		if err != nil {
+			if response, ok := promotionCheckErrResponse(err); ok {
+				slog.Debug("invalid promotion", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, response)
+			}
+			slog.Error("promotion creation error", slog.Any("error", err))
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		slog.Info("promotion created")
+		return c.JSON(http.StatusOK, newPromotion)
+	}
+}
+
+func (h *PromotionHandlers) getPromotionById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		promotionId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid promotion id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		promotion, err := h.usecase.GetById(c.Request().Context(), promotionId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrPromotionNotFound):
+				slog.Debug("promotion not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("promotion receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("promotion received")
+		return c.JSON(http.StatusOK, promotion)
+	}
+}
+
+func (h *PromotionHandlers) updatePromotionById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		promotionId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid promotion id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		var requestData promotionRequest
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		err = requestData.validate()
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		promotion := requestData.toEntity()
+		promotion.Id = promotionId
+		updatedPromotion, err := h.usecase.Update(c.Request().Context(), promotion)
+		if err != nil {
+			if response, ok := promotionCheckErrResponse(err); ok {
+				slog.Debug("invalid promotion", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, response)
+			}
+			switch {
+			case errors.Is(err, usecase.ErrPromotionNotFound):
+				slog.Debug("promotion not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("promotion updating error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("promotion updated")
+		return c.JSON(http.StatusOK, updatedPromotion)
+	}
+}
+
+func (h *PromotionHandlers) deletePromotionById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		promotionId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid promotion id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		err = h.usecase.DeleteById(c.Request().Context(), promotionId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrPromotionNotFound):
+				slog.Debug("promotion not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("promotion delete error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("promotion deleted")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func RegisterPromotionRoutes(u usecase.Promotion, g *echo.Group) {
+	h := NewPromotionHandlers(u)
+	g.GET("", h.getAllPromotions())
+	g.POST("", h.createPromotion())
+	g.GET("/:id", h.getPromotionById())
+	g.PUT("/:id", h.updatePromotionById())
+	g.DELETE("/:id", h.deletePromotionById())
+}
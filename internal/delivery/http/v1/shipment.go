@@ -0,0 +1,130 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	. "github.com/krijebr/printer-shop/internal/delivery/http/common"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+type shipmentLineRequest struct {
+	ProductId uuid.UUID `json:"product_id" validate:"required"`
+	Count     int       `json:"count" validate:"required,min=1"`
+}
+
+type shipmentRequest struct {
+	Carrier        string                `json:"carrier" validate:"required,max=50"`
+	TrackingNumber string                `json:"tracking_number" validate:"max=100"`
+	Lines          []shipmentLineRequest `json:"lines" validate:"required,min=1,unique=ProductId,dive"`
+	ShippedAt      *time.Time            `json:"shipped_at"`
+}
+
+func (r *shipmentRequest) toEntity(orderId uuid.UUID) entity.Shipment {
+	shipment := entity.Shipment{
+		OrderId:        orderId,
+		Carrier:        r.Carrier,
+		TrackingNumber: r.TrackingNumber,
+		Lines:          make([]*entity.ShipmentLine, 0, len(r.Lines)),
+	}
+	for _, line := range r.Lines {
+		shipment.Lines = append(shipment.Lines, &entity.ShipmentLine{ProductId: line.ProductId, Count: line.Count})
+	}
+	if r.ShippedAt != nil {
+		shipment.ShippedAt = *r.ShippedAt
+	}
+	return shipment
+}
+
+type ShipmentHandlers struct {
+	usecase usecase.Shipment
+}
+
+func NewShipmentHandlers(u usecase.Shipment) *ShipmentHandlers {
+	return &ShipmentHandlers{usecase: u}
+}
+
+func (s *ShipmentHandlers) createShipment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		orderId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid order id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		var requestData shipmentRequest
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		err = validator.New().Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		shipment, err := s.usecase.Create(c.Request().Context(), userId, requestData.toEntity(orderId))
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrOrderNotFound):
+				slog.Debug("order not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrOrderCantBeShipped):
+				slog.Debug("order can't be shipped", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrOrderCantBeShippedCode,
+					Message: ErrOrderCantBeShippedMessage,
+				})
+			case errors.Is(err, usecase.ErrShipmentProductNotInOrder):
+				slog.Debug("shipped product not in order", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrShipmentNotInOrderCode,
+					Message: ErrShipmentNotInOrderMessage,
+				})
+			case errors.Is(err, usecase.ErrShipmentExceedsOrder):
+				slog.Debug("shipment exceeds order", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrShipmentExceedsOrderCode,
+					Message: ErrShipmentExceedsOrderMessage,
+				})
+			default:
+				slog.Error("shipment creating error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("shipment created")
+		return c.JSON(http.StatusOK, shipment)
+	}
+}
+
+func RegisterShipmentRoutes(u usecase.Shipment, g *echo.Group) {
+	h := NewShipmentHandlers(u)
+	g.POST("/:id/shipments", h.createShipment())
+}
@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	. "github.com/krijebr/printer-shop/internal/delivery/http/common"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+type ShippingHandlers struct {
+	usecase usecase.Shipping
+}
+
+func NewShippingHandlers(u usecase.Shipping) *ShippingHandlers {
+	return &ShippingHandlers{usecase: u}
+}
+
+func (h *ShippingHandlers) getQuote() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		currency := entity.Currency(c.QueryParam("currency"))
+		err := validator.New().Var(currency, "omitempty,len=3,alpha,uppercase")
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		var addressId *uuid.UUID
+		if c.QueryParam("address_id") != "" {
+			id, err := uuid.Parse(c.QueryParam("address_id"))
+			if err != nil {
+				slog.Debug("validation error", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrValidationErrorCode,
+					Message: ErrValidationErrorMessage,
+				})
+			}
+			addressId = &id
+		}
+		quotes, err := h.usecase.Quote(c.Request().Context(), userId, currency, addressId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrAddressNotFound):
+				slog.Debug("address not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrAddressNotExistCode,
+					Message: ErrAddressNotExistMessage,
+				})
+			case errors.Is(err, usecase.ErrCartIsEmpty):
+				slog.Debug("cart is empty", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrCartIsEmptyCode,
+					Message: ErrCartIsEmptyMessage,
+				})
+			case errors.Is(err, usecase.ErrExchangeRateNotFound):
+				slog.Debug("exchange rate not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrExchangeRateNotFoundCode,
+					Message: ErrExchangeRateNotFoundMessage,
+				})
+			default:
+				slog.Error("shipping quote error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("shipping quoted")
+		return c.JSON(http.StatusOK, quotes)
+	}
+}
+
+func RegisterShippingRoutes(u usecase.Shipping, g *echo.Group) {
+	h := NewShippingHandlers(u)
+	g.GET("/quote", h.getQuote())
+}
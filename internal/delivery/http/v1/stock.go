@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	. "github.com/krijebr/printer-shop/internal/delivery/http/common"
+	"github.com/krijebr/printer-shop/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+type StockHandlers struct {
+	usecase usecase.Stock
+}
+
+func NewStockHandlers(u usecase.Stock) *StockHandlers {
+	return &StockHandlers{usecase: u}
+}
+
+func (s *StockHandlers) adjustStock() echo.HandlerFunc {
+	type request struct {
+		WarehouseId uuid.UUID `json:"warehouse_id" validate:"required,uuid"`
+		Delta       int       `json:"delta" validate:"required,min=-100000,max=100000"`
+		Reason      string    `json:"reason" validate:"required,max=300,min=3"`
+	}
+	return func(c echo.Context) error {
+		productId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid product id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		var requestData request
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		err = validator.New().Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		product, err := s.usecase.Adjust(c.Request().Context(), userId, productId, requestData.WarehouseId,
+			requestData.Delta, requestData.Reason)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrProductNotFound):
+				slog.Debug("product not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrWarehouseNotFound):
+				slog.Debug("warehouse not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrWarehouseNotExistCode,
+					Message: ErrWarehouseNotExistMessage,
+				})
+			case errors.Is(err, usecase.ErrOutOfStock):
+				slog.Debug("not enough stock", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrOutOfStockCode,
+					Message: ErrOutOfStockMessage,
+				})
+			default:
+				slog.Error("stock adjusting error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("stock adjusted", slog.Any("product_id", productId), slog.Any("warehouse_id", requestData.WarehouseId),
+			slog.Int("delta", requestData.Delta))
+		return c.JSON(http.StatusOK, product)
+	}
+}
+
+func (s *StockHandlers) getStockLevels() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		productId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid product id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		levels, err := s.usecase.GetLevels(c.Request().Context(), productId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrProductNotFound):
+				slog.Debug("product not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("stock levels receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("stock levels received")
+		return c.JSON(http.StatusOK, levels)
+	}
+}
+
+func (s *StockHandlers) getStockMovements() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		productId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid product id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		movements, err := s.usecase.GetMovements(c.Request().Context(), productId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrProductNotFound):
+				slog.Debug("product not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("stock movements receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("stock movements received")
+		return c.JSON(http.StatusOK, movements)
+	}
+}
+
+func RegisterStockRoutes(u usecase.Stock, g *echo.Group) {
+	s := NewStockHandlers(u)
+	g.GET("/:id/stock", s.getStockLevels())
+	g.POST("/:id/stock", s.adjustStock())
+	g.GET("/:id/stock-movements", s.getStockMovements())
+}
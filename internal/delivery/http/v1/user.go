@@ -57,14 +57,31 @@ func (u *UserHandlers) allUsers() echo.HandlerFunc {
 			userRole := entity.UserRole(c.QueryParam("user_role"))
 			userFilter.UserRole = &userRole
 		}
-		users, err := u.usecase.GetAll(c.Request().Context(), userFilter)
+		pagination, err := parsePagination(c, "created_at name")
 		if err != nil {
-			slog.Error("users receiving error", slog.Any("error", err))
-			return c.JSON(http.StatusInternalServerError, ErrResponse{
-				Error:   ErrInternalErrorCode,
-				Message: ErrInternalErrorMessage,
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
 			})
 		}
+		users, err := u.usecase.GetAll(c.Request().Context(), userFilter, pagination)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrInvalidCursor):
+				slog.Debug("invalid cursor", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrInvalidCursorCode,
+					Message: ErrInvalidCursorMessage,
+				})
+			default:
+				slog.Error("users receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
 		slog.Info("all users received")
 		return c.JSON(http.StatusOK, users)
 	}
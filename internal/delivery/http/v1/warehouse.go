@@ -0,0 +1,253 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	. "github.com/krijebr/printer-shop/internal/delivery/http/common"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+type WarehouseHandlers struct {
+	usecase usecase.Warehouse
+}
+
+func NewWarehouseHandlers(u usecase.Warehouse) *WarehouseHandlers {
+	return &WarehouseHandlers{usecase: u}
+}
+
+func (h *WarehouseHandlers) getAllWarehouses() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		warehouses, err := h.usecase.GetAll(c.Request().Context())
+		if err != nil {
+			slog.Error("warehouses receiving error", slog.Any("error", err))
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		slog.Info("all warehouses received")
+		return c.JSON(http.StatusOK, warehouses)
+	}
+}
+
+func (h *WarehouseHandlers) createWarehouse() echo.HandlerFunc {
+	type request struct {
+		Name     string `json:"name" validate:"required,max=100,min=2"`
+		City     string `json:"city" validate:"required,max=100,min=2"`
+		Priority int    `json:"priority" validate:"min=0"`
+	}
+	return func(c echo.Context) error {
+		var requestData request
+		err := c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		validate := validator.New()
+		err = validate.Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		warehouse := entity.Warehouse{
+			Name:     requestData.Name,
+			City:     requestData.City,
+			Priority: requestData.Priority,
+		}
+		newWarehouse, err := h.usecase.Create(c.Request().Context(), warehouse)
+		if err != nil {
+			slog.Error("warehouse creation error", slog.Any("error", err))
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		slog.Info("warehouse created")
+		return c.JSON(http.StatusOK, newWarehouse)
+	}
+}
+
+func (h *WarehouseHandlers) getWarehouseById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		warehouseId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid warehouse id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		warehouse, err := h.usecase.GetById(c.Request().Context(), warehouseId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWarehouseNotFound):
+				slog.Debug("warehouse not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("warehouse receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("warehouse received")
+		return c.JSON(http.StatusOK, warehouse)
+	}
+}
+
+func (h *WarehouseHandlers) updateWarehouseById() echo.HandlerFunc {
+	type request struct {
+		Name     string `json:"name" validate:"required,max=100,min=2"`
+		City     string `json:"city" validate:"required,max=100,min=2"`
+		Priority int    `json:"priority" validate:"min=0"`
+	}
+	return func(c echo.Context) error {
+		warehouseId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid warehouse id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		var requestData request
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		validate := validator.New()
+		err = validate.Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		warehouse := entity.Warehouse{
+			Id:       warehouseId,
+			Name:     requestData.Name,
+			City:     requestData.City,
+			Priority: requestData.Priority,
+		}
+		updatedWarehouse, err := h.usecase.Update(c.Request().Context(), warehouse)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWarehouseNotFound):
+				slog.Debug("warehouse not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("warehouse updating error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("warehouse updated")
+		return c.JSON(http.StatusOK, updatedWarehouse)
+	}
+}
+
+func (h *WarehouseHandlers) deleteWarehouseById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		warehouseId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid warehouse id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		err = h.usecase.DeleteById(c.Request().Context(), warehouseId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWarehouseNotFound):
+				slog.Debug("warehouse not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrWarehouseIsUsed):
+				slog.Debug("warehouse is used", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrWarehouseIsUsedCode,
+					Message: ErrWarehouseIsUsedMessage,
+				})
+			default:
+				slog.Error("warehouse delete error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("warehouse deleted")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func (h *WarehouseHandlers) getWarehouseStock() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		warehouseId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid warehouse id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		stock, err := h.usecase.GetStock(c.Request().Context(), warehouseId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWarehouseNotFound):
+				slog.Debug("warehouse not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("warehouse stock receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("warehouse stock received")
+		return c.JSON(http.StatusOK, stock)
+	}
+}
+
+func RegisterWarehouseRoutes(u usecase.Warehouse, g *echo.Group) {
+	h := NewWarehouseHandlers(u)
+	g.GET("", h.getAllWarehouses())
+	g.POST("", h.createWarehouse())
+	g.GET("/:id", h.getWarehouseById())
+	g.PUT("/:id", h.updateWarehouseById())
+	g.DELETE("/:id", h.deleteWarehouseById())
+	g.GET("/:id/stock", h.getWarehouseStock())
+}
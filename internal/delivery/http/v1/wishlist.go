@@ -0,0 +1,649 @@
+package v1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	. "github.com/krijebr/printer-shop/internal/delivery/http/common"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+type WishlistHandlers struct {
+	usecase usecase.Wishlist
+}
+
+func NewWishlistHandlers(u usecase.Wishlist) *WishlistHandlers {
+	return &WishlistHandlers{usecase: u}
+}
+
+func (h *WishlistHandlers) getAllWishlists() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		wishlists, err := h.usecase.GetAll(c.Request().Context(), userId)
+		if err != nil {
+			slog.Error("wishlists receiving error", slog.Any("error", err))
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		slog.Info("all wishlists received")
+		return c.JSON(http.StatusOK, wishlists)
+	}
+}
+
+func (h *WishlistHandlers) createWishlist() echo.HandlerFunc {
+	type request struct {
+		Name string `json:"name" validate:"required,max=100,min=1"`
+	}
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		var requestData request
+		err := c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		validate := validator.New()
+		err = validate.Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		wishlist := entity.Wishlist{
+			UserId: userId,
+			Name:   requestData.Name,
+		}
+		newWishlist, err := h.usecase.Create(c.Request().Context(), wishlist)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWishlistNameExists):
+				slog.Debug("wishlist name already exists", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrWishlistNameExistsCode,
+					Message: ErrWishlistNameExistsMessage,
+				})
+			default:
+				slog.Error("wishlist creation error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("wishlist created")
+		return c.JSON(http.StatusOK, newWishlist)
+	}
+}
+
+func (h *WishlistHandlers) getWishlistById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		wishlistId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid wishlist id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		wishlist, err := h.usecase.GetById(c.Request().Context(), userId, wishlistId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWishlistNotFound):
+				slog.Debug("wishlist not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("wishlist receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("wishlist received")
+		return c.JSON(http.StatusOK, wishlist)
+	}
+}
+
+func (h *WishlistHandlers) getSharedWishlist() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token, err := uuid.Parse(c.Param("token"))
+		if err != nil {
+			slog.Debug("invalid share token", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		wishlist, err := h.usecase.GetShared(c.Request().Context(), token)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWishlistNotFound):
+				slog.Debug("shared wishlist not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("shared wishlist receiving error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("shared wishlist received")
+		return c.JSON(http.StatusOK, wishlist)
+	}
+}
+
+func (h *WishlistHandlers) updateWishlistById() echo.HandlerFunc {
+	type request struct {
+		Name string `json:"name" validate:"required,max=100,min=1"`
+	}
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		wishlistId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid wishlist id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		var requestData request
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		validate := validator.New()
+		err = validate.Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		wishlist := entity.Wishlist{
+			Id:     wishlistId,
+			UserId: userId,
+			Name:   requestData.Name,
+		}
+		updatedWishlist, err := h.usecase.Update(c.Request().Context(), wishlist)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWishlistNotFound):
+				slog.Debug("wishlist not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrWishlistNameExists):
+				slog.Debug("wishlist name already exists", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrWishlistNameExistsCode,
+					Message: ErrWishlistNameExistsMessage,
+				})
+			default:
+				slog.Error("wishlist updating error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("wishlist updated")
+		return c.JSON(http.StatusOK, updatedWishlist)
+	}
+}
+
+func (h *WishlistHandlers) deleteWishlistById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		wishlistId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid wishlist id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		err = h.usecase.DeleteById(c.Request().Context(), userId, wishlistId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWishlistNotFound):
+				slog.Debug("wishlist not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("wishlist deleting error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("wishlist deleted")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func (h *WishlistHandlers) addProductToWishlist() echo.HandlerFunc {
+	type request struct {
+		ProductId uuid.UUID `json:"product_id" validate:"required,uuid"`
+	}
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		wishlistId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid wishlist id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		var requestData request
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		validate := validator.New()
+		err = validate.Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		err = h.usecase.AddProduct(c.Request().Context(), userId, wishlistId, requestData.ProductId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWishlistNotFound):
+				slog.Debug("wishlist not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrProductNotFound), errors.Is(err, usecase.ErrProductIsHidden):
+				slog.Debug("product not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrProductNotExistCode,
+					Message: ErrProductNotExistMessage,
+				})
+			default:
+				slog.Error("adding product to wishlist error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("product added to wishlist")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func (h *WishlistHandlers) deleteProductFromWishlist() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		wishlistId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid wishlist id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		productId, err := uuid.Parse(c.Param("productId"))
+		if err != nil {
+			slog.Debug("invalid product id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		err = h.usecase.DeleteProduct(c.Request().Context(), userId, wishlistId, productId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWishlistNotFound):
+				slog.Debug("wishlist not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrProductNotInWishlist):
+				slog.Debug("product is not in wishlist", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrProductNotInWishlistCode,
+					Message: ErrProductNotInWishlistMessage,
+				})
+			default:
+				slog.Error("deleting product from wishlist error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("product deleted from wishlist")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func (h *WishlistHandlers) moveProductToCart() echo.HandlerFunc {
+	type request struct {
+		Count int `json:"count" validate:"omitempty,min=1"`
+	}
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		wishlistId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid wishlist id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		productId, err := uuid.Parse(c.Param("productId"))
+		if err != nil {
+			slog.Debug("invalid product id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		var requestData request
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		validate := validator.New()
+		err = validate.Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		if requestData.Count == 0 {
+			requestData.Count = 1
+		}
+		err = h.usecase.MoveToCart(c.Request().Context(), userId, wishlistId, productId, requestData.Count)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWishlistNotFound):
+				slog.Debug("wishlist not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrProductNotInWishlist):
+				slog.Debug("product is not in wishlist", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrProductNotInWishlistCode,
+					Message: ErrProductNotInWishlistMessage,
+				})
+			case errors.Is(err, usecase.ErrProductNotFound), errors.Is(err, usecase.ErrProductIsHidden):
+				slog.Debug("product not found", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrProductNotExistCode,
+					Message: ErrProductNotExistMessage,
+				})
+			case errors.Is(err, usecase.ErrOutOfStock):
+				slog.Debug("not enough stock", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrOutOfStockCode,
+					Message: ErrOutOfStockMessage,
+				})
+			default:
+				slog.Error("moving product to cart error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("product moved from wishlist to cart")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func (h *WishlistHandlers) moveProductFromCart() echo.HandlerFunc {
+	type request struct {
+		ProductId uuid.UUID `json:"product_id" validate:"required,uuid"`
+	}
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		wishlistId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid wishlist id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		var requestData request
+		err = c.Bind(&requestData)
+		if err != nil {
+			slog.Debug("invalid request", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrInvalidRequestCode,
+				Message: ErrInvalidRequestMessage,
+			})
+		}
+		validate := validator.New()
+		err = validate.Struct(requestData)
+		if err != nil {
+			slog.Debug("validation error", slog.Any("error", err))
+			return c.JSON(http.StatusBadRequest, ErrResponse{
+				Error:   ErrValidationErrorCode,
+				Message: ErrValidationErrorMessage,
+			})
+		}
+		err = h.usecase.MoveFromCart(c.Request().Context(), userId, wishlistId, requestData.ProductId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWishlistNotFound):
+				slog.Debug("wishlist not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			case errors.Is(err, usecase.ErrProductNotInCart):
+				slog.Debug("product is not in cart", slog.Any("error", err))
+				return c.JSON(http.StatusBadRequest, ErrResponse{
+					Error:   ErrProductNotInCartCode,
+					Message: ErrProductNotInCartMessage,
+				})
+			default:
+				slog.Error("moving product from cart error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("product moved from cart to wishlist")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func (h *WishlistHandlers) shareWishlist() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		wishlistId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid wishlist id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		sharedWishlist, err := h.usecase.Share(c.Request().Context(), userId, wishlistId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWishlistNotFound):
+				slog.Debug("wishlist not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("wishlist sharing error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("wishlist shared")
+		return c.JSON(http.StatusOK, sharedWishlist)
+	}
+}
+
+func (h *WishlistHandlers) unshareWishlist() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, ok := c.Get(UserIdContextKey).(uuid.UUID)
+		if !ok {
+			return c.JSON(http.StatusInternalServerError, ErrResponse{
+				Error:   ErrInternalErrorCode,
+				Message: ErrInternalErrorMessage,
+			})
+		}
+		wishlistId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			slog.Debug("invalid wishlist id", slog.Any("error", err))
+			return c.JSON(http.StatusNotFound, ErrResponse{
+				Error:   ErrResourceNotFoundCode,
+				Message: ErrResourceNotFoundMessage,
+			})
+		}
+		err = h.usecase.Unshare(c.Request().Context(), userId, wishlistId)
+		if err != nil {
+			switch {
+			case errors.Is(err, usecase.ErrWishlistNotFound):
+				slog.Debug("wishlist not found", slog.Any("error", err))
+				return c.JSON(http.StatusNotFound, ErrResponse{
+					Error:   ErrResourceNotFoundCode,
+					Message: ErrResourceNotFoundMessage,
+				})
+			default:
+				slog.Error("wishlist unsharing error", slog.Any("error", err))
+				return c.JSON(http.StatusInternalServerError, ErrResponse{
+					Error:   ErrInternalErrorCode,
+					Message: ErrInternalErrorMessage,
+				})
+			}
+		}
+		slog.Info("wishlist is no longer shared")
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func RegisterWishlistRoutes(u usecase.Wishlist, g *echo.Group) {
+	a := NewWishlistHandlers(u)
+	g.GET("", a.getAllWishlists())
+	g.POST("", a.createWishlist())
+	g.GET("/shared/:token", a.getSharedWishlist())
+	g.GET("/:id", a.getWishlistById())
+	g.PUT("/:id", a.updateWishlistById())
+	g.DELETE("/:id", a.deleteWishlistById())
+	g.POST("/:id/products", a.addProductToWishlist())
+	g.DELETE("/:id/products/:productId", a.deleteProductFromWishlist())
+	g.POST("/:id/products/:productId/move-to-cart", a.moveProductToCart())
+	g.POST("/:id/move-from-cart", a.moveProductFromCart())
+	g.POST("/:id/share", a.shareWishlist())
+	g.DELETE("/:id/share", a.unshareWishlist())
+}
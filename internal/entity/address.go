@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OrderAddressTypeShipping OrderAddressType = "shipping"
+	OrderAddressTypeBilling  OrderAddressType = "billing"
+)
+
+type (
+	// Address is an address from the address book of a user. Every user with addresses
+	// has exactly one default address.
+	Address struct {
+		Id         uuid.UUID `json:"id"`
+		UserId     uuid.UUID `json:"user_id"`
+		Label      string    `json:"label,omitempty"`
+		Recipient  string    `json:"recipient"`
+		Phone      string    `json:"phone"`
+		Country    string    `json:"country"`
+		Region     string    `json:"region,omitempty"`
+		City       string    `json:"city"`
+		Street     string    `json:"street"`
+		PostalCode string    `json:"postal_code"`
+		IsDefault  bool      `json:"is_default"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+
+	OrderAddressType string
+
+	// OrderAddress is a copy of an address made when the order is placed, so changes
+	// to the address book don't change placed orders.
+	OrderAddress struct {
+		Recipient  string `json:"recipient"`
+		Phone      string `json:"phone"`
+		Country    string `json:"country"`
+		Region     string `json:"region,omitempty"`
+		City       string `json:"city"`
+		Street     string `json:"street"`
+		PostalCode string `json:"postal_code"`
+	}
+)
+
+// ToOrderAddress returns the copy of the address to place an order with.
+func (a *Address) ToOrderAddress() *OrderAddress {
+	return &OrderAddress{
+		Recipient:  a.Recipient,
+		Phone:      a.Phone,
+		Country:    a.Country,
+		Region:     a.Region,
+		City:       a.City,
+		Street:     a.Street,
+		PostalCode: a.PostalCode,
+	}
+}
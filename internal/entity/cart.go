@@ -1,6 +1,61 @@
 package entity
 
-type Cart struct {
-	Product *Product `json:"product"`
-	Count   int      `json:"count"`
-}
+import "github.com/google/uuid"
+
+const (
+	CartWarningTypeProductHidden     CartWarningType = "product_hidden"
+	CartWarningTypePriceChanged      CartWarningType = "price_changed"
+	CartWarningTypeInsufficientStock CartWarningType = "insufficient_stock"
+	CartWarningTypePromoCode         CartWarningType = "promo_code_not_applicable"
+)
+
+type (
+	CartWarningType string
+
+	Cart struct {
+		Product *Product `json:"product"`
+		Count   int      `json:"count"`
+	}
+
+	// CartWarning explains why a cart line differs from what will be ordered.
+	CartWarning struct {
+		Type    CartWarningType `json:"type"`
+		Message string          `json:"message"`
+	}
+
+	// GuestCartLine is a line of an anonymous cart, Price is the product price
+	// when it was added.
+	GuestCartLine struct {
+		ProductId uuid.UUID `json:"product_id"`
+		Count     int       `json:"count"`
+		Price     Money     `json:"price"`
+	}
+
+	// CartPriceChange is a cart line of a user whose product price differs from
+	// the price when it was added.
+	CartPriceChange struct {
+		UserId         uuid.UUID `json:"user_id"`
+		ProductId      uuid.UUID `json:"product_id"`
+		Count          int       `json:"count"`
+		PriceWhenAdded Money     `json:"price_when_added"`
+		CurrentPrice   Money     `json:"current_price"`
+		ChangePercent  float64   `json:"change_percent"`
+	}
+
+	// CartSummary is the cart with its totals. Lines of hidden products aren't
+	// counted in ItemsCount and totals as they can't be ordered. PromoCodeWarning
+	// explains why the applied promo code gives no discount. The tax is added to
+	// Total unless PricesIncludeTax is set.
+	CartSummary struct {
+		Products         []*ProductInCart `json:"products"`
+		ItemsCount       int              `json:"items_count"`
+		Subtotal         Money            `json:"subtotal"`
+		Discount         Money            `json:"discount"`
+		Tax              Money            `json:"tax"`
+		PricesIncludeTax bool             `json:"prices_include_tax"`
+		Total            Money            `json:"total"`
+		PromoCode        *string          `json:"promo_code,omitempty"`
+		PromoCodeWarning *CartWarning     `json:"promo_code_warning,omitempty"`
+		HasWarnings      bool             `json:"has_warnings"`
+	}
+)
@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Category struct {
+	Id        uuid.UUID   `json:"id"`
+	ParentId  *uuid.UUID  `json:"parent_id"`
+	Name      string      `json:"name"`
+	CreatedAt time.Time   `json:"created_at"`
+	Children  []*Category `json:"children,omitempty"`
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+// ExchangeRate is the amount of Currency given for one unit of the base currency.
+type ExchangeRate struct {
+	Currency  Currency  `json:"currency"`
+	Rate      float64   `json:"rate"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
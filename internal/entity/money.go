@@ -0,0 +1,64 @@
+package entity
+
+import "math"
+
+const (
+	CurrencyRUB Currency = "RUB"
+	CurrencyKZT Currency = "KZT"
+	CurrencyBYN Currency = "BYN"
+
+	DefaultCurrency = CurrencyRUB
+)
+
+type (
+	Currency string
+
+	// Money is an amount in minor units (kopecks, tiyns) of the currency.
+	Money struct {
+		Amount   int64    `json:"amount"`
+		Currency Currency `json:"currency"`
+	}
+)
+
+func NewMoney(amount int64, currency Currency) Money {
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+	}
+}
+
+// Add and Sub expect both values to be in the same currency. A zero value without
+// currency takes the currency of the other operand.
+func (m Money) Add(other Money) Money {
+	currency := m.Currency
+	if currency == "" {
+		currency = other.Currency
+	}
+	return NewMoney(m.Amount+other.Amount, currency)
+}
+
+func (m Money) Sub(other Money) Money {
+	currency := m.Currency
+	if currency == "" {
+		currency = other.Currency
+	}
+	return NewMoney(m.Amount-other.Amount, currency)
+}
+
+func (m Money) Mul(n int) Money {
+	return NewMoney(m.Amount*int64(n), m.Currency)
+}
+
+// Convert multiplies the amount by rate and rounds the result to the nearest minor unit.
+func (m Money) Convert(to Currency, rate float64) Money {
+	return NewMoney(int64(math.Round(float64(m.Amount)*rate)), to)
+}
+
+// ChangePercent is the change from m to other in percent of m, rounded to one decimal place.
+// It's negative when the amount dropped and zero when m is zero.
+func (m Money) ChangePercent(other Money) float64 {
+	if m.Amount == 0 {
+		return 0
+	}
+	return math.Round(float64(other.Amount-m.Amount)*1000/float64(m.Amount)) / 10
+}
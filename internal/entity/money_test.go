@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney_Add(t *testing.T) {
+	testTable := []struct {
+		name     string
+		first    Money
+		second   Money
+		expected Money
+	}{
+		{
+			name:     "same currency",
+			first:    NewMoney(4999999, CurrencyRUB),
+			second:   NewMoney(1, CurrencyRUB),
+			expected: NewMoney(5000000, CurrencyRUB),
+		},
+		{
+			name:     "zero value takes currency",
+			first:    Money{},
+			second:   NewMoney(1250, CurrencyKZT),
+			expected: NewMoney(1250, CurrencyKZT),
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.first.Add(testCase.second))
+		})
+	}
+}
+
+func TestMoney_Mul(t *testing.T) {
+	assert.Equal(t, NewMoney(14999997, CurrencyRUB), NewMoney(4999999, CurrencyRUB).Mul(3))
+}
+
+func TestMoney_Convert(t *testing.T) {
+	testTable := []struct {
+		name     string
+		money    Money
+		to       Currency
+		rate     float64
+		expected Money
+	}{
+		{
+			name:     "rub to kzt",
+			money:    NewMoney(4999999, CurrencyRUB),
+			to:       CurrencyKZT,
+			rate:     5.9,
+			expected: NewMoney(29499994, CurrencyKZT),
+		},
+		{
+			name:     "rub to byn rounds to nearest minor unit",
+			money:    NewMoney(1219100, CurrencyRUB),
+			to:       CurrencyBYN,
+			rate:     0.0371,
+			expected: NewMoney(45229, CurrencyBYN),
+		},
+		{
+			name:     "same currency",
+			money:    NewMoney(100, CurrencyRUB),
+			to:       CurrencyRUB,
+			rate:     1,
+			expected: NewMoney(100, CurrencyRUB),
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.money.Convert(testCase.to, testCase.rate))
+		})
+	}
+}
+
+func TestMoney_ChangePercent(t *testing.T) {
+	testTable := []struct {
+		name     string
+		from     Money
+		to       Money
+		expected float64
+	}{
+		{
+			name:     "dropped",
+			from:     NewMoney(1000000, CurrencyRUB),
+			to:       NewMoney(900000, CurrencyRUB),
+			expected: -10,
+		},
+		{
+			name:     "rose and rounded",
+			from:     NewMoney(300000, CurrencyRUB),
+			to:       NewMoney(400000, CurrencyRUB),
+			expected: 33.3,
+		},
+		{
+			name:     "from zero",
+			from:     NewMoney(0, CurrencyRUB),
+			to:       NewMoney(400000, CurrencyRUB),
+			expected: 0,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.from.ChangePercent(testCase.to))
+		})
+	}
+}
@@ -8,19 +8,40 @@ import (
 
 const (
 	OrderStatusNew        OrderStatus = "new"
+	OrderStatusPaid       OrderStatus = "paid"
 	OrderStatusInProgress OrderStatus = "in_progress"
+	OrderStatusShipped    OrderStatus = "shipped"
+	OrderStatusDelivered  OrderStatus = "delivered"
 	OrderStatusDone       OrderStatus = "done"
+	OrderStatusCancelled  OrderStatus = "cancelled"
+	OrderStatusRefunded   OrderStatus = "refunded"
 )
 
 type (
 	OrderStatus string
 
 	Order struct {
-		Id        uuid.UUID        `json:"id"`
-		UserId    uuid.UUID        `json:"user_id"`
-		Status    OrderStatus      `json:"status"`
-		CreatedAt time.Time        `json:"created_at"`
-		Products  []*ProductInCart `json:"products"`
+		Id               uuid.UUID        `json:"id"`
+		UserId           uuid.UUID        `json:"user_id"`
+		Status           OrderStatus      `json:"status"`
+		CreatedAt        time.Time        `json:"created_at"`
+		Products         []*ProductInCart `json:"products"`
+		ItemsCount       int              `json:"items_count"`
+		Currency         Currency         `json:"currency"`
+		ExchangeRate     float64          `json:"exchange_rate"`
+		PromoCodeId      *uuid.UUID       `json:"promo_code_id,omitempty"`
+		PromoCode        *string          `json:"promo_code,omitempty"`
+		Subtotal         Money            `json:"subtotal"`
+		Discount         Money            `json:"discount"`
+		Tax              Money            `json:"tax"`
+		PricesIncludeTax bool             `json:"prices_include_tax"`
+		ShippingMethod   string           `json:"shipping_method,omitempty"`
+		Shipping         Money            `json:"shipping"`
+		Total            Money            `json:"total"`
+		ReservedUntil    *time.Time       `json:"reserved_until,omitempty"`
+		ShippingAddress  *OrderAddress    `json:"shipping_address,omitempty"`
+		BillingAddress   *OrderAddress    `json:"billing_address,omitempty"`
+		Shipments        []*Shipment      `json:"shipments,omitempty"`
 	}
 	OrderFilter struct {
 		UserId *uuid.UUID   `json:"user_id"`
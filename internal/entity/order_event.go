@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OrderEventTypeCreated       OrderEventType = "created"
+	OrderEventTypeUpdated       OrderEventType = "updated"
+	OrderEventTypeStatusChanged OrderEventType = "status_changed"
+)
+
+type (
+	OrderEventType string
+
+	OrderEvent struct {
+		Id         uuid.UUID      `json:"id"`
+		OrderId    uuid.UUID      `json:"order_id"`
+		UserId     *uuid.UUID     `json:"user_id"`
+		Type       OrderEventType `json:"type"`
+		FromStatus *OrderStatus   `json:"from_status"`
+		ToStatus   *OrderStatus   `json:"to_status"`
+		Comment    string         `json:"comment"`
+		CreatedAt  time.Time      `json:"created_at"`
+	}
+)
@@ -0,0 +1,58 @@
+package entity
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+const (
+	SortByCreatedAt SortBy = "created_at"
+	SortByPrice     SortBy = "price"
+	SortByName      SortBy = "name"
+	SortByRelevance SortBy = "relevance"
+)
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+type (
+	SortBy    string
+	SortOrder string
+
+	// Pagination requests one page of a list. Cursor is the opaque next_cursor
+	// of the previous page, empty for the first page.
+	Pagination struct {
+		Limit  int       `json:"limit"`
+		Cursor string    `json:"cursor"`
+		SortBy SortBy    `json:"sort_by"`
+		Order  SortOrder `json:"order"`
+	}
+
+	// PageInfo is the list metadata. NextCursor is empty on the last page.
+	PageInfo struct {
+		NextCursor string `json:"next_cursor,omitempty"`
+		TotalCount int    `json:"total_count"`
+	}
+
+	ProductPage struct {
+		Items []*Product `json:"items"`
+		PageInfo
+	}
+
+	ProducerPage struct {
+		Items []*Producer `json:"items"`
+		PageInfo
+	}
+
+	OrderPage struct {
+		Items []*Order `json:"items"`
+		PageInfo
+	}
+
+	UserPage struct {
+		Items []*User `json:"items"`
+		PageInfo
+	}
+)
@@ -15,21 +15,59 @@ type (
 	ProductStatus string
 
 	Product struct {
-		Id        uuid.UUID     `json:"id"`
-		Name      string        `json:"name"`
-		Price     float32       `jsone:"price"`
-		Producer  *Producer     `json:"producer"`
-		Status    ProductStatus `json:"status"`
-		CreatedAt time.Time     `json:"created_at"`
+		Id          uuid.UUID     `json:"id"`
+		Name        string        `json:"name"`
+		Price       Money         `json:"price"`
+		Producer    *Producer     `json:"producer"`
+		Category    *Category     `json:"category"`
+		Status      ProductStatus `json:"status"`
+		Specs       *ProductSpecs `json:"specs"`
+		Stock       *int          `json:"stock,omitempty"`
+		StockStatus StockStatus   `json:"stock_status"`
+		TaxClass    string        `json:"tax_class,omitempty"`
+		Weight      int           `json:"weight,omitempty"`
+		Dimensions  *Dimensions   `json:"dimensions,omitempty"`
+		CreatedAt   time.Time     `json:"created_at"`
+		Highlight   string        `json:"highlight,omitempty"`
+	}
+
+	// Dimensions of a packed product in millimeters.
+	Dimensions struct {
+		Length int `json:"length"`
+		Width  int `json:"width"`
+		Height int `json:"height"`
 	}
 
 	ProductInCart struct {
-		Product *Product `json:"product"`
-		Count   int      `json:"count"`
+		Product        *Product            `json:"product"`
+		Count          int                 `json:"count"`
+		Total          Money               `json:"total"`
+		Discount       *Money              `json:"discount,omitempty"`
+		Promotions     []*AppliedPromotion `json:"promotions,omitempty"`
+		TaxRate        *float64            `json:"tax_rate,omitempty"`
+		Tax            *Money              `json:"tax,omitempty"`
+		PriceWhenAdded *Money              `json:"price_when_added,omitempty"`
+		CurrentPrice   *Money              `json:"current_price,omitempty"`
+		PriceChange    *float64            `json:"price_change_percent,omitempty"`
+		Warnings       []*CartWarning      `json:"warnings,omitempty"`
+		Allocations    []*OrderAllocation  `json:"allocations,omitempty"`
 	}
 
 	ProductFilter struct {
-		ProducerId *uuid.UUID     `json:"producer_id"`
-		Status     *ProductStatus `json:"status"`
+		Query             *string          `json:"q"`
+		ProducerId        *uuid.UUID       `json:"producer_id"`
+		Status            *ProductStatus   `json:"status"`
+		CategoryId        *uuid.UUID       `json:"category_id"`
+		PrintTechnology   *PrintTechnology `json:"print_technology"`
+		Color             *bool            `json:"color"`
+		MaxFormat         *PaperFormat     `json:"max_format"`
+		MinDpi            *int             `json:"min_dpi"`
+		MinPagesPerMinute *int             `json:"min_pages_per_minute"`
+		Duplex            *bool            `json:"duplex"`
+		WiFi              *bool            `json:"wifi"`
+		Ethernet          *bool            `json:"ethernet"`
+		Scan              *bool            `json:"scan"`
+		Copy              *bool            `json:"copy"`
+		Ids               []uuid.UUID      `json:"-"`
 	}
 )
@@ -0,0 +1,30 @@
+package entity
+
+const (
+	PrintTechnologyLaser   PrintTechnology = "laser"
+	PrintTechnologyInkjet  PrintTechnology = "inkjet"
+	PrintTechnologyThermal PrintTechnology = "thermal"
+)
+
+const (
+	PaperFormatA4 PaperFormat = "A4"
+	PaperFormatA3 PaperFormat = "A3"
+)
+
+type (
+	PrintTechnology string
+	PaperFormat     string
+
+	ProductSpecs struct {
+		PrintTechnology PrintTechnology `json:"print_technology"`
+		Color           bool            `json:"color"`
+		MaxFormat       PaperFormat     `json:"max_format"`
+		Dpi             int             `json:"dpi"`
+		PagesPerMinute  int             `json:"pages_per_minute"`
+		Duplex          bool            `json:"duplex"`
+		WiFi            bool            `json:"wifi"`
+		Ethernet        bool            `json:"ethernet"`
+		Scan            bool            `json:"scan"`
+		Copy            bool            `json:"copy"`
+	}
+)
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DiscountTypePercentage DiscountType = "percentage"
+	DiscountTypeFixed      DiscountType = "fixed"
+)
+
+const (
+	PromoCodeScopeProduct  PromoCodeScopeType = "product"
+	PromoCodeScopeProducer PromoCodeScopeType = "producer"
+	PromoCodeScopeCategory PromoCodeScopeType = "category"
+)
+
+type (
+	DiscountType       string
+	PromoCodeScopeType string
+
+	// PromoCode is a discount a user applies to the cart by its code. DiscountValue is the
+	// percent off for percentage discounts and the amount off in minor units of Currency
+	// for fixed ones. A code without scopes applies to all products.
+	PromoCode struct {
+		Id                uuid.UUID         `json:"id"`
+		Code              string            `json:"code"`
+		Description       string            `json:"description"`
+		DiscountType      DiscountType      `json:"discount_type"`
+		DiscountValue     int64             `json:"discount_value"`
+		MinOrderAmount    int64             `json:"min_order_amount"`
+		Currency          Currency          `json:"currency"`
+		StartsAt          *time.Time        `json:"starts_at"`
+		EndsAt            *time.Time        `json:"ends_at"`
+		UsageLimit        *int              `json:"usage_limit"`
+		UsageLimitPerUser *int              `json:"usage_limit_per_user"`
+		Scopes            []*PromoCodeScope `json:"scopes"`
+		CreatedAt         time.Time         `json:"created_at"`
+	}
+
+	// PromoCodeScope limits a promo code to a product, the products of a producer or
+	// the products of a category and its subcategories.
+	PromoCodeScope struct {
+		Type PromoCodeScopeType `json:"type"`
+		Id   uuid.UUID          `json:"id"`
+	}
+)
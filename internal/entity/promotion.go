@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PromotionTypeBundle     PromotionType = "bundle"
+	PromotionTypeBuyXGetY   PromotionType = "buy_x_get_y"
+	PromotionTypeVolumeTier PromotionType = "volume_tier"
+)
+
+type (
+	PromotionType string
+
+	// Promotion is a discount applied automatically to the cart lines which meet its rule.
+	// A bundle gives Percent off every complete set of its Products with their counts.
+	// Buy X get Y makes FreeCount of every BuyCount+FreeCount items of each of its Products
+	// free. Volume tiers lower the unit price of each of its Products to the price of the
+	// highest tier the line count reaches, tier prices are in minor units of Currency.
+	// Promotions with lower Priority are applied first, an item is discounted by one
+	// promotion at most.
+	Promotion struct {
+		Id        uuid.UUID           `json:"id"`
+		Name      string              `json:"name"`
+		Type      PromotionType       `json:"type"`
+		Priority  int                 `json:"priority"`
+		Products  []*PromotionProduct `json:"products"`
+		Percent   int                 `json:"percent,omitempty"`
+		BuyCount  int                 `json:"buy_count,omitempty"`
+		FreeCount int                 `json:"free_count,omitempty"`
+		Tiers     []*PromotionTier    `json:"tiers,omitempty"`
+		Currency  Currency            `json:"currency"`
+		Active    bool                `json:"active"`
+		StartsAt  *time.Time          `json:"starts_at"`
+		EndsAt    *time.Time          `json:"ends_at"`
+		CreatedAt time.Time           `json:"created_at"`
+	}
+
+	// PromotionProduct is a product of a promotion, Count is only used by bundles.
+	PromotionProduct struct {
+		ProductId uuid.UUID `json:"product_id"`
+		Count     int       `json:"count"`
+	}
+
+	PromotionTier struct {
+		MinCount int   `json:"min_count"`
+		Price    int64 `json:"price"`
+	}
+
+	// AppliedPromotion explains the part of a line discount given by a promotion.
+	// PromotionId is absent when the promotion was deleted after the order was placed.
+	AppliedPromotion struct {
+		PromotionId *uuid.UUID    `json:"promotion_id,omitempty"`
+		Name        string        `json:"name"`
+		Type        PromotionType `json:"type"`
+		Discount    Money         `json:"discount"`
+	}
+)
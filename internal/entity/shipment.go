@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// Shipment is a parcel of the order handed to a carrier, an order can be shipped in several
+	// shipments each carrying a part of its lines.
+	Shipment struct {
+		Id             uuid.UUID       `json:"id"`
+		OrderId        uuid.UUID       `json:"order_id"`
+		Carrier        string          `json:"carrier"`
+		TrackingNumber string          `json:"tracking_number"`
+		Lines          []*ShipmentLine `json:"lines"`
+		ShippedAt      time.Time       `json:"shipped_at"`
+		CreatedAt      time.Time       `json:"created_at"`
+	}
+
+	ShipmentLine struct {
+		ProductId uuid.UUID `json:"product_id"`
+		Count     int       `json:"count"`
+	}
+)
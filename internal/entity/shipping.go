@@ -0,0 +1,53 @@
+package entity
+
+const (
+	ShippingCalculatorFlat              ShippingCalculatorType = "flat"
+	ShippingCalculatorWeightTable       ShippingCalculatorType = "weight_table"
+	ShippingCalculatorFreeOverThreshold ShippingCalculatorType = "free_over_threshold"
+)
+
+type (
+	ShippingCalculatorType string
+
+	// ShippingMethod is a way to deliver orders, like a courier or a pickup point. The cost
+	// is calculated by the first rate which covers the region of the address, the method
+	// isn't available in regions no rate covers. Prices are in Currency.
+	ShippingMethod struct {
+		Code       string
+		Name       string
+		Calculator ShippingCalculatorType
+		Currency   Currency
+		Rates      []*ShippingRate
+	}
+
+	// ShippingRate prices shipping to the regions, a rate without regions covers every region.
+	// Price is used by the flat calculator and by the free over threshold one for parcels
+	// cheaper than Threshold. Weights are used by the weight table calculator.
+	ShippingRate struct {
+		Regions   []string
+		Price     int64
+		Weights   []*ShippingWeightPrice
+		Threshold int64
+	}
+
+	// ShippingWeightPrice is the price of parcels up to MaxWeight grams.
+	ShippingWeightPrice struct {
+		MaxWeight int
+		Price     int64
+	}
+
+	// Parcel is what is shipped: the weight of the products in grams, the amount paid for them
+	// and where it goes.
+	Parcel struct {
+		Weight int
+		Amount Money
+		Region string
+		City   string
+	}
+
+	ShippingQuote struct {
+		Method string `json:"method"`
+		Name   string `json:"name"`
+		Cost   Money  `json:"cost"`
+	}
+)
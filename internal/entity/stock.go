@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LowStockThreshold is the stock level at and below which a product is shown as low on stock.
+const LowStockThreshold = 5
+
+const (
+	StockStatusInStock    StockStatus = "in_stock"
+	StockStatusLowStock   StockStatus = "low_stock"
+	StockStatusOutOfStock StockStatus = "out_of_stock"
+)
+
+const (
+	StockMovementTypeAdjustment     StockMovementType = "adjustment"
+	StockMovementTypeOrderPlaced    StockMovementType = "order_placed"
+	StockMovementTypeOrderChanged   StockMovementType = "order_changed"
+	StockMovementTypeOrderCancelled StockMovementType = "order_cancelled"
+	StockMovementTypeOrderDeleted   StockMovementType = "order_deleted"
+)
+
+type (
+	StockStatus       string
+	StockMovementType string
+
+	// StockMovement is a change of product stock. Stock is the stock after the change,
+	// in the warehouse when WarehouseId is set, otherwise of the whole product.
+	StockMovement struct {
+		Id          uuid.UUID         `json:"id"`
+		ProductId   uuid.UUID         `json:"product_id"`
+		WarehouseId *uuid.UUID        `json:"warehouse_id"`
+		Delta       int               `json:"delta"`
+		Stock       int               `json:"stock"`
+		Type        StockMovementType `json:"type"`
+		Reason      string            `json:"reason"`
+		OrderId     *uuid.UUID        `json:"order_id"`
+		UserId      *uuid.UUID        `json:"user_id"`
+		CreatedAt   time.Time         `json:"created_at"`
+	}
+)
+
+// NewStockStatus returns the status for the stock level, nil stock means the product stock is not tracked.
+func NewStockStatus(stock *int) StockStatus {
+	switch {
+	case stock == nil:
+		return StockStatusInStock
+	case *stock <= 0:
+		return StockStatusOutOfStock
+	case *stock <= LowStockThreshold:
+		return StockStatusLowStock
+	default:
+		return StockStatusInStock
+	}
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStockStatus(t *testing.T) {
+	stock := func(v int) *int {
+		return &v
+	}
+	testTable := []struct {
+		name           string
+		stock          *int
+		expectedStatus StockStatus
+	}{
+		{
+			name:           "not tracked",
+			stock:          nil,
+			expectedStatus: StockStatusInStock,
+		},
+		{
+			name:           "empty",
+			stock:          stock(0),
+			expectedStatus: StockStatusOutOfStock,
+		},
+		{
+			name:           "low",
+			stock:          stock(LowStockThreshold),
+			expectedStatus: StockStatusLowStock,
+		},
+		{
+			name:           "in stock",
+			stock:          stock(LowStockThreshold + 1),
+			expectedStatus: StockStatusInStock,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedStatus, NewStockStatus(testCase.stock))
+		})
+	}
+}
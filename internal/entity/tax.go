@@ -0,0 +1,39 @@
+package entity
+
+import "math"
+
+type (
+	// TaxSettings are the VAT rates in percent by tax class. Products without a class are
+	// taxed at the rate of DefaultClass. Product prices include the tax when PricesIncludeTax
+	// is set, otherwise the tax is added on top of them.
+	TaxSettings struct {
+		PricesIncludeTax bool
+		DefaultClass     string
+		Rates            map[string]float64
+	}
+)
+
+// HasClass reports whether the tax class has a rate.
+func (s TaxSettings) HasClass(class string) bool {
+	_, ok := s.Rates[class]
+	return ok
+}
+
+// Rate returns the rate of the tax class in percent, unknown classes get the default rate.
+func (s TaxSettings) Rate(class string) float64 {
+	if rate, ok := s.Rates[class]; ok && class != "" {
+		return rate
+	}
+	return s.Rates[s.DefaultClass]
+}
+
+// Tax returns the VAT at the rate on the amount, the amount includes the tax when prices include it.
+func (s TaxSettings) Tax(amount Money, rate float64) Money {
+	var tax float64
+	if s.PricesIncludeTax {
+		tax = float64(amount.Amount) * rate / (100 + rate)
+	} else {
+		tax = float64(amount.Amount) * rate / 100
+	}
+	return NewMoney(int64(math.Round(tax)), amount.Currency)
+}
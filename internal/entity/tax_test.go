@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaxSettings_Tax(t *testing.T) {
+	rates := map[string]float64{"standard": 20, "reduced": 10, "zero": 0}
+	testTable := []struct {
+		name     string
+		settings TaxSettings
+		class    string
+		amount   Money
+		expected Money
+	}{
+		{
+			name:     "added to price",
+			settings: TaxSettings{DefaultClass: "standard", Rates: rates},
+			class:    "reduced",
+			amount:   NewMoney(4999999, CurrencyRUB),
+			expected: NewMoney(500000, CurrencyRUB),
+		},
+		{
+			name:     "included in price",
+			settings: TaxSettings{PricesIncludeTax: true, DefaultClass: "standard", Rates: rates},
+			class:    "standard",
+			amount:   NewMoney(1200000, CurrencyRUB),
+			expected: NewMoney(200000, CurrencyRUB),
+		},
+		{
+			name:     "no class taxed at default rate",
+			settings: TaxSettings{DefaultClass: "standard", Rates: rates},
+			class:    "",
+			amount:   NewMoney(1000, CurrencyKZT),
+			expected: NewMoney(200, CurrencyKZT),
+		},
+		{
+			name:     "zero rate",
+			settings: TaxSettings{DefaultClass: "standard", Rates: rates},
+			class:    "zero",
+			amount:   NewMoney(1000, CurrencyRUB),
+			expected: NewMoney(0, CurrencyRUB),
+		},
+		{
+			name:     "no rates",
+			settings: TaxSettings{},
+			class:    "standard",
+			amount:   NewMoney(1000, CurrencyRUB),
+			expected: NewMoney(0, CurrencyRUB),
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			rate := testCase.settings.Rate(testCase.class)
+			assert.Equal(t, testCase.expected, testCase.settings.Tax(testCase.amount, rate))
+		})
+	}
+}
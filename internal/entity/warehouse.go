@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// Warehouse is a place products are shipped from. Warehouses with lower
+	// Priority are preferred when an order is allocated.
+	Warehouse struct {
+		Id        uuid.UUID `json:"id"`
+		Name      string    `json:"name"`
+		City      string    `json:"city"`
+		Priority  int       `json:"priority"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	WarehouseStock struct {
+		WarehouseId   uuid.UUID `json:"warehouse_id"`
+		WarehouseName string    `json:"warehouse_name"`
+		ProductId     uuid.UUID `json:"product_id"`
+		ProductName   string    `json:"product_name"`
+		Quantity      int       `json:"quantity"`
+	}
+	WarehouseStockFilter struct {
+		WarehouseId *uuid.UUID  `json:"warehouse_id"`
+		ProductIds  []uuid.UUID `json:"product_ids"`
+	}
+
+	// OrderAllocation is the part of an order line picked from one warehouse.
+	OrderAllocation struct {
+		WarehouseId   uuid.UUID `json:"warehouse_id"`
+		WarehouseName string    `json:"warehouse_name"`
+		Count         int       `json:"count"`
+	}
+)
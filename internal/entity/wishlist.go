@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// Wishlist is a named list of products a user saved for later. A wishlist with
+	// ShareToken can be viewed by anyone who has the token.
+	Wishlist struct {
+		Id         uuid.UUID  `json:"id"`
+		UserId     uuid.UUID  `json:"user_id"`
+		Name       string     `json:"name"`
+		ShareToken *uuid.UUID `json:"share_token,omitempty"`
+		Products   []*Product `json:"products"`
+		CreatedAt  time.Time  `json:"created_at"`
+	}
+)
@@ -0,0 +1,153 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	_ "github.com/lib/pq"
+)
+
+type AddressRepoPg struct {
+	db *sql.DB
+}
+
+func NewAddressRepoPg(db *sql.DB) Address {
+	return &AddressRepoPg{
+		db: db,
+	}
+}
+
+const addressSelect = "select id, user_id, label, recipient, phone, country, region, city, street, postal_code, is_default, created_at from addresses"
+
+// GetAllByUserId returns the addresses of the user, the default one first.
+func (a *AddressRepoPg) GetAllByUserId(ctx context.Context, userId uuid.UUID) ([]*entity.Address, error) {
+	rows, err := a.db.QueryContext(ctx, addressSelect+" where user_id = $1 order by is_default desc, created_at", userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	addresses := []*entity.Address{}
+	for rows.Next() {
+		address, err := a.scanAddress(rows)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, rows.Err()
+}
+
+func (a *AddressRepoPg) GetById(ctx context.Context, id uuid.UUID) (*entity.Address, error) {
+	address, err := a.scanAddress(a.db.QueryRowContext(ctx, addressSelect+" where id = $1", id))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrAddressNotFound
+		default:
+			return nil, err
+		}
+	}
+	return address, nil
+}
+
+// Create saves the address, a default address replaces the previous default address of the user.
+func (a *AddressRepoPg) Create(ctx context.Context, address entity.Address) error {
+	tx, err := a.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if address.IsDefault {
+		err = unsetDefaultAddress(ctx, tx, address.UserId)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = tx.ExecContext(ctx,
+		"insert into addresses (id, user_id, label, recipient, phone, country, region, city, street, postal_code, is_default, created_at) "+
+			"values ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)",
+		address.Id, address.UserId, address.Label, address.Recipient, address.Phone, address.Country, address.Region,
+		address.City, address.Street, address.PostalCode, address.IsDefault, address.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Update replaces the address, a default address replaces the previous default address of the user.
+func (a *AddressRepoPg) Update(ctx context.Context, address entity.Address) error {
+	tx, err := a.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if address.IsDefault {
+		err = unsetDefaultAddress(ctx, tx, address.UserId)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = tx.ExecContext(ctx,
+		"update addresses set label = $1, recipient = $2, phone = $3, country = $4, region = $5, city = $6, street = $7, "+
+			"postal_code = $8, is_default = $9 where id = $10",
+		address.Label, address.Recipient, address.Phone, address.Country, address.Region, address.City, address.Street,
+		address.PostalCode, address.IsDefault, address.Id)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteById deletes the address. When it was the default one, the oldest remaining address
+// of the user becomes the default.
+func (a *AddressRepoPg) DeleteById(ctx context.Context, id uuid.UUID) error {
+	tx, err := a.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var userId uuid.UUID
+	var isDefault bool
+	err = tx.QueryRowContext(ctx, "delete from addresses where id = $1 returning user_id, is_default", id).Scan(&userId, &isDefault)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrAddressNotFound
+		default:
+			return err
+		}
+	}
+	if isDefault {
+		_, err = tx.ExecContext(ctx,
+			"update addresses set is_default = true where id = (select id from addresses where user_id = $1 order by created_at, id limit 1)",
+			userId)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func unsetDefaultAddress(ctx context.Context, tx *sql.Tx, userId uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, "update addresses set is_default = false where user_id = $1 and is_default", userId)
+	return err
+}
+
+func (a *AddressRepoPg) scanAddress(row Row) (*entity.Address, error) {
+	var createdAt string
+	address := new(entity.Address)
+	err := row.Scan(&address.Id, &address.UserId, &address.Label, &address.Recipient, &address.Phone, &address.Country,
+		&address.Region, &address.City, &address.Street, &address.PostalCode, &address.IsDefault, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	address.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	return address, nil
+}
@@ -24,7 +24,7 @@ func NewCartRepoPg(db *sql.DB) Cart {
 func (c *CartRepoPg) GetAllProducts(ctx context.Context, userId uuid.UUID) ([]*entity.ProductInCart, error) {
 	rows, err := c.db.QueryContext(ctx,
 		"select "+
-			"products.id,products.name,products.price,products.status,products.created_at,producers.id,producers.name,producers.description,producers.created_at, carts.count "+
+			"products.id,products.name,products.price,products.currency,products.status,products.stock,products.tax_class,products.weight,products.length,products.width,products.height,products.created_at,producers.id,producers.name,producers.description,producers.created_at, carts.count, carts.price, carts.currency "+
 			"from "+
 			"carts join products on carts.product_id = products.id join producers on producers.id = producer_id "+
 			"where "+
@@ -44,14 +44,46 @@ func (c *CartRepoPg) GetAllProducts(ctx context.Context, userId uuid.UUID) ([]*e
 	return productsInCart, nil
 }
 
-func (c *CartRepoPg) AddProduct(ctx context.Context, userId uuid.UUID, productId uuid.UUID, count int) error {
-	_, err := c.db.ExecContext(ctx, "insert into carts (user_id, product_id, count) values ($1,$2,$3)", userId, productId, count)
+// AddProduct puts the product in the cart, price is the product price at the moment it's added.
+func (c *CartRepoPg) AddProduct(ctx context.Context, userId uuid.UUID, productId uuid.UUID, count int, price entity.Money) error {
+	_, err := c.db.ExecContext(ctx, "insert into carts (user_id, product_id, count, price, currency) values ($1,$2,$3,$4,$5)",
+		userId, productId, count, price.Amount, price.Currency)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// AddLines adds the lines to the cart in one transaction. Counts of products which are already
+// in the cart are added up and the price when added is kept, other products are put at the line price.
+func (c *CartRepoPg) AddLines(ctx context.Context, userId uuid.UUID, lines []*entity.GuestCartLine) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, line := range lines {
+		result, err := tx.ExecContext(ctx, "update carts set count = count + $1 where user_id = $2 and product_id = $3",
+			line.Count, userId, line.ProductId)
+		if err != nil {
+			return err
+		}
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if updated != 0 {
+			continue
+		}
+		_, err = tx.ExecContext(ctx, "insert into carts (user_id, product_id, count, price, currency) values ($1,$2,$3,$4,$5)",
+			userId, line.ProductId, line.Count, line.Price.Amount, line.Price.Currency)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 func (c *CartRepoPg) UpdateCount(ctx context.Context, userId uuid.UUID, productId uuid.UUID, count int) error {
 	_, err := c.db.ExecContext(ctx, "update carts set count = $1 where user_id = $2 and product_id = $3", count, userId, productId)
 	if err != nil {
@@ -96,25 +128,68 @@ func (c *CartRepoPg) CheckIfExistsById(ctx context.Context, productId uuid.UUID)
 	return false, nil
 }
 
+// GetPriceChanges returns the lines of the product whose price when added differs from price.
+func (c *CartRepoPg) GetPriceChanges(ctx context.Context, productId uuid.UUID, price entity.Money) ([]*entity.CartPriceChange, error) {
+	rows, err := c.db.QueryContext(ctx,
+		"select user_id, product_id, count, price, currency from carts "+
+			"where product_id = $1 and (price <> $2 or currency <> $3) order by user_id",
+		productId, price.Amount, price.Currency)
+	if err != nil {
+		return nil, err
+	}
+	changes := []*entity.CartPriceChange{}
+	for rows.Next() {
+		change := new(entity.CartPriceChange)
+		err = rows.Scan(&change.UserId, &change.ProductId, &change.Count, &change.PriceWhenAdded.Amount, &change.PriceWhenAdded.Currency)
+		if err != nil {
+			return nil, err
+		}
+		change.CurrentPrice = price
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
 func (c *CartRepoPg) scanProductInCart(row Row) (*entity.ProductInCart, error) {
 	var productCreatedAt string
 	var producerCreatedAt string
+	var stock sql.NullInt64
+	var taxClass sql.NullString
+	var weight, length, width, height sql.NullInt64
 	productInCart := new(entity.ProductInCart)
 	product := new(entity.Product)
 	producer := new(entity.Producer)
+	priceWhenAdded := new(entity.Money)
 	err := row.Scan(&product.Id,
 		&product.Name,
-		&product.Price,
+		&product.Price.Amount,
+		&product.Price.Currency,
 		&product.Status,
+		&stock,
+		&taxClass,
+		&weight,
+		&length,
+		&width,
+		&height,
 		&productCreatedAt,
 		&producer.Id,
 		&producer.Name,
 		&producer.Description,
 		&producerCreatedAt,
-		&productInCart.Count)
+		&productInCart.Count,
+		&priceWhenAdded.Amount,
+		&priceWhenAdded.Currency)
 	if err != nil {
 		return nil, err
 	}
+	if stock.Valid {
+		productStock := int(stock.Int64)
+		product.Stock = &productStock
+	}
+	product.StockStatus = entity.NewStockStatus(product.Stock)
+	product.TaxClass = taxClass.String
+	setProductSize(product, weight, length, width, height)
+	productInCart.PriceWhenAdded = priceWhenAdded
 	product.CreatedAt, err = time.Parse(time.RFC3339, productCreatedAt)
 	if err != nil {
 		return nil, err
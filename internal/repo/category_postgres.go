@@ -0,0 +1,96 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	_ "github.com/lib/pq"
+)
+
+type CategoryRepoPg struct {
+	db *sql.DB
+}
+
+func NewCategoryRepoPg(db *sql.DB) Category {
+	return &CategoryRepoPg{
+		db: db,
+	}
+}
+
+func (c *CategoryRepoPg) GetAll(ctx context.Context) ([]*entity.Category, error) {
+	rows, err := c.db.QueryContext(ctx, "select id, parent_id, name, created_at from categories order by name")
+	if err != nil {
+		return nil, err
+	}
+	categories := []*entity.Category{}
+	for rows.Next() {
+		category, err := c.scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	return categories, nil
+}
+
+func (c *CategoryRepoPg) GetById(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
+	row := c.db.QueryRowContext(ctx, "select id, parent_id, name, created_at from categories where id = $1", id)
+	category, err := c.scanCategory(row)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrCategoryNotFound
+		default:
+			return nil, err
+		}
+	}
+	return category, nil
+}
+
+func (c *CategoryRepoPg) Create(ctx context.Context, category entity.Category) error {
+	_, err := c.db.ExecContext(ctx, "insert into categories (id, parent_id, name, created_at) values ($1,$2,$3,$4)",
+		category.Id, category.ParentId, category.Name, category.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *CategoryRepoPg) Update(ctx context.Context, category entity.Category) error {
+	_, err := c.db.ExecContext(ctx, "update categories set name = $1, parent_id = $2 where id = $3",
+		category.Name, category.ParentId, category.Id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *CategoryRepoPg) DeleteById(ctx context.Context, id uuid.UUID) error {
+	_, err := c.db.ExecContext(ctx, "delete from categories where id = $1", id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *CategoryRepoPg) scanCategory(row Row) (*entity.Category, error) {
+	var dateStr string
+	var parentId uuid.NullUUID
+	category := new(entity.Category)
+	err := row.Scan(&category.Id, &parentId, &category.Name, &dateStr)
+	if err != nil {
+		return nil, err
+	}
+	if parentId.Valid {
+		category.ParentId = &parentId.UUID
+	}
+	category.CreatedAt, err = time.Parse(time.RFC3339, dateStr)
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
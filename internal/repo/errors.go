@@ -9,3 +9,18 @@ var ErrProducerNotFound = errors.New("producer not found")
 var ErrProductNotFound = errors.New("product not found")
 var ErrTokenNotFound = errors.New("token not found")
 var ErrOrderNotFound = errors.New("order not found")
+var ErrExchangeRateNotFound = errors.New("exchange rate not found")
+var ErrCategoryNotFound = errors.New("category not found")
+var ErrCompatibilityNotFound = errors.New("compatibility not found")
+var ErrInvalidCursor = errors.New("invalid cursor")
+var ErrUnsupportedSort = errors.New("unsupported sort")
+var ErrOutOfStock = errors.New("out of stock")
+var ErrWarehouseNotFound = errors.New("warehouse not found")
+var ErrWishlistNotFound = errors.New("wishlist not found")
+var ErrPromoCodeNotFound = errors.New("promo code not found")
+var ErrPromoCodeUsageLimitReached = errors.New("promo code usage limit is reached")
+var ErrPromotionNotFound = errors.New("promotion not found")
+var ErrAddressNotFound = errors.New("address not found")
+var ErrNoWarehouse = errors.New("no warehouse")
+var ErrShipmentExceedsOrder = errors.New("shipment exceeds order")
+var ErrOrderChanged = errors.New("order changed")
@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/krijebr/printer-shop/internal/entity"
+	_ "github.com/lib/pq"
+)
+
+type ExchangeRateRepoPg struct {
+	db *sql.DB
+}
+
+func NewExchangeRateRepoPg(db *sql.DB) ExchangeRate {
+	return &ExchangeRateRepoPg{
+		db: db,
+	}
+}
+
+func (e *ExchangeRateRepoPg) GetAll(ctx context.Context) ([]*entity.ExchangeRate, error) {
+	rows, err := e.db.QueryContext(ctx, "select currency, rate, updated_at from exchange_rates order by currency")
+	if err != nil {
+		return nil, err
+	}
+	rates := []*entity.ExchangeRate{}
+	for rows.Next() {
+		rate, err := e.scanExchangeRate(rows)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+func (e *ExchangeRateRepoPg) GetByCurrency(ctx context.Context, currency entity.Currency) (*entity.ExchangeRate, error) {
+	row := e.db.QueryRowContext(ctx, "select currency, rate, updated_at from exchange_rates where currency = $1", currency)
+	rate, err := e.scanExchangeRate(row)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrExchangeRateNotFound
+		default:
+			return nil, err
+		}
+	}
+	return rate, nil
+}
+
+func (e *ExchangeRateRepoPg) Set(ctx context.Context, rate entity.ExchangeRate) error {
+	_, err := e.db.ExecContext(ctx,
+		"insert into exchange_rates (currency, rate, updated_at) values ($1,$2,$3) "+
+			"on conflict (currency) do update set rate = excluded.rate, updated_at = excluded.updated_at",
+		rate.Currency, rate.Rate, rate.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (e *ExchangeRateRepoPg) DeleteByCurrency(ctx context.Context, currency entity.Currency) error {
+	_, err := e.db.ExecContext(ctx, "delete from exchange_rates where currency = $1", currency)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (e *ExchangeRateRepoPg) scanExchangeRate(row Row) (*entity.ExchangeRate, error) {
+	var dateStr string
+	rate := new(entity.ExchangeRate)
+	err := row.Scan(&rate.Currency, &rate.Rate, &dateStr)
+	if err != nil {
+		return nil, err
+	}
+	rate.UpdatedAt, err = time.Parse(time.RFC3339, dateStr)
+	if err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+type GuestCartRedis struct {
+	rdb *redis.Client
+}
+
+const guestCartPrefix string = "guest_cart_"
+
+func NewGuestCartRedis(rdb *redis.Client) GuestCart {
+	return &GuestCartRedis{
+		rdb: rdb,
+	}
+}
+
+func (g *GuestCartRedis) GetAll(ctx context.Context, token uuid.UUID) ([]*entity.GuestCartLine, error) {
+	result, err := g.rdb.HGetAll(ctx, guestCartPrefix+token.String()).Result()
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]*entity.GuestCartLine, 0, len(result))
+	for _, value := range result {
+		line := new(entity.GuestCartLine)
+		err = json.Unmarshal([]byte(value), line)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// SetLine adds or replaces the line of the product, the cart expires ttl after the last change.
+func (g *GuestCartRedis) SetLine(ctx context.Context, token uuid.UUID, line *entity.GuestCartLine, ttl time.Duration) error {
+	value, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+	key := guestCartPrefix + token.String()
+	_, err = g.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, line.ProductId.String(), value)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	return err
+}
+
+func (g *GuestCartRedis) DeleteLine(ctx context.Context, token uuid.UUID, productId uuid.UUID) error {
+	return g.rdb.HDel(ctx, guestCartPrefix+token.String(), productId.String()).Err()
+}
+
+func (g *GuestCartRedis) Delete(ctx context.Context, token uuid.UUID) error {
+	return g.rdb.Del(ctx, guestCartPrefix+token.String()).Err()
+}
@@ -0,0 +1,93 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuestCartRedis_GetAll(t *testing.T) {
+	rdb, mock := redismock.NewClientMock()
+
+	r := NewGuestCartRedis(rdb)
+
+	token := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	productId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	testTable := []struct {
+		name          string
+		mockBehavior  func()
+		expectedLines []*entity.GuestCartLine
+		wantErr       bool
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				mock.ExpectHGetAll(guestCartPrefix + token.String()).SetVal(map[string]string{
+					productId.String(): `{"product_id":"00000000-0000-0000-0000-000000000002","count":2,"price":{"amount":1000000,"currency":"RUB"}}`,
+				})
+			},
+			expectedLines: []*entity.GuestCartLine{
+				{ProductId: productId, Count: 2, Price: entity.NewMoney(1000000, entity.CurrencyRUB)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "OK no cart",
+			mockBehavior: func() {
+				mock.ExpectHGetAll(guestCartPrefix + token.String()).SetVal(map[string]string{})
+			},
+			expectedLines: []*entity.GuestCartLine{},
+			wantErr:       false,
+		},
+		{
+			name: "some error",
+			mockBehavior: func() {
+				mock.ExpectHGetAll(guestCartPrefix + token.String()).SetErr(someErr)
+			},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+			lines, err := r.GetAll(context.Background(), token)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedLines, lines)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGuestCartRedis_SetLine(t *testing.T) {
+	rdb, mock := redismock.NewClientMock()
+
+	r := NewGuestCartRedis(rdb)
+
+	token := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	line := &entity.GuestCartLine{
+		ProductId: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+		Count:     2,
+		Price:     entity.NewMoney(1000000, entity.CurrencyRUB),
+	}
+	value := `{"product_id":"00000000-0000-0000-0000-000000000002","count":2,"price":{"amount":1000000,"currency":"RUB"}}`
+	key := guestCartPrefix + token.String()
+	ttl := time.Hour * 24
+
+	mock.ExpectTxPipeline()
+	mock.ExpectHSet(key, line.ProductId.String(), []byte(value)).SetVal(1)
+	mock.ExpectExpire(key, ttl).SetVal(true)
+	mock.ExpectTxPipelineExec()
+
+	err := r.SetLine(context.Background(), token, line, ttl)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
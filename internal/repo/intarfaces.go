@@ -19,6 +19,7 @@ type Token interface {
 }
 type User interface {
 	GetAll(ctx context.Context, filter *entity.UserFilter) (allUsers []*entity.User, err error)
+	GetPage(ctx context.Context, filter *entity.UserFilter, pagination entity.Pagination) (page *entity.UserPage, err error)
 	GetById(ctx context.Context, id uuid.UUID) (user *entity.User, err error)
 	Create(ctx context.Context, user entity.User) (err error)
 	Update(ctx context.Context, user entity.User) (err error)
@@ -28,34 +29,128 @@ type User interface {
 
 type Producer interface {
 	GetAll(ctx context.Context) (allProducers []*entity.Producer, err error)
+	GetPage(ctx context.Context, pagination entity.Pagination) (page *entity.ProducerPage, err error)
 	GetById(ctx context.Context, id uuid.UUID) (producer *entity.Producer, err error)
 	Create(ctx context.Context, producer entity.Producer) (err error)
 	Update(ctx context.Context, producer entity.Producer) (err error)
 	DeleteById(ctx context.Context, id uuid.UUID) (err error)
 }
+type Category interface {
+	GetAll(ctx context.Context) (allCategories []*entity.Category, err error)
+	GetById(ctx context.Context, id uuid.UUID) (category *entity.Category, err error)
+	Create(ctx context.Context, category entity.Category) (err error)
+	Update(ctx context.Context, category entity.Category) (err error)
+	DeleteById(ctx context.Context, id uuid.UUID) (err error)
+}
 type Product interface {
 	GetAll(ctx context.Context, filter *entity.ProductFilter) (allProducts []*entity.Product, err error)
+	GetPage(ctx context.Context, filter *entity.ProductFilter, pagination entity.Pagination) (page *entity.ProductPage, err error)
 	GetById(ctx context.Context, id uuid.UUID) (product *entity.Product, err error)
 	Create(ctx context.Context, product entity.Product) (err error)
 	Update(ctx context.Context, product entity.Product) (err error)
 	DeleteById(ctx context.Context, id uuid.UUID) (err error)
+	GetByName(ctx context.Context, name string) (products []*entity.Product, err error)
+	GetCompatible(ctx context.Context, id uuid.UUID, filter *entity.ProductFilter) (compatibleProducts []*entity.Product, err error)
+	AddCompatible(ctx context.Context, id uuid.UUID, compatibleId uuid.UUID) (err error)
+	DeleteCompatible(ctx context.Context, id uuid.UUID, compatibleId uuid.UUID) (err error)
 }
 type Cart interface {
 	GetAllProducts(ctx context.Context, userId uuid.UUID) (allProducts []*entity.ProductInCart, err error)
-	AddProduct(ctx context.Context, userId uuid.UUID, productId uuid.UUID, count int) (err error)
+	AddProduct(ctx context.Context, userId uuid.UUID, productId uuid.UUID, count int, price entity.Money) (err error)
 	UpdateCount(ctx context.Context, userId uuid.UUID, productId uuid.UUID, count int) (err error)
 	DeleteByProductId(ctx context.Context, userId uuid.UUID, productId uuid.UUID) (err error)
 	GetProductCountById(ctx context.Context, userId uuid.UUID, productId uuid.UUID) (count int, err error)
 	CheckIfExistsById(ctx context.Context, productId uuid.UUID) (exists bool, err error)
 	ClearCart(ctx context.Context, userId uuid.UUID) (err error)
+	GetPriceChanges(ctx context.Context, productId uuid.UUID, price entity.Money) (changes []*entity.CartPriceChange, err error)
+	AddLines(ctx context.Context, userId uuid.UUID, lines []*entity.GuestCartLine) (err error)
+}
+type GuestCart interface {
+	GetAll(ctx context.Context, token uuid.UUID) (lines []*entity.GuestCartLine, err error)
+	SetLine(ctx context.Context, token uuid.UUID, line *entity.GuestCartLine, ttl time.Duration) (err error)
+	DeleteLine(ctx context.Context, token uuid.UUID, productId uuid.UUID) (err error)
+	Delete(ctx context.Context, token uuid.UUID) (err error)
 }
 type Order interface {
-	Create(ctx context.Context, order *entity.Order) (err error)
+	Create(ctx context.Context, order *entity.Order, event entity.OrderEvent) (err error)
 	GetAll(ctx context.Context, filter *entity.OrderFilter) (allOrders []*entity.Order, err error)
+	GetPage(ctx context.Context, filter *entity.OrderFilter, pagination entity.Pagination) (page *entity.OrderPage, err error)
 	GetById(ctx context.Context, id uuid.UUID) (order *entity.Order, err error)
 	DeleteById(ctx context.Context, id uuid.UUID) (err error)
-	UpdateById(ctx context.Context, order *entity.Order) (err error)
+	UpdateById(ctx context.Context, order *entity.Order, from entity.OrderStatus, events []entity.OrderEvent) (err error)
 	CheckIfExistsByProductId(ctx context.Context, productId uuid.UUID) (exists bool, err error)
+	GetExpiredReservations(ctx context.Context, now time.Time) (ids []uuid.UUID, err error)
+	ExpireReservation(ctx context.Context, id uuid.UUID, now time.Time, event entity.OrderEvent) (expired bool, err error)
+	Cancel(ctx context.Context, id uuid.UUID, event entity.OrderEvent) (cancelled bool, err error)
+}
+type OrderEvent interface {
+	GetByOrderId(ctx context.Context, orderId uuid.UUID) (events []*entity.OrderEvent, err error)
+}
+type ExchangeRate interface {
+	GetAll(ctx context.Context) (allRates []*entity.ExchangeRate, err error)
+	GetByCurrency(ctx context.Context, currency entity.Currency) (rate *entity.ExchangeRate, err error)
+	Set(ctx context.Context, rate entity.ExchangeRate) (err error)
+	DeleteByCurrency(ctx context.Context, currency entity.Currency) (err error)
+}
+type Stock interface {
+	Adjust(ctx context.Context, movement entity.StockMovement) (err error)
+	GetMovements(ctx context.Context, productId uuid.UUID) (movements []*entity.StockMovement, err error)
+}
+type Warehouse interface {
+	GetAll(ctx context.Context) (allWarehouses []*entity.Warehouse, err error)
+	GetById(ctx context.Context, id uuid.UUID) (warehouse *entity.Warehouse, err error)
+	Create(ctx context.Context, warehouse entity.Warehouse) (err error)
+	Update(ctx context.Context, warehouse entity.Warehouse) (err error)
+	DeleteById(ctx context.Context, id uuid.UUID) (err error)
+	CheckIfUsedById(ctx context.Context, id uuid.UUID) (used bool, err error)
+	GetStock(ctx context.Context, filter *entity.WarehouseStockFilter) (stock []*entity.WarehouseStock, err error)
+}
+type Wishlist interface {
+	GetAllByUserId(ctx context.Context, userId uuid.UUID) (wishlists []*entity.Wishlist, err error)
+	GetById(ctx context.Context, id uuid.UUID) (wishlist *entity.Wishlist, err error)
+	GetByShareToken(ctx context.Context, token uuid.UUID) (wishlist *entity.Wishlist, err error)
+	CheckIfNameExists(ctx context.Context, userId uuid.UUID, name string, excludeId uuid.UUID) (exists bool, err error)
+	Create(ctx context.Context, wishlist entity.Wishlist) (err error)
+	Update(ctx context.Context, wishlist entity.Wishlist) (err error)
+	SetShareToken(ctx context.Context, id uuid.UUID, token *uuid.UUID) (err error)
+	DeleteById(ctx context.Context, id uuid.UUID) (err error)
+	GetProductIds(ctx context.Context, id uuid.UUID) (productIds []uuid.UUID, err error)
+	AddProduct(ctx context.Context, id uuid.UUID, productId uuid.UUID) (err error)
+	DeleteProduct(ctx context.Context, id uuid.UUID, productId uuid.UUID) (deleted bool, err error)
+}
+type PromoCode interface {
+	GetAll(ctx context.Context) (allPromoCodes []*entity.PromoCode, err error)
+	GetById(ctx context.Context, id uuid.UUID) (promoCode *entity.PromoCode, err error)
+	GetByCode(ctx context.Context, code string) (promoCode *entity.PromoCode, err error)
+	GetByCartUserId(ctx context.Context, userId uuid.UUID) (promoCode *entity.PromoCode, err error)
+	CheckIfCodeExists(ctx context.Context, code string, excludeId uuid.UUID) (exists bool, err error)
+	Create(ctx context.Context, promoCode entity.PromoCode) (err error)
+	Update(ctx context.Context, promoCode entity.PromoCode) (err error)
+	DeleteById(ctx context.Context, id uuid.UUID) (err error)
+	CheckIfUsedById(ctx context.Context, id uuid.UUID) (used bool, err error)
+	GetUsage(ctx context.Context, id uuid.UUID, userId uuid.UUID) (total int, byUser int, err error)
+	GetApplicableProductIds(ctx context.Context, id uuid.UUID, productIds []uuid.UUID) (applicable []uuid.UUID, err error)
+	SetCartPromoCode(ctx context.Context, userId uuid.UUID, id uuid.UUID) (err error)
+	DeleteCartPromoCode(ctx context.Context, userId uuid.UUID) (err error)
+}
+type Promotion interface {
+	GetAll(ctx context.Context) (allPromotions []*entity.Promotion, err error)
+	GetActive(ctx context.Context, now time.Time) (activePromotions []*entity.Promotion, err error)
+	GetById(ctx context.Context, id uuid.UUID) (promotion *entity.Promotion, err error)
+	Create(ctx context.Context, promotion entity.Promotion) (err error)
+	Update(ctx context.Context, promotion entity.Promotion) (err error)
+	DeleteById(ctx context.Context, id uuid.UUID) (err error)
+}
+type Address interface {
+	GetAllByUserId(ctx context.Context, userId uuid.UUID) (addresses []*entity.Address, err error)
+	GetById(ctx context.Context, id uuid.UUID) (address *entity.Address, err error)
+	Create(ctx context.Context, address entity.Address) (err error)
+	Update(ctx context.Context, address entity.Address) (err error)
+	DeleteById(ctx context.Context, id uuid.UUID) (err error)
+}
+type Shipment interface {
+	Create(ctx context.Context, shipment *entity.Shipment, from entity.OrderStatus, to entity.OrderStatus, events []entity.OrderEvent) (err error)
+	GetByOrderId(ctx context.Context, orderId uuid.UUID) (shipments []*entity.Shipment, err error)
 }
 
 type Row interface {
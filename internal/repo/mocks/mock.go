@@ -219,6 +219,21 @@ func (mr *MockUserMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockUser)(nil).GetById), ctx, id)
 }
 
+// GetPage mocks base method.
+func (m *MockUser) GetPage(ctx context.Context, filter *entity.UserFilter, pagination entity.Pagination) (*entity.UserPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, filter, pagination)
+	ret0, _ := ret[0].(*entity.UserPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockUserMockRecorder) GetPage(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockUser)(nil).GetPage), ctx, filter, pagination)
+}
+
 // Update mocks base method.
 func (m *MockUser) Update(ctx context.Context, user entity.User) error {
 	m.ctrl.T.Helper()
@@ -314,6 +329,21 @@ func (mr *MockProducerMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockProducer)(nil).GetById), ctx, id)
 }
 
+// GetPage mocks base method.
+func (m *MockProducer) GetPage(ctx context.Context, pagination entity.Pagination) (*entity.ProducerPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, pagination)
+	ret0, _ := ret[0].(*entity.ProducerPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockProducerMockRecorder) GetPage(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockProducer)(nil).GetPage), ctx, pagination)
+}
+
 // Update mocks base method.
 func (m *MockProducer) Update(ctx context.Context, producer entity.Producer) error {
 	m.ctrl.T.Helper()
@@ -328,6 +358,101 @@ func (mr *MockProducerMockRecorder) Update(ctx, producer interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProducer)(nil).Update), ctx, producer)
 }
 
+// MockCategory is a mock of Category interface.
+type MockCategory struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryMockRecorder
+}
+
+// MockCategoryMockRecorder is the mock recorder for MockCategory.
+type MockCategoryMockRecorder struct {
+	mock *MockCategory
+}
+
+// NewMockCategory creates a new mock instance.
+func NewMockCategory(ctrl *gomock.Controller) *MockCategory {
+	mock := &MockCategory{ctrl: ctrl}
+	mock.recorder = &MockCategoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategory) EXPECT() *MockCategoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCategory) Create(ctx context.Context, category entity.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCategoryMockRecorder) Create(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategory)(nil).Create), ctx, category)
+}
+
+// DeleteById mocks base method.
+func (m *MockCategory) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockCategoryMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockCategory)(nil).DeleteById), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockCategory) GetAll(ctx context.Context) ([]*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCategoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCategory)(nil).GetAll), ctx)
+}
+
+// GetById mocks base method.
+func (m *MockCategory) GetById(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockCategoryMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockCategory)(nil).GetById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockCategory) Update(ctx context.Context, category entity.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCategoryMockRecorder) Update(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategory)(nil).Update), ctx, category)
+}
+
 // MockProduct is a mock of Product interface.
 type MockProduct struct {
 	ctrl     *gomock.Controller
@@ -351,6 +476,20 @@ func (m *MockProduct) EXPECT() *MockProductMockRecorder {
 	return m.recorder
 }
 
+// AddCompatible mocks base method.
+func (m *MockProduct) AddCompatible(ctx context.Context, id, compatibleId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCompatible", ctx, id, compatibleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCompatible indicates an expected call of AddCompatible.
+func (mr *MockProductMockRecorder) AddCompatible(ctx, id, compatibleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCompatible", reflect.TypeOf((*MockProduct)(nil).AddCompatible), ctx, id, compatibleId)
+}
+
 // Create mocks base method.
 func (m *MockProduct) Create(ctx context.Context, product entity.Product) error {
 	m.ctrl.T.Helper()
@@ -379,6 +518,20 @@ func (mr *MockProductMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockProduct)(nil).DeleteById), ctx, id)
 }
 
+// DeleteCompatible mocks base method.
+func (m *MockProduct) DeleteCompatible(ctx context.Context, id, compatibleId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCompatible", ctx, id, compatibleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCompatible indicates an expected call of DeleteCompatible.
+func (mr *MockProductMockRecorder) DeleteCompatible(ctx, id, compatibleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompatible", reflect.TypeOf((*MockProduct)(nil).DeleteCompatible), ctx, id, compatibleId)
+}
+
 // GetAll mocks base method.
 func (m *MockProduct) GetAll(ctx context.Context, filter *entity.ProductFilter) ([]*entity.Product, error) {
 	m.ctrl.T.Helper()
@@ -409,6 +562,51 @@ func (mr *MockProductMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockProduct)(nil).GetById), ctx, id)
 }
 
+// GetByName mocks base method.
+func (m *MockProduct) GetByName(ctx context.Context, name string) ([]*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name)
+	ret0, _ := ret[0].([]*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockProductMockRecorder) GetByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockProduct)(nil).GetByName), ctx, name)
+}
+
+// GetCompatible mocks base method.
+func (m *MockProduct) GetCompatible(ctx context.Context, id uuid.UUID, filter *entity.ProductFilter) ([]*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompatible", ctx, id, filter)
+	ret0, _ := ret[0].([]*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompatible indicates an expected call of GetCompatible.
+func (mr *MockProductMockRecorder) GetCompatible(ctx, id, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompatible", reflect.TypeOf((*MockProduct)(nil).GetCompatible), ctx, id, filter)
+}
+
+// GetPage mocks base method.
+func (m *MockProduct) GetPage(ctx context.Context, filter *entity.ProductFilter, pagination entity.Pagination) (*entity.ProductPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, filter, pagination)
+	ret0, _ := ret[0].(*entity.ProductPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockProductMockRecorder) GetPage(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockProduct)(nil).GetPage), ctx, filter, pagination)
+}
+
 // Update mocks base method.
 func (m *MockProduct) Update(ctx context.Context, product entity.Product) error {
 	m.ctrl.T.Helper()
@@ -446,18 +644,32 @@ func (m *MockCart) EXPECT() *MockCartMockRecorder {
 	return m.recorder
 }
 
+// AddLines mocks base method.
+func (m *MockCart) AddLines(ctx context.Context, userId uuid.UUID, lines []*entity.GuestCartLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLines", ctx, userId, lines)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLines indicates an expected call of AddLines.
+func (mr *MockCartMockRecorder) AddLines(ctx, userId, lines interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLines", reflect.TypeOf((*MockCart)(nil).AddLines), ctx, userId, lines)
+}
+
 // AddProduct mocks base method.
-func (m *MockCart) AddProduct(ctx context.Context, userId, productId uuid.UUID, count int) error {
+func (m *MockCart) AddProduct(ctx context.Context, userId, productId uuid.UUID, count int, price entity.Money) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddProduct", ctx, userId, productId, count)
+	ret := m.ctrl.Call(m, "AddProduct", ctx, userId, productId, count, price)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddProduct indicates an expected call of AddProduct.
-func (mr *MockCartMockRecorder) AddProduct(ctx, userId, productId, count interface{}) *gomock.Call {
+func (mr *MockCartMockRecorder) AddProduct(ctx, userId, productId, count, price interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockCart)(nil).AddProduct), ctx, userId, productId, count)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockCart)(nil).AddProduct), ctx, userId, productId, count, price)
 }
 
 // CheckIfExistsById mocks base method.
@@ -518,6 +730,21 @@ func (mr *MockCartMockRecorder) GetAllProducts(ctx, userId interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockCart)(nil).GetAllProducts), ctx, userId)
 }
 
+// GetPriceChanges mocks base method.
+func (m *MockCart) GetPriceChanges(ctx context.Context, productId uuid.UUID, price entity.Money) ([]*entity.CartPriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceChanges", ctx, productId, price)
+	ret0, _ := ret[0].([]*entity.CartPriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceChanges indicates an expected call of GetPriceChanges.
+func (mr *MockCartMockRecorder) GetPriceChanges(ctx, productId, price interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceChanges", reflect.TypeOf((*MockCart)(nil).GetPriceChanges), ctx, productId, price)
+}
+
 // GetProductCountById mocks base method.
 func (m *MockCart) GetProductCountById(ctx context.Context, userId, productId uuid.UUID) (int, error) {
 	m.ctrl.T.Helper()
@@ -547,6 +774,86 @@ func (mr *MockCartMockRecorder) UpdateCount(ctx, userId, productId, count interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCount", reflect.TypeOf((*MockCart)(nil).UpdateCount), ctx, userId, productId, count)
 }
 
+// MockGuestCart is a mock of GuestCart interface.
+type MockGuestCart struct {
+	ctrl     *gomock.Controller
+	recorder *MockGuestCartMockRecorder
+}
+
+// MockGuestCartMockRecorder is the mock recorder for MockGuestCart.
+type MockGuestCartMockRecorder struct {
+	mock *MockGuestCart
+}
+
+// NewMockGuestCart creates a new mock instance.
+func NewMockGuestCart(ctrl *gomock.Controller) *MockGuestCart {
+	mock := &MockGuestCart{ctrl: ctrl}
+	mock.recorder = &MockGuestCartMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGuestCart) EXPECT() *MockGuestCartMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockGuestCart) Delete(ctx context.Context, token uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGuestCartMockRecorder) Delete(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGuestCart)(nil).Delete), ctx, token)
+}
+
+// DeleteLine mocks base method.
+func (m *MockGuestCart) DeleteLine(ctx context.Context, token, productId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLine", ctx, token, productId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLine indicates an expected call of DeleteLine.
+func (mr *MockGuestCartMockRecorder) DeleteLine(ctx, token, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLine", reflect.TypeOf((*MockGuestCart)(nil).DeleteLine), ctx, token, productId)
+}
+
+// GetAll mocks base method.
+func (m *MockGuestCart) GetAll(ctx context.Context, token uuid.UUID) ([]*entity.GuestCartLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, token)
+	ret0, _ := ret[0].([]*entity.GuestCartLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockGuestCartMockRecorder) GetAll(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockGuestCart)(nil).GetAll), ctx, token)
+}
+
+// SetLine mocks base method.
+func (m *MockGuestCart) SetLine(ctx context.Context, token uuid.UUID, line *entity.GuestCartLine, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLine", ctx, token, line, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLine indicates an expected call of SetLine.
+func (mr *MockGuestCartMockRecorder) SetLine(ctx, token, line, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLine", reflect.TypeOf((*MockGuestCart)(nil).SetLine), ctx, token, line, ttl)
+}
+
 // MockOrder is a mock of Order interface.
 type MockOrder struct {
 	ctrl     *gomock.Controller
@@ -570,6 +877,21 @@ func (m *MockOrder) EXPECT() *MockOrderMockRecorder {
 	return m.recorder
 }
 
+// Cancel mocks base method.
+func (m *MockOrder) Cancel(ctx context.Context, id uuid.UUID, event entity.OrderEvent) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockOrderMockRecorder) Cancel(ctx, id, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockOrder)(nil).Cancel), ctx, id, event)
+}
+
 // CheckIfExistsByProductId mocks base method.
 func (m *MockOrder) CheckIfExistsByProductId(ctx context.Context, productId uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
@@ -586,17 +908,17 @@ func (mr *MockOrderMockRecorder) CheckIfExistsByProductId(ctx, productId interfa
 }
 
 // Create mocks base method.
-func (m *MockOrder) Create(ctx context.Context, order *entity.Order) error {
+func (m *MockOrder) Create(ctx context.Context, order *entity.Order, event entity.OrderEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, order)
+	ret := m.ctrl.Call(m, "Create", ctx, order, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockOrderMockRecorder) Create(ctx, order interface{}) *gomock.Call {
+func (mr *MockOrderMockRecorder) Create(ctx, order, event interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrder)(nil).Create), ctx, order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrder)(nil).Create), ctx, order, event)
 }
 
 // DeleteById mocks base method.
@@ -613,6 +935,21 @@ func (mr *MockOrderMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockOrder)(nil).DeleteById), ctx, id)
 }
 
+// ExpireReservation mocks base method.
+func (m *MockOrder) ExpireReservation(ctx context.Context, id uuid.UUID, now time.Time, event entity.OrderEvent) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReservation", ctx, id, now, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireReservation indicates an expected call of ExpireReservation.
+func (mr *MockOrderMockRecorder) ExpireReservation(ctx, id, now, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReservation", reflect.TypeOf((*MockOrder)(nil).ExpireReservation), ctx, id, now, event)
+}
+
 // GetAll mocks base method.
 func (m *MockOrder) GetAll(ctx context.Context, filter *entity.OrderFilter) ([]*entity.Order, error) {
 	m.ctrl.T.Helper()
@@ -643,18 +980,998 @@ func (mr *MockOrderMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockOrder)(nil).GetById), ctx, id)
 }
 
+// GetExpiredReservations mocks base method.
+func (m *MockOrder) GetExpiredReservations(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredReservations", ctx, now)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredReservations indicates an expected call of GetExpiredReservations.
+func (mr *MockOrderMockRecorder) GetExpiredReservations(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredReservations", reflect.TypeOf((*MockOrder)(nil).GetExpiredReservations), ctx, now)
+}
+
+// GetPage mocks base method.
+func (m *MockOrder) GetPage(ctx context.Context, filter *entity.OrderFilter, pagination entity.Pagination) (*entity.OrderPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, filter, pagination)
+	ret0, _ := ret[0].(*entity.OrderPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockOrderMockRecorder) GetPage(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockOrder)(nil).GetPage), ctx, filter, pagination)
+}
+
 // UpdateById mocks base method.
-func (m *MockOrder) UpdateById(ctx context.Context, order *entity.Order) error {
+func (m *MockOrder) UpdateById(ctx context.Context, order *entity.Order, from entity.OrderStatus, events []entity.OrderEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateById", ctx, order)
+	ret := m.ctrl.Call(m, "UpdateById", ctx, order, from, events)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateById indicates an expected call of UpdateById.
-func (mr *MockOrderMockRecorder) UpdateById(ctx, order interface{}) *gomock.Call {
+func (mr *MockOrderMockRecorder) UpdateById(ctx, order, from, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockOrder)(nil).UpdateById), ctx, order, from, events)
+}
+
+// MockOrderEvent is a mock of OrderEvent interface.
+type MockOrderEvent struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderEventMockRecorder
+}
+
+// MockOrderEventMockRecorder is the mock recorder for MockOrderEvent.
+type MockOrderEventMockRecorder struct {
+	mock *MockOrderEvent
+}
+
+// NewMockOrderEvent creates a new mock instance.
+func NewMockOrderEvent(ctrl *gomock.Controller) *MockOrderEvent {
+	mock := &MockOrderEvent{ctrl: ctrl}
+	mock.recorder = &MockOrderEventMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderEvent) EXPECT() *MockOrderEventMockRecorder {
+	return m.recorder
+}
+
+// GetByOrderId mocks base method.
+func (m *MockOrderEvent) GetByOrderId(ctx context.Context, orderId uuid.UUID) ([]*entity.OrderEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrderId", ctx, orderId)
+	ret0, _ := ret[0].([]*entity.OrderEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrderId indicates an expected call of GetByOrderId.
+func (mr *MockOrderEventMockRecorder) GetByOrderId(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrderId", reflect.TypeOf((*MockOrderEvent)(nil).GetByOrderId), ctx, orderId)
+}
+
+// MockExchangeRate is a mock of ExchangeRate interface.
+type MockExchangeRate struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateMockRecorder
+}
+
+// MockExchangeRateMockRecorder is the mock recorder for MockExchangeRate.
+type MockExchangeRateMockRecorder struct {
+	mock *MockExchangeRate
+}
+
+// NewMockExchangeRate creates a new mock instance.
+func NewMockExchangeRate(ctrl *gomock.Controller) *MockExchangeRate {
+	mock := &MockExchangeRate{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRate) EXPECT() *MockExchangeRateMockRecorder {
+	return m.recorder
+}
+
+// DeleteByCurrency mocks base method.
+func (m *MockExchangeRate) DeleteByCurrency(ctx context.Context, currency entity.Currency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByCurrency", ctx, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByCurrency indicates an expected call of DeleteByCurrency.
+func (mr *MockExchangeRateMockRecorder) DeleteByCurrency(ctx, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByCurrency", reflect.TypeOf((*MockExchangeRate)(nil).DeleteByCurrency), ctx, currency)
+}
+
+// GetAll mocks base method.
+func (m *MockExchangeRate) GetAll(ctx context.Context) ([]*entity.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*entity.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockExchangeRateMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockExchangeRate)(nil).GetAll), ctx)
+}
+
+// GetByCurrency mocks base method.
+func (m *MockExchangeRate) GetByCurrency(ctx context.Context, currency entity.Currency) (*entity.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCurrency", ctx, currency)
+	ret0, _ := ret[0].(*entity.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCurrency indicates an expected call of GetByCurrency.
+func (mr *MockExchangeRateMockRecorder) GetByCurrency(ctx, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCurrency", reflect.TypeOf((*MockExchangeRate)(nil).GetByCurrency), ctx, currency)
+}
+
+// Set mocks base method.
+func (m *MockExchangeRate) Set(ctx context.Context, rate entity.ExchangeRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockExchangeRateMockRecorder) Set(ctx, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockExchangeRate)(nil).Set), ctx, rate)
+}
+
+// MockStock is a mock of Stock interface.
+type MockStock struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockMockRecorder
+}
+
+// MockStockMockRecorder is the mock recorder for MockStock.
+type MockStockMockRecorder struct {
+	mock *MockStock
+}
+
+// NewMockStock creates a new mock instance.
+func NewMockStock(ctrl *gomock.Controller) *MockStock {
+	mock := &MockStock{ctrl: ctrl}
+	mock.recorder = &MockStockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStock) EXPECT() *MockStockMockRecorder {
+	return m.recorder
+}
+
+// Adjust mocks base method.
+func (m *MockStock) Adjust(ctx context.Context, movement entity.StockMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Adjust", ctx, movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Adjust indicates an expected call of Adjust.
+func (mr *MockStockMockRecorder) Adjust(ctx, movement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Adjust", reflect.TypeOf((*MockStock)(nil).Adjust), ctx, movement)
+}
+
+// GetMovements mocks base method.
+func (m *MockStock) GetMovements(ctx context.Context, productId uuid.UUID) ([]*entity.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovements", ctx, productId)
+	ret0, _ := ret[0].([]*entity.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovements indicates an expected call of GetMovements.
+func (mr *MockStockMockRecorder) GetMovements(ctx, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovements", reflect.TypeOf((*MockStock)(nil).GetMovements), ctx, productId)
+}
+
+// MockWarehouse is a mock of Warehouse interface.
+type MockWarehouse struct {
+	ctrl     *gomock.Controller
+	recorder *MockWarehouseMockRecorder
+}
+
+// MockWarehouseMockRecorder is the mock recorder for MockWarehouse.
+type MockWarehouseMockRecorder struct {
+	mock *MockWarehouse
+}
+
+// NewMockWarehouse creates a new mock instance.
+func NewMockWarehouse(ctrl *gomock.Controller) *MockWarehouse {
+	mock := &MockWarehouse{ctrl: ctrl}
+	mock.recorder = &MockWarehouseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWarehouse) EXPECT() *MockWarehouseMockRecorder {
+	return m.recorder
+}
+
+// CheckIfUsedById mocks base method.
+func (m *MockWarehouse) CheckIfUsedById(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIfUsedById", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckIfUsedById indicates an expected call of CheckIfUsedById.
+func (mr *MockWarehouseMockRecorder) CheckIfUsedById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIfUsedById", reflect.TypeOf((*MockWarehouse)(nil).CheckIfUsedById), ctx, id)
+}
+
+// Create mocks base method.
+func (m *MockWarehouse) Create(ctx context.Context, warehouse entity.Warehouse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, warehouse)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWarehouseMockRecorder) Create(ctx, warehouse interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWarehouse)(nil).Create), ctx, warehouse)
+}
+
+// DeleteById mocks base method.
+func (m *MockWarehouse) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockWarehouseMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockWarehouse)(nil).DeleteById), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockWarehouse) GetAll(ctx context.Context) ([]*entity.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*entity.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockWarehouseMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWarehouse)(nil).GetAll), ctx)
+}
+
+// GetById mocks base method.
+func (m *MockWarehouse) GetById(ctx context.Context, id uuid.UUID) (*entity.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*entity.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockWarehouseMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockWarehouse)(nil).GetById), ctx, id)
+}
+
+// GetStock mocks base method.
+func (m *MockWarehouse) GetStock(ctx context.Context, filter *entity.WarehouseStockFilter) ([]*entity.WarehouseStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStock", ctx, filter)
+	ret0, _ := ret[0].([]*entity.WarehouseStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStock indicates an expected call of GetStock.
+func (mr *MockWarehouseMockRecorder) GetStock(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStock", reflect.TypeOf((*MockWarehouse)(nil).GetStock), ctx, filter)
+}
+
+// Update mocks base method.
+func (m *MockWarehouse) Update(ctx context.Context, warehouse entity.Warehouse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, warehouse)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWarehouseMockRecorder) Update(ctx, warehouse interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWarehouse)(nil).Update), ctx, warehouse)
+}
+
+// MockWishlist is a mock of Wishlist interface.
+type MockWishlist struct {
+	ctrl     *gomock.Controller
+	recorder *MockWishlistMockRecorder
+}
+
+// MockWishlistMockRecorder is the mock recorder for MockWishlist.
+type MockWishlistMockRecorder struct {
+	mock *MockWishlist
+}
+
+// NewMockWishlist creates a new mock instance.
+func NewMockWishlist(ctrl *gomock.Controller) *MockWishlist {
+	mock := &MockWishlist{ctrl: ctrl}
+	mock.recorder = &MockWishlistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWishlist) EXPECT() *MockWishlistMockRecorder {
+	return m.recorder
+}
+
+// AddProduct mocks base method.
+func (m *MockWishlist) AddProduct(ctx context.Context, id, productId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProduct", ctx, id, productId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProduct indicates an expected call of AddProduct.
+func (mr *MockWishlistMockRecorder) AddProduct(ctx, id, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockWishlist)(nil).AddProduct), ctx, id, productId)
+}
+
+// CheckIfNameExists mocks base method.
+func (m *MockWishlist) CheckIfNameExists(ctx context.Context, userId uuid.UUID, name string, excludeId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIfNameExists", ctx, userId, name, excludeId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckIfNameExists indicates an expected call of CheckIfNameExists.
+func (mr *MockWishlistMockRecorder) CheckIfNameExists(ctx, userId, name, excludeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIfNameExists", reflect.TypeOf((*MockWishlist)(nil).CheckIfNameExists), ctx, userId, name, excludeId)
+}
+
+// Create mocks base method.
+func (m *MockWishlist) Create(ctx context.Context, wishlist entity.Wishlist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, wishlist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWishlistMockRecorder) Create(ctx, wishlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWishlist)(nil).Create), ctx, wishlist)
+}
+
+// DeleteById mocks base method.
+func (m *MockWishlist) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockWishlistMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockWishlist)(nil).DeleteById), ctx, id)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockWishlist) DeleteProduct(ctx context.Context, id, productId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, id, productId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockWishlistMockRecorder) DeleteProduct(ctx, id, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockWishlist)(nil).DeleteProduct), ctx, id, productId)
+}
+
+// GetAllByUserId mocks base method.
+func (m *MockWishlist) GetAllByUserId(ctx context.Context, userId uuid.UUID) ([]*entity.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByUserId", ctx, userId)
+	ret0, _ := ret[0].([]*entity.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByUserId indicates an expected call of GetAllByUserId.
+func (mr *MockWishlistMockRecorder) GetAllByUserId(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserId", reflect.TypeOf((*MockWishlist)(nil).GetAllByUserId), ctx, userId)
+}
+
+// GetById mocks base method.
+func (m *MockWishlist) GetById(ctx context.Context, id uuid.UUID) (*entity.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*entity.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockWishlistMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockWishlist)(nil).GetById), ctx, id)
+}
+
+// GetByShareToken mocks base method.
+func (m *MockWishlist) GetByShareToken(ctx context.Context, token uuid.UUID) (*entity.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByShareToken", ctx, token)
+	ret0, _ := ret[0].(*entity.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByShareToken indicates an expected call of GetByShareToken.
+func (mr *MockWishlistMockRecorder) GetByShareToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByShareToken", reflect.TypeOf((*MockWishlist)(nil).GetByShareToken), ctx, token)
+}
+
+// GetProductIds mocks base method.
+func (m *MockWishlist) GetProductIds(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductIds", ctx, id)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductIds indicates an expected call of GetProductIds.
+func (mr *MockWishlistMockRecorder) GetProductIds(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductIds", reflect.TypeOf((*MockWishlist)(nil).GetProductIds), ctx, id)
+}
+
+// SetShareToken mocks base method.
+func (m *MockWishlist) SetShareToken(ctx context.Context, id uuid.UUID, token *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetShareToken", ctx, id, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetShareToken indicates an expected call of SetShareToken.
+func (mr *MockWishlistMockRecorder) SetShareToken(ctx, id, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetShareToken", reflect.TypeOf((*MockWishlist)(nil).SetShareToken), ctx, id, token)
+}
+
+// Update mocks base method.
+func (m *MockWishlist) Update(ctx context.Context, wishlist entity.Wishlist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, wishlist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWishlistMockRecorder) Update(ctx, wishlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWishlist)(nil).Update), ctx, wishlist)
+}
+
+// MockPromoCode is a mock of PromoCode interface.
+type MockPromoCode struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromoCodeMockRecorder
+}
+
+// MockPromoCodeMockRecorder is the mock recorder for MockPromoCode.
+type MockPromoCodeMockRecorder struct {
+	mock *MockPromoCode
+}
+
+// NewMockPromoCode creates a new mock instance.
+func NewMockPromoCode(ctrl *gomock.Controller) *MockPromoCode {
+	mock := &MockPromoCode{ctrl: ctrl}
+	mock.recorder = &MockPromoCodeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromoCode) EXPECT() *MockPromoCodeMockRecorder {
+	return m.recorder
+}
+
+// CheckIfCodeExists mocks base method.
+func (m *MockPromoCode) CheckIfCodeExists(ctx context.Context, code string, excludeId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIfCodeExists", ctx, code, excludeId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckIfCodeExists indicates an expected call of CheckIfCodeExists.
+func (mr *MockPromoCodeMockRecorder) CheckIfCodeExists(ctx, code, excludeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIfCodeExists", reflect.TypeOf((*MockPromoCode)(nil).CheckIfCodeExists), ctx, code, excludeId)
+}
+
+// CheckIfUsedById mocks base method.
+func (m *MockPromoCode) CheckIfUsedById(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIfUsedById", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckIfUsedById indicates an expected call of CheckIfUsedById.
+func (mr *MockPromoCodeMockRecorder) CheckIfUsedById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIfUsedById", reflect.TypeOf((*MockPromoCode)(nil).CheckIfUsedById), ctx, id)
+}
+
+// Create mocks base method.
+func (m *MockPromoCode) Create(ctx context.Context, promoCode entity.PromoCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, promoCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromoCodeMockRecorder) Create(ctx, promoCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromoCode)(nil).Create), ctx, promoCode)
+}
+
+// DeleteById mocks base method.
+func (m *MockPromoCode) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockPromoCodeMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockPromoCode)(nil).DeleteById), ctx, id)
+}
+
+// DeleteCartPromoCode mocks base method.
+func (m *MockPromoCode) DeleteCartPromoCode(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCartPromoCode", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCartPromoCode indicates an expected call of DeleteCartPromoCode.
+func (mr *MockPromoCodeMockRecorder) DeleteCartPromoCode(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCartPromoCode", reflect.TypeOf((*MockPromoCode)(nil).DeleteCartPromoCode), ctx, userId)
+}
+
+// GetAll mocks base method.
+func (m *MockPromoCode) GetAll(ctx context.Context) ([]*entity.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*entity.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPromoCodeMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPromoCode)(nil).GetAll), ctx)
+}
+
+// GetApplicableProductIds mocks base method.
+func (m *MockPromoCode) GetApplicableProductIds(ctx context.Context, id uuid.UUID, productIds []uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicableProductIds", ctx, id, productIds)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicableProductIds indicates an expected call of GetApplicableProductIds.
+func (mr *MockPromoCodeMockRecorder) GetApplicableProductIds(ctx, id, productIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicableProductIds", reflect.TypeOf((*MockPromoCode)(nil).GetApplicableProductIds), ctx, id, productIds)
+}
+
+// GetByCartUserId mocks base method.
+func (m *MockPromoCode) GetByCartUserId(ctx context.Context, userId uuid.UUID) (*entity.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCartUserId", ctx, userId)
+	ret0, _ := ret[0].(*entity.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCartUserId indicates an expected call of GetByCartUserId.
+func (mr *MockPromoCodeMockRecorder) GetByCartUserId(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCartUserId", reflect.TypeOf((*MockPromoCode)(nil).GetByCartUserId), ctx, userId)
+}
+
+// GetByCode mocks base method.
+func (m *MockPromoCode) GetByCode(ctx context.Context, code string) (*entity.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", ctx, code)
+	ret0, _ := ret[0].(*entity.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockPromoCodeMockRecorder) GetByCode(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockPromoCode)(nil).GetByCode), ctx, code)
+}
+
+// GetById mocks base method.
+func (m *MockPromoCode) GetById(ctx context.Context, id uuid.UUID) (*entity.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*entity.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockPromoCodeMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPromoCode)(nil).GetById), ctx, id)
+}
+
+// GetUsage mocks base method.
+func (m *MockPromoCode) GetUsage(ctx context.Context, id, userId uuid.UUID) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", ctx, id, userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockPromoCodeMockRecorder) GetUsage(ctx, id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockPromoCode)(nil).GetUsage), ctx, id, userId)
+}
+
+// SetCartPromoCode mocks base method.
+func (m *MockPromoCode) SetCartPromoCode(ctx context.Context, userId, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCartPromoCode", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCartPromoCode indicates an expected call of SetCartPromoCode.
+func (mr *MockPromoCodeMockRecorder) SetCartPromoCode(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCartPromoCode", reflect.TypeOf((*MockPromoCode)(nil).SetCartPromoCode), ctx, userId, id)
+}
+
+// Update mocks base method.
+func (m *MockPromoCode) Update(ctx context.Context, promoCode entity.PromoCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, promoCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPromoCodeMockRecorder) Update(ctx, promoCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPromoCode)(nil).Update), ctx, promoCode)
+}
+
+// MockPromotion is a mock of Promotion interface.
+type MockPromotion struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionMockRecorder
+}
+
+// MockPromotionMockRecorder is the mock recorder for MockPromotion.
+type MockPromotionMockRecorder struct {
+	mock *MockPromotion
+}
+
+// NewMockPromotion creates a new mock instance.
+func NewMockPromotion(ctrl *gomock.Controller) *MockPromotion {
+	mock := &MockPromotion{ctrl: ctrl}
+	mock.recorder = &MockPromotionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotion) EXPECT() *MockPromotionMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPromotion) Create(ctx context.Context, promotion entity.Promotion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, promotion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromotionMockRecorder) Create(ctx, promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromotion)(nil).Create), ctx, promotion)
+}
+
+// DeleteById mocks base method.
+func (m *MockPromotion) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockPromotionMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockPromotion)(nil).DeleteById), ctx, id)
+}
+
+// GetActive mocks base method.
+func (m *MockPromotion) GetActive(ctx context.Context, now time.Time) ([]*entity.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActive", ctx, now)
+	ret0, _ := ret[0].([]*entity.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActive indicates an expected call of GetActive.
+func (mr *MockPromotionMockRecorder) GetActive(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActive", reflect.TypeOf((*MockPromotion)(nil).GetActive), ctx, now)
+}
+
+// GetAll mocks base method.
+func (m *MockPromotion) GetAll(ctx context.Context) ([]*entity.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*entity.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPromotionMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPromotion)(nil).GetAll), ctx)
+}
+
+// GetById mocks base method.
+func (m *MockPromotion) GetById(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*entity.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockPromotionMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPromotion)(nil).GetById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockPromotion) Update(ctx context.Context, promotion entity.Promotion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, promotion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPromotionMockRecorder) Update(ctx, promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPromotion)(nil).Update), ctx, promotion)
+}
+
+// MockAddress is a mock of Address interface.
+type MockAddress struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddressMockRecorder
+}
+
+// MockAddressMockRecorder is the mock recorder for MockAddress.
+type MockAddressMockRecorder struct {
+	mock *MockAddress
+}
+
+// NewMockAddress creates a new mock instance.
+func NewMockAddress(ctrl *gomock.Controller) *MockAddress {
+	mock := &MockAddress{ctrl: ctrl}
+	mock.recorder = &MockAddressMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAddress) EXPECT() *MockAddressMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAddress) Create(ctx context.Context, address entity.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAddressMockRecorder) Create(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAddress)(nil).Create), ctx, address)
+}
+
+// DeleteById mocks base method.
+func (m *MockAddress) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockAddressMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockAddress)(nil).DeleteById), ctx, id)
+}
+
+// GetAllByUserId mocks base method.
+func (m *MockAddress) GetAllByUserId(ctx context.Context, userId uuid.UUID) ([]*entity.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByUserId", ctx, userId)
+	ret0, _ := ret[0].([]*entity.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByUserId indicates an expected call of GetAllByUserId.
+func (mr *MockAddressMockRecorder) GetAllByUserId(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByUserId", reflect.TypeOf((*MockAddress)(nil).GetAllByUserId), ctx, userId)
+}
+
+// GetById mocks base method.
+func (m *MockAddress) GetById(ctx context.Context, id uuid.UUID) (*entity.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*entity.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockAddressMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockAddress)(nil).GetById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockAddress) Update(ctx context.Context, address entity.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAddressMockRecorder) Update(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAddress)(nil).Update), ctx, address)
+}
+
+// MockShipment is a mock of Shipment interface.
+type MockShipment struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentMockRecorder
+}
+
+// MockShipmentMockRecorder is the mock recorder for MockShipment.
+type MockShipmentMockRecorder struct {
+	mock *MockShipment
+}
+
+// NewMockShipment creates a new mock instance.
+func NewMockShipment(ctrl *gomock.Controller) *MockShipment {
+	mock := &MockShipment{ctrl: ctrl}
+	mock.recorder = &MockShipmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipment) EXPECT() *MockShipmentMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShipment) Create(ctx context.Context, shipment *entity.Shipment, from, to entity.OrderStatus, events []entity.OrderEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, shipment, from, to, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShipmentMockRecorder) Create(ctx, shipment, from, to, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShipment)(nil).Create), ctx, shipment, from, to, events)
+}
+
+// GetByOrderId mocks base method.
+func (m *MockShipment) GetByOrderId(ctx context.Context, orderId uuid.UUID) ([]*entity.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrderId", ctx, orderId)
+	ret0, _ := ret[0].([]*entity.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrderId indicates an expected call of GetByOrderId.
+func (mr *MockShipmentMockRecorder) GetByOrderId(ctx, orderId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockOrder)(nil).UpdateById), ctx, order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrderId", reflect.TypeOf((*MockShipment)(nil).GetByOrderId), ctx, orderId)
 }
 
 // MockRow is a mock of Row interface.
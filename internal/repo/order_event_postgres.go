@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	_ "github.com/lib/pq"
+)
+
+type OrderEventRepoPg struct {
+	db *sql.DB
+}
+
+func NewOrderEventRepoPg(db *sql.DB) OrderEvent {
+	return &OrderEventRepoPg{
+		db: db,
+	}
+}
+
+// insertOrderEvents records the events in the transaction which changes the order, so the
+// history gets the events only when the change is saved.
+func insertOrderEvents(ctx context.Context, tx *sql.Tx, events ...entity.OrderEvent) error {
+	for _, event := range events {
+		_, err := tx.ExecContext(ctx,
+			"insert into order_events (id, order_id, user_id, type, from_status, to_status, comment, created_at) values ($1,$2,$3,$4,$5,$6,$7,$8)",
+			event.Id, event.OrderId, event.UserId, event.Type, event.FromStatus, event.ToStatus, event.Comment, event.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *OrderEventRepoPg) GetByOrderId(ctx context.Context, orderId uuid.UUID) ([]*entity.OrderEvent, error) {
+	rows, err := o.db.QueryContext(ctx,
+		"select id, order_id, user_id, type, from_status, to_status, comment, created_at from order_events where order_id = $1 order by created_at",
+		orderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	events := []*entity.OrderEvent{}
+	for rows.Next() {
+		event, err := o.scanOrderEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (o *OrderEventRepoPg) scanOrderEvent(row Row) (*entity.OrderEvent, error) {
+	var (
+		createdAt  string
+		userId     uuid.NullUUID
+		fromStatus sql.NullString
+		toStatus   sql.NullString
+	)
+	event := new(entity.OrderEvent)
+	err := row.Scan(&event.Id, &event.OrderId, &userId, &event.Type, &fromStatus, &toStatus, &event.Comment, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if userId.Valid {
+		event.UserId = &userId.UUID
+	}
+	if fromStatus.Valid {
+		status := entity.OrderStatus(fromStatus.String)
+		event.FromStatus = &status
+	}
+	if toStatus.Valid {
+		status := entity.OrderStatus(toStatus.String)
+		event.ToStatus = &status
+	}
+	event.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -3,6 +3,7 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"strconv"
 	"strings"
 	"time"
@@ -22,27 +23,47 @@ func NewOrderRepoPg(db *sql.DB) Order {
 	}
 }
 
-func (o *OrderRepoPg) Create(ctx context.Context, order *entity.Order) error {
+// Create saves the order and the event of its creation.
+func (o *OrderRepoPg) Create(ctx context.Context, order *entity.Order, event entity.OrderEvent) error {
 	tx, err := o.db.Begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
-	_, err = tx.ExecContext(ctx, "insert into orders(id, user_id, status, created_at) values ($1,$2,$3,$4)",
-		order.Id, order.UserId, order.Status, order.CreatedAt)
+	err = checkPromoCodeUsage(ctx, tx, order)
 	if err != nil {
 		return err
 	}
-	values := []string{}
-	for _, product := range order.Products {
-		values = append(values, "('"+product.Product.Id.String()+"','"+order.Id.String()+
-			"',"+strconv.Itoa(product.Count)+","+strconv.FormatFloat(float64(product.Product.Price), 'f', 2, 32)+")")
+	_, err = tx.ExecContext(ctx, "insert into orders(id, user_id, status, created_at, currency, exchange_rate, subtotal, discount, tax, total, reserved_until, promo_code_id, promo_code, prices_include_tax, shipping_method, shipping) "+
+		"values ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16)",
+		order.Id, order.UserId, order.Status, order.CreatedAt, order.Currency, order.ExchangeRate,
+		order.Subtotal.Amount, order.Discount.Amount, order.Tax.Amount, order.Total.Amount, order.ReservedUntil,
+		order.PromoCodeId, order.PromoCode, order.PricesIncludeTax,
+		sql.NullString{String: order.ShippingMethod, Valid: order.ShippingMethod != ""}, order.Shipping.Amount)
+	if err != nil {
+		return err
 	}
-	_, err = tx.ExecContext(ctx, "insert into order_products(product_id,order_id,product_count,product_price) values "+strings.Join(values, ","))
+	_, err = tx.ExecContext(ctx, "insert into order_products(product_id,order_id,product_count,product_price,discount,tax_rate,tax) values "+orderProductValues(order))
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
+	err = insertLinePromotions(ctx, tx, order)
+	if err != nil {
+		return err
+	}
+	err = insertOrderAddresses(ctx, tx, order)
+	if err != nil {
+		return err
+	}
+	err = takeStock(ctx, tx, order, entity.StockMovementTypeOrderPlaced)
+	if err != nil {
+		return err
+	}
+	err = insertOrderEvents(ctx, tx, event)
+	if err != nil {
+		return err
+	}
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -51,26 +72,99 @@ func (o *OrderRepoPg) Create(ctx context.Context, order *entity.Order) error {
 }
 
 func (o *OrderRepoPg) GetAll(ctx context.Context, filter *entity.OrderFilter) ([]*entity.Order, error) {
+	return o.getOrders(ctx, whereClause(orderFilterConditions(filter)), " order by orders.created_at, orders.id")
+}
+
+func (o *OrderRepoPg) GetPage(ctx context.Context, filter *entity.OrderFilter, pagination entity.Pagination) (*entity.OrderPage, error) {
+	// Totals are in the currency of the order, so orders are sorted by the total in the base currency.
+	keyset, err := newKeysetPage(pagination, map[entity.SortBy]sortColumn{
+		entity.SortByCreatedAt: {expression: "orders.created_at", cast: "timestamp"},
+		entity.SortByPrice:     {expression: "orders.total / orders.exchange_rate", cast: "numeric"},
+	}, "orders.id")
+	if err != nil {
+		return nil, err
+	}
+	whereS := orderFilterConditions(filter)
+	page := new(entity.OrderPage)
+	err = o.db.QueryRowContext(ctx, "select count(*) from orders"+whereClause(whereS)).Scan(&page.TotalCount)
+	if err != nil {
+		return nil, err
+	}
+	condition, args, err := keyset.condition([]any{})
+	if err != nil {
+		return nil, err
+	}
+	if condition != "" {
+		whereS = append(whereS, condition)
+	}
+	rows, err := o.db.QueryContext(ctx, "select orders.id,"+keyset.sortValue()+" from orders"+whereClause(whereS)+keyset.orderBy(), args...)
+	if err != nil {
+		return nil, err
+	}
+	values := []string{}
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		var value string
+		err := rows.Scan(&id, &value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+		values = append(values, value)
+	}
+	page.NextCursor = keyset.nextCursor(values, ids)
+	if len(ids) > keyset.limit() {
+		ids = ids[:keyset.limit()]
+	}
+	page.Items = []*entity.Order{}
+	if len(ids) == 0 {
+		return page, nil
+	}
+	in := []string{}
+	for _, id := range ids {
+		in = append(in, "'"+id.String()+"'")
+	}
+	page.Items, err = o.getOrders(ctx, " where orders.id in ("+strings.Join(in, ",")+")", keyset.sorting())
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func orderFilterConditions(filter *entity.OrderFilter) []string {
+	whereS := []string{}
+	if filter == nil {
+		return whereS
+	}
+	if filter.UserId != nil {
+		whereS = append(whereS, "orders.user_id = '"+(*filter.UserId).String()+"'")
+	}
+	if filter.Status != nil {
+		whereS = append(whereS, "orders.status = '"+string(*filter.Status)+"'")
+	}
+	return whereS
+}
+
+// getOrders selects orders with their products. Rows must be ordered so that
+// products of an order follow each other.
+func (o *OrderRepoPg) getOrders(ctx context.Context, where string, order string) ([]*entity.Order, error) {
 	var orderCreatedAt string
+	var reservedUntil sql.NullString
 	var productCreatedAt string
 	var producerCreatedAt string
-	where := ""
-	if filter != nil {
-		whereS := []string{}
-		if filter.UserId != nil {
-			whereS = append(whereS, "orders.user_id = '"+(*filter.UserId).String()+"'")
-		}
-		if filter.Status != nil {
-			whereS = append(whereS, "orders.status = '"+string(*filter.Status)+"'")
-		}
-		where = " where " + strings.Join(whereS, " and ")
-	}
+	var currency entity.Currency
+	var promoCodeId uuid.NullUUID
+	var promoCode sql.NullString
+	var shippingMethod sql.NullString
+	var discount, tax int64
+	var taxRate float64
 	rows, err := o.db.QueryContext(ctx,
 		"select "+
-			"orders.id, orders.user_id, orders.status, orders.created_at, products.id, products.name, order_products.product_price, producers.id, producers.name, producers.description, producers.created_at, products.status, products.created_at, order_products.product_count "+
+			"orders.id, orders.user_id, orders.status, orders.created_at, orders.currency, orders.exchange_rate, orders.subtotal, orders.discount, orders.tax, orders.prices_include_tax, orders.shipping_method, orders.shipping, orders.total, orders.reserved_until, orders.promo_code_id, orders.promo_code, products.id, products.name, order_products.product_price, order_products.discount, order_products.tax_rate, order_products.tax, producers.id, producers.name, producers.description, producers.created_at, products.status, products.created_at, order_products.product_count "+
 			"from "+
 			"orders join order_products on order_products.order_id = orders.id join products on order_products.product_id = products.id join producers on products.producer_id = producers.id"+
-			where)
+			where+order)
 	if err != nil {
 		return nil, err
 	}
@@ -82,18 +176,25 @@ func (o *OrderRepoPg) GetAll(ctx context.Context, filter *entity.OrderFilter) ([
 			Product: &entity.Product{},
 		}
 		producer := new(entity.Producer)
-		err := rows.Scan(&order.Id, &order.UserId, &order.Status, &orderCreatedAt, &product.Product.Id, &product.Product.Name,
-			&product.Product.Price, &producer.Id, &producer.Name, &producer.Description, &producerCreatedAt,
+		err := rows.Scan(&order.Id, &order.UserId, &order.Status, &orderCreatedAt, &currency, &order.ExchangeRate,
+			&order.Subtotal.Amount, &order.Discount.Amount, &order.Tax.Amount, &order.PricesIncludeTax, &shippingMethod, &order.Shipping.Amount, &order.Total.Amount, &reservedUntil, &promoCodeId, &promoCode,
+			&product.Product.Id, &product.Product.Name, &product.Product.Price.Amount, &discount, &taxRate, &tax, &producer.Id, &producer.Name, &producer.Description, &producerCreatedAt,
 			&product.Product.Status, &productCreatedAt, &product.Count)
 		if err != nil {
 			return nil, err
 		}
 		if order.Id != previousOrderId {
+			setOrderCurrency(&order, currency)
 			order.CreatedAt, err = time.Parse(time.RFC3339, orderCreatedAt)
 			if err != nil {
 				return nil, err
 			}
-
+			order.ReservedUntil, err = parseNullTime(reservedUntil)
+			if err != nil {
+				return nil, err
+			}
+			setOrderPromoCode(&order, promoCodeId, promoCode)
+			order.ShippingMethod = shippingMethod.String
 			orders = append(orders, &order)
 			previousOrderId = order.Id
 		}
@@ -106,18 +207,197 @@ func (o *OrderRepoPg) GetAll(ctx context.Context, filter *entity.OrderFilter) ([
 			return nil, err
 		}
 		product.Product.Producer = producer
+		setOrderLine(product, currency, discount, taxRate, tax)
 		orders[len(orders)-1].Products = append(orders[len(orders)-1].Products, product)
+		orders[len(orders)-1].ItemsCount += product.Count
+	}
+	err = o.loadAllocations(ctx, orders)
+	if err != nil {
+		return nil, err
+	}
+	err = o.loadLinePromotions(ctx, orders)
+	if err != nil {
+		return nil, err
+	}
+	err = o.loadOrderAddresses(ctx, orders)
+	if err != nil {
+		return nil, err
 	}
 	return orders, nil
 }
 
+// loadAllocations fills the warehouse allocations of the order lines.
+func (o *OrderRepoPg) loadAllocations(ctx context.Context, orders []*entity.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	lines := map[uuid.UUID]map[uuid.UUID]*entity.ProductInCart{}
+	in := []string{}
+	for _, order := range orders {
+		lines[order.Id] = map[uuid.UUID]*entity.ProductInCart{}
+		for _, product := range order.Products {
+			lines[order.Id][product.Product.Id] = product
+		}
+		in = append(in, "'"+order.Id.String()+"'")
+	}
+	rows, err := o.db.QueryContext(ctx,
+		"select order_allocations.order_id, order_allocations.product_id, order_allocations.warehouse_id, warehouses.name, order_allocations.count "+
+			"from order_allocations join warehouses on warehouses.id = order_allocations.warehouse_id "+
+			"where order_allocations.order_id in ("+strings.Join(in, ",")+") order by warehouses.priority, warehouses.name")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var orderId, productId uuid.UUID
+		allocation := new(entity.OrderAllocation)
+		err := rows.Scan(&orderId, &productId, &allocation.WarehouseId, &allocation.WarehouseName, &allocation.Count)
+		if err != nil {
+			return err
+		}
+		line, ok := lines[orderId][productId]
+		if !ok {
+			continue
+		}
+		line.Allocations = append(line.Allocations, allocation)
+	}
+	return rows.Err()
+}
+
+// loadLinePromotions fills the promotions which discounted the order lines.
+func (o *OrderRepoPg) loadLinePromotions(ctx context.Context, orders []*entity.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	lines := map[uuid.UUID]map[uuid.UUID]*entity.ProductInCart{}
+	currencies := map[uuid.UUID]entity.Currency{}
+	in := []string{}
+	for _, order := range orders {
+		lines[order.Id] = map[uuid.UUID]*entity.ProductInCart{}
+		for _, product := range order.Products {
+			lines[order.Id][product.Product.Id] = product
+		}
+		currencies[order.Id] = order.Currency
+		in = append(in, "'"+order.Id.String()+"'")
+	}
+	rows, err := o.db.QueryContext(ctx,
+		"select order_id, product_id, promotion_id, name, type, discount from order_line_promotions "+
+			"where order_id in ("+strings.Join(in, ",")+") order by name")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var orderId, productId uuid.UUID
+		var promotionId uuid.NullUUID
+		promotion := new(entity.AppliedPromotion)
+		err := rows.Scan(&orderId, &productId, &promotionId, &promotion.Name, &promotion.Type, &promotion.Discount.Amount)
+		if err != nil {
+			return err
+		}
+		line, ok := lines[orderId][productId]
+		if !ok {
+			continue
+		}
+		if promotionId.Valid {
+			promotion.PromotionId = &promotionId.UUID
+		}
+		promotion.Discount.Currency = currencies[orderId]
+		line.Promotions = append(line.Promotions, promotion)
+	}
+	return rows.Err()
+}
+
+// insertLinePromotions saves the promotions which discounted the order lines.
+func insertLinePromotions(ctx context.Context, tx *sql.Tx, order *entity.Order) error {
+	for _, product := range order.Products {
+		for _, promotion := range product.Promotions {
+			_, err := tx.ExecContext(ctx,
+				"insert into order_line_promotions (order_id, product_id, promotion_id, name, type, discount) values ($1,$2,$3,$4,$5,$6)",
+				order.Id, product.Product.Id, promotion.PromotionId, promotion.Name, promotion.Type, promotion.Discount.Amount)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// loadOrderAddresses fills the shipping and billing addresses the orders were placed with.
+func (o *OrderRepoPg) loadOrderAddresses(ctx context.Context, orders []*entity.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	byId := map[uuid.UUID]*entity.Order{}
+	in := []string{}
+	for _, order := range orders {
+		byId[order.Id] = order
+		in = append(in, "'"+order.Id.String()+"'")
+	}
+	rows, err := o.db.QueryContext(ctx,
+		"select order_id, type, recipient, phone, country, region, city, street, postal_code from order_addresses "+
+			"where order_id in ("+strings.Join(in, ",")+")")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var orderId uuid.UUID
+		var addressType entity.OrderAddressType
+		address := new(entity.OrderAddress)
+		err := rows.Scan(&orderId, &addressType, &address.Recipient, &address.Phone, &address.Country, &address.Region,
+			&address.City, &address.Street, &address.PostalCode)
+		if err != nil {
+			return err
+		}
+		order, ok := byId[orderId]
+		if !ok {
+			continue
+		}
+		switch addressType {
+		case entity.OrderAddressTypeShipping:
+			order.ShippingAddress = address
+		case entity.OrderAddressTypeBilling:
+			order.BillingAddress = address
+		}
+	}
+	return rows.Err()
+}
+
+// insertOrderAddresses saves the copies of the addresses the order is placed with.
+func insertOrderAddresses(ctx context.Context, tx *sql.Tx, order *entity.Order) error {
+	for _, addressType := range []entity.OrderAddressType{entity.OrderAddressTypeShipping, entity.OrderAddressTypeBilling} {
+		address := order.ShippingAddress
+		if addressType == entity.OrderAddressTypeBilling {
+			address = order.BillingAddress
+		}
+		if address == nil {
+			continue
+		}
+		_, err := tx.ExecContext(ctx,
+			"insert into order_addresses (order_id, type, recipient, phone, country, region, city, street, postal_code) values ($1,$2,$3,$4,$5,$6,$7,$8,$9)",
+			order.Id, addressType, address.Recipient, address.Phone, address.Country, address.Region, address.City, address.Street, address.PostalCode)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (o *OrderRepoPg) GetById(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
 	var orderCreatedAt string
+	var reservedUntil sql.NullString
 	var productCreatedAt string
 	var producerCreatedAt string
+	var currency entity.Currency
+	var promoCodeId uuid.NullUUID
+	var promoCode sql.NullString
+	var shippingMethod sql.NullString
+	var discount, tax int64
+	var taxRate float64
 	rows, err := o.db.QueryContext(ctx,
 		"select "+
-			"orders.id, orders.user_id, orders.status, orders.created_at, products.id, products.name, order_products.product_price, producers.id, producers.name, producers.description, producers.created_at, products.status, products.created_at, order_products.product_count "+
+			"orders.id, orders.user_id, orders.status, orders.created_at, orders.currency, orders.exchange_rate, orders.subtotal, orders.discount, orders.tax, orders.prices_include_tax, orders.shipping_method, orders.shipping, orders.total, orders.reserved_until, orders.promo_code_id, orders.promo_code, products.id, products.name, order_products.product_price, order_products.discount, order_products.tax_rate, order_products.tax, producers.id, producers.name, producers.description, producers.created_at, products.status, products.created_at, order_products.product_count "+
 			"from "+
 			"orders join order_products on order_products.order_id = orders.id join products on order_products.product_id = products.id join producers on products.producer_id = producers.id "+
 			"where orders.id = $1",
@@ -132,17 +412,25 @@ func (o *OrderRepoPg) GetById(ctx context.Context, id uuid.UUID) (*entity.Order,
 			Product: &entity.Product{},
 		}
 		producer := new(entity.Producer)
-		err := rows.Scan(&order.Id, &order.UserId, &order.Status, &orderCreatedAt, &product.Product.Id, &product.Product.Name,
-			&product.Product.Price, &producer.Id, &producer.Name, &producer.Description, &producerCreatedAt,
+		err := rows.Scan(&order.Id, &order.UserId, &order.Status, &orderCreatedAt, &currency, &order.ExchangeRate,
+			&order.Subtotal.Amount, &order.Discount.Amount, &order.Tax.Amount, &order.PricesIncludeTax, &shippingMethod, &order.Shipping.Amount, &order.Total.Amount, &reservedUntil, &promoCodeId, &promoCode,
+			&product.Product.Id, &product.Product.Name, &product.Product.Price.Amount, &discount, &taxRate, &tax, &producer.Id, &producer.Name, &producer.Description, &producerCreatedAt,
 			&product.Product.Status, &productCreatedAt, &product.Count)
 		if err != nil {
 			return nil, err
 		}
 		if first {
+			setOrderCurrency(order, currency)
 			order.CreatedAt, err = time.Parse(time.RFC3339, orderCreatedAt)
 			if err != nil {
 				return nil, err
 			}
+			order.ReservedUntil, err = parseNullTime(reservedUntil)
+			if err != nil {
+				return nil, err
+			}
+			setOrderPromoCode(order, promoCodeId, promoCode)
+			order.ShippingMethod = shippingMethod.String
 			first = false
 		}
 
@@ -155,54 +443,122 @@ func (o *OrderRepoPg) GetById(ctx context.Context, id uuid.UUID) (*entity.Order,
 			return nil, err
 		}
 		product.Product.Producer = producer
+		setOrderLine(product, currency, discount, taxRate, tax)
 		order.Products = append(order.Products, product)
+		order.ItemsCount += product.Count
 	}
 	if first {
 		return nil, ErrOrderNotFound
 	}
+	err = o.loadAllocations(ctx, []*entity.Order{order})
+	if err != nil {
+		return nil, err
+	}
+	err = o.loadLinePromotions(ctx, []*entity.Order{order})
+	if err != nil {
+		return nil, err
+	}
+	err = o.loadOrderAddresses(ctx, []*entity.Order{order})
+	if err != nil {
+		return nil, err
+	}
+	order.Shipments, err = getShipments(ctx, o.db, order.Id)
+	if err != nil {
+		return nil, err
+	}
 	return order, nil
 }
 
 func (o *OrderRepoPg) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
-	_, err = o.db.ExecContext(ctx, "delete from orders where id = $1", id)
+	tx, err := o.db.Begin()
 	if err != nil {
 		return err
 	}
-	return nil
+	defer tx.Rollback()
+	err = releaseStock(ctx, tx, id, entity.StockMovementTypeOrderDeleted)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "delete from orders where id = $1", id)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func (o *OrderRepoPg) UpdateById(ctx context.Context, order *entity.Order) (err error) {
+// UpdateById saves the changed status or lines of the order together with the events of the change.
+// from is the status the change was checked against. The order is locked and ErrOrderChanged is
+// returned when it isn't in that status anymore, e.g. when its reservation has expired meanwhile.
+func (o *OrderRepoPg) UpdateById(ctx context.Context, order *entity.Order, from entity.OrderStatus, events []entity.OrderEvent) (err error) {
 	tx, err := o.db.Begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
+	var status entity.OrderStatus
+	err = tx.QueryRowContext(ctx, "select status from orders where id = $1 for update", order.Id).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		return err
+	}
+	if status != from {
+		return ErrOrderChanged
+	}
 	if order.Status != "" {
-		_, err := tx.ExecContext(ctx, "update orders set status = $1  where id = $2", order.Status, order.Id)
+		_, err := tx.ExecContext(ctx, "update orders set status = $1, reserved_until = null where id = $2", order.Status, order.Id)
 		if err != nil {
 			tx.Rollback()
 			return err
 		}
 	}
 	if order.Products != nil {
-		_, err := tx.ExecContext(ctx, "delete from order_products where order_id = $1", order.Id)
+		_, err := tx.ExecContext(ctx, "update orders set subtotal = $1, discount = $2, tax = $3, total = $4, prices_include_tax = $5 where id = $6",
+			order.Subtotal.Amount, order.Discount.Amount, order.Tax.Amount, order.Total.Amount, order.PricesIncludeTax, order.Id)
 		if err != nil {
 			tx.Rollback()
 			return err
 		}
-		values := []string{}
-		for _, product := range order.Products {
-			values = append(values, "('"+product.Product.Id.String()+"','"+order.Id.String()+
-				"',"+strconv.Itoa(product.Count)+","+strconv.FormatFloat(float64(product.Product.Price), 'f', 2, 32)+")")
+		err = releaseStock(ctx, tx, order.Id, entity.StockMovementTypeOrderChanged)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, "delete from order_products where order_id = $1", order.Id)
+		if err != nil {
+			tx.Rollback()
+			return err
 		}
 		_, err = tx.ExecContext(
 			ctx,
-			"insert into order_products(product_id,order_id,product_count,product_price) values "+
-				strings.Join(values, ","))
+			"insert into order_products(product_id,order_id,product_count,product_price,discount,tax_rate,tax) values "+
+				orderProductValues(order))
 		if err != nil {
 			tx.Rollback()
 			return err
 		}
+		_, err = tx.ExecContext(ctx, "delete from order_line_promotions where order_id = $1", order.Id)
+		if err != nil {
+			return err
+		}
+		err = insertLinePromotions(ctx, tx, order)
+		if err != nil {
+			return err
+		}
+		err = takeStock(ctx, tx, order, entity.StockMovementTypeOrderChanged)
+		if err != nil {
+			return err
+		}
+	}
+	if order.Status == entity.OrderStatusCancelled {
+		err = releaseStock(ctx, tx, order.Id, entity.StockMovementTypeOrderCancelled)
+		if err != nil {
+			return err
+		}
+	}
+	err = insertOrderEvents(ctx, tx, events...)
+	if err != nil {
+		return err
 	}
 	err = tx.Commit()
 	if err != nil {
@@ -211,6 +567,79 @@ func (o *OrderRepoPg) UpdateById(ctx context.Context, order *entity.Order) (err
 	return nil
 }
 
+// GetExpiredReservations returns ids of new orders which reservation ended before now.
+func (o *OrderRepoPg) GetExpiredReservations(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	rows, err := o.db.QueryContext(ctx,
+		"select id from orders where status = $1 and reserved_until < $2 order by reserved_until",
+		entity.OrderStatusNew, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ExpireReservation cancels the order, releases its stock and records the event if it is still
+// new and its reservation ended before now. It reports whether the order was cancelled.
+func (o *OrderRepoPg) ExpireReservation(ctx context.Context, id uuid.UUID, now time.Time, event entity.OrderEvent) (bool, error) {
+	return o.cancelNewOrder(ctx, id, &now, event)
+}
+
+// Cancel cancels the order, releases its stock and records the event if the order is still new.
+// It reports whether the order was cancelled.
+func (o *OrderRepoPg) Cancel(ctx context.Context, id uuid.UUID, event entity.OrderEvent) (bool, error) {
+	return o.cancelNewOrder(ctx, id, nil, event)
+}
+
+// cancelNewOrder cancels the order only if it is new, and its reservation ended before
+// reservedBefore when it is set, so an order paid in the meantime is never cancelled.
+func (o *OrderRepoPg) cancelNewOrder(ctx context.Context, id uuid.UUID, reservedBefore *time.Time, event entity.OrderEvent) (bool, error) {
+	tx, err := o.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	query := "update orders set status = $1, reserved_until = null where id = $2 and status = $3"
+	args := []any{entity.OrderStatusCancelled, id, entity.OrderStatusNew}
+	if reservedBefore != nil {
+		query += " and reserved_until < $4"
+		args = append(args, *reservedBefore)
+	}
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+	err = releaseStock(ctx, tx, id, entity.StockMovementTypeOrderCancelled)
+	if err != nil {
+		return false, err
+	}
+	err = insertOrderEvents(ctx, tx, event)
+	if err != nil {
+		return false, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (o *OrderRepoPg) CheckIfExistsByProductId(ctx context.Context, productId uuid.UUID) (bool, error) {
 	row := o.db.QueryRowContext(ctx,
 		"select count(orders.id) from orders join order_products on order_products.order_id = orders.id where order_products.product_id = $1",
@@ -225,3 +654,69 @@ func (o *OrderRepoPg) CheckIfExistsByProductId(ctx context.Context, productId uu
 	}
 	return false, nil
 }
+
+// orderProductValues lists the order lines as values of an insert into order_products.
+func orderProductValues(order *entity.Order) string {
+	values := []string{}
+	for _, product := range order.Products {
+		var discount, tax int64
+		var taxRate float64
+		if product.Discount != nil {
+			discount = product.Discount.Amount
+		}
+		if product.TaxRate != nil {
+			taxRate = *product.TaxRate
+		}
+		if product.Tax != nil {
+			tax = product.Tax.Amount
+		}
+		values = append(values, "('"+product.Product.Id.String()+"','"+order.Id.String()+
+			"',"+strconv.Itoa(product.Count)+","+strconv.FormatInt(product.Product.Price.Amount, 10)+
+			","+strconv.FormatInt(discount, 10)+","+strconv.FormatFloat(taxRate, 'f', -1, 64)+
+			","+strconv.FormatInt(tax, 10)+")")
+	}
+	return strings.Join(values, ",")
+}
+
+// setOrderLine fills the fields of the order line which are derived from its row.
+func setOrderLine(product *entity.ProductInCart, currency entity.Currency, discount int64, taxRate float64, tax int64) {
+	product.Product.Price.Currency = currency
+	product.Total = product.Product.Price.Mul(product.Count)
+	if discount != 0 {
+		lineDiscount := entity.NewMoney(discount, currency)
+		product.Discount = &lineDiscount
+	}
+	lineTax := entity.NewMoney(tax, currency)
+	product.TaxRate = &taxRate
+	product.Tax = &lineTax
+}
+
+// setOrderPromoCode sets the promo code the order was placed with.
+func setOrderPromoCode(order *entity.Order, promoCodeId uuid.NullUUID, promoCode sql.NullString) {
+	if promoCodeId.Valid {
+		order.PromoCodeId = &promoCodeId.UUID
+	}
+	if promoCode.Valid {
+		order.PromoCode = &promoCode.String
+	}
+}
+
+func setOrderCurrency(order *entity.Order, currency entity.Currency) {
+	order.Currency = currency
+	order.Subtotal.Currency = currency
+	order.Discount.Currency = currency
+	order.Tax.Currency = currency
+	order.Shipping.Currency = currency
+	order.Total.Currency = currency
+}
+
+func parseNullTime(value sql.NullString) (*time.Time, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value.String)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,89 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderRepoPg_UpdateById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer db.Close()
+
+	r := NewOrderRepoPg(db)
+
+	type mockBehavior func(ctx context.Context)
+
+	orderId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	orderLocked := func(status entity.OrderStatus) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`select status from orders where id = \$1 for update`).
+			WithArgs(orderId).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(status))
+	}
+	testTable := []struct {
+		name         string
+		inputOrder   *entity.Order
+		inputFrom    entity.OrderStatus
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			name:       "OK new to paid",
+			inputOrder: &entity.Order{Id: orderId, Status: entity.OrderStatusPaid},
+			inputFrom:  entity.OrderStatusNew,
+			mockBehavior: func(ctx context.Context) {
+				orderLocked(entity.OrderStatusNew)
+				mock.ExpectExec(`update orders set status = \$1, reserved_until = null where id = \$2`).
+					WithArgs(entity.OrderStatusPaid, orderId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "reservation expired meanwhile",
+			inputOrder: &entity.Order{Id: orderId, Status: entity.OrderStatusPaid},
+			inputFrom:  entity.OrderStatusNew,
+			mockBehavior: func(ctx context.Context) {
+				orderLocked(entity.OrderStatusCancelled)
+				mock.ExpectRollback()
+			},
+			expectedErr: ErrOrderChanged,
+		},
+		{
+			name:       "order not found",
+			inputOrder: &entity.Order{Id: orderId, Status: entity.OrderStatusPaid},
+			inputFrom:  entity.OrderStatusNew,
+			mockBehavior: func(ctx context.Context) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`select status from orders where id = \$1 for update`).
+					WithArgs(orderId).
+					WillReturnRows(sqlmock.NewRows([]string{"status"}))
+				mock.ExpectRollback()
+			},
+			expectedErr: ErrOrderNotFound,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(context.Background())
+			err := r.UpdateById(context.Background(), testCase.inputOrder, testCase.inputFrom, nil)
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
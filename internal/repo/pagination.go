@@ -0,0 +1,147 @@
+package repo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+)
+
+// sortColumn is an expression a list can be ordered by and the SQL type
+// the cursor value is cast back to when it is compared with the expression.
+type sortColumn struct {
+	expression string
+	cast       string
+}
+
+// cursor points right after the last row of a page. Sort and order are kept
+// so that a cursor can't be reused with another ordering.
+type cursor struct {
+	SortBy entity.SortBy    `json:"s"`
+	Order  entity.SortOrder `json:"o"`
+	Value  string           `json:"v"`
+	Id     uuid.UUID        `json:"id"`
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	c := new(cursor)
+	err = json.Unmarshal(data, c)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// keysetPage builds the parts of a keyset paginated query. Rows are ordered by
+// the sort column and then by id, one extra row is fetched to find out whether
+// there is a next page.
+type keysetPage struct {
+	pagination entity.Pagination
+	column     sortColumn
+	idColumn   string
+}
+
+func newKeysetPage(pagination entity.Pagination, columns map[entity.SortBy]sortColumn, idColumn string) (*keysetPage, error) {
+	if pagination.SortBy == "" {
+		pagination.SortBy = entity.SortByCreatedAt
+	}
+	column, ok := columns[pagination.SortBy]
+	if !ok {
+		return nil, ErrUnsupportedSort
+	}
+	if pagination.Order == "" {
+		pagination.Order = entity.SortOrderAsc
+		if pagination.SortBy == entity.SortByCreatedAt || pagination.SortBy == entity.SortByRelevance {
+			pagination.Order = entity.SortOrderDesc
+		}
+	}
+	if pagination.Limit <= 0 || pagination.Limit > entity.MaxPageLimit {
+		pagination.Limit = entity.DefaultPageLimit
+	}
+	return &keysetPage{
+		pagination: pagination,
+		column:     column,
+		idColumn:   idColumn,
+	}, nil
+}
+
+// condition returns the keyset condition for the cursor or an empty string for the first page.
+// Cursor values are appended to args.
+func (k *keysetPage) condition(args []any) (string, []any, error) {
+	if k.pagination.Cursor == "" {
+		return "", args, nil
+	}
+	c, err := decodeCursor(k.pagination.Cursor)
+	if err != nil {
+		return "", nil, err
+	}
+	if c.SortBy != k.pagination.SortBy || c.Order != k.pagination.Order {
+		return "", nil, ErrInvalidCursor
+	}
+	args = append(args, c.Value, c.Id)
+	operator := " > "
+	if k.pagination.Order == entity.SortOrderDesc {
+		operator = " < "
+	}
+	return "(" + k.column.expression + ", " + k.idColumn + ")" + operator +
+		"($" + strconv.Itoa(len(args)-1) + "::" + k.column.cast + ", $" + strconv.Itoa(len(args)) + ")", args, nil
+}
+
+func (k *keysetPage) sortValue() string {
+	return "(" + k.column.expression + ")::text"
+}
+
+func (k *keysetPage) sorting() string {
+	return " order by " + k.column.expression + " " + string(k.pagination.Order) + ", " + k.idColumn + " " + string(k.pagination.Order)
+}
+
+func (k *keysetPage) orderBy() string {
+	return k.sorting() + " limit " + strconv.Itoa(k.pagination.Limit+1)
+}
+
+func (k *keysetPage) limit() int {
+	return k.pagination.Limit
+}
+
+// nextCursor returns the cursor after the last row of the page
+// or an empty string if no extra row was fetched.
+func (k *keysetPage) nextCursor(values []string, ids []uuid.UUID) string {
+	if len(ids) <= k.pagination.Limit {
+		return ""
+	}
+	return encodeCursor(cursor{
+		SortBy: k.pagination.SortBy,
+		Order:  k.pagination.Order,
+		Value:  values[k.pagination.Limit-1],
+		Id:     ids[k.pagination.Limit-1],
+	})
+}
+
+// sortedRow scans the sort value that paginated queries select after the entity columns.
+type sortedRow struct {
+	Row
+	value *string
+}
+
+func (r sortedRow) Scan(dest ...interface{}) error {
+	return r.Row.Scan(append(dest, r.value)...)
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " where " + strings.Join(conditions, " and ")
+}
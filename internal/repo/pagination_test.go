@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysetPage_Condition(t *testing.T) {
+	id := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	columns := map[entity.SortBy]sortColumn{
+		entity.SortByCreatedAt: {expression: "created_at", cast: "timestamp"},
+		entity.SortByName:      {expression: "name", cast: "text"},
+	}
+	testTable := []struct {
+		name              string
+		inputPagination   entity.Pagination
+		expectedCondition string
+		expectedArgs      []any
+		expectedErr       error
+	}{
+		{
+			name:              "first page",
+			inputPagination:   entity.Pagination{Limit: 10},
+			expectedCondition: "",
+			expectedArgs:      []any{},
+			expectedErr:       nil,
+		},
+		{
+			name: "next page by name",
+			inputPagination: entity.Pagination{
+				Limit:  10,
+				SortBy: entity.SortByName,
+				Cursor: encodeCursor(cursor{SortBy: entity.SortByName, Order: entity.SortOrderAsc, Value: "HP", Id: id}),
+			},
+			expectedCondition: "(name, id) > ($1::text, $2)",
+			expectedArgs:      []any{"HP", id},
+			expectedErr:       nil,
+		},
+		{
+			name: "next page by created_at",
+			inputPagination: entity.Pagination{
+				Limit:  10,
+				Cursor: encodeCursor(cursor{SortBy: entity.SortByCreatedAt, Order: entity.SortOrderDesc, Value: "2025-06-25 00:00:00", Id: id}),
+			},
+			expectedCondition: "(created_at, id) < ($1::timestamp, $2)",
+			expectedArgs:      []any{"2025-06-25 00:00:00", id},
+			expectedErr:       nil,
+		},
+		{
+			name: "cursor of another sort",
+			inputPagination: entity.Pagination{
+				Limit:  10,
+				SortBy: entity.SortByName,
+				Order:  entity.SortOrderDesc,
+				Cursor: encodeCursor(cursor{SortBy: entity.SortByName, Order: entity.SortOrderAsc, Value: "HP", Id: id}),
+			},
+			expectedErr: ErrInvalidCursor,
+		},
+		{
+			name:            "malformed cursor",
+			inputPagination: entity.Pagination{Limit: 10, Cursor: "not a cursor"},
+			expectedErr:     ErrInvalidCursor,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			keyset, err := newKeysetPage(testCase.inputPagination, columns, "id")
+			assert.NoError(t, err)
+			condition, args, err := keyset.condition([]any{})
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedCondition, condition)
+				assert.Equal(t, testCase.expectedArgs, args)
+			}
+		})
+	}
+}
+
+func TestProducerRepoPg_GetPage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer db.Close()
+
+	r := NewProducerRepoPg(db)
+
+	producerRows := func(count int) *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{"id", "name", "description", "created_at", "sort_value"})
+		for i := 1; i <= count; i++ {
+			rows.AddRow(uuid.MustParse(fmt.Sprintf("00000000-0000-0000-0000-00000000000%d", i)), fmt.Sprintf("Producer %d", i),
+				"description", "2025-06-25T00:00:00Z", fmt.Sprintf("Producer %d", i))
+		}
+		return rows
+	}
+	testTable := []struct {
+		name               string
+		inputPagination    entity.Pagination
+		mockBehavior       func()
+		expectedItemsCount int
+		expectedNextCursor string
+		wantErr            bool
+	}{
+		{
+			name:            "OK has next page",
+			inputPagination: entity.Pagination{Limit: 2, SortBy: entity.SortByName},
+			mockBehavior: func() {
+				mock.ExpectQuery(regexp.QuoteMeta("select count(*) from producers")).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+				mock.ExpectQuery(regexp.QuoteMeta("select *,(name)::text from producers order by name asc, id asc limit 3")).
+					WillReturnRows(producerRows(3))
+			},
+			expectedItemsCount: 2,
+			expectedNextCursor: encodeCursor(cursor{
+				SortBy: entity.SortByName,
+				Order:  entity.SortOrderAsc,
+				Value:  "Producer 2",
+				Id:     uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			}),
+			wantErr: false,
+		},
+		{
+			name:            "OK last page",
+			inputPagination: entity.Pagination{Limit: 2, SortBy: entity.SortByName},
+			mockBehavior: func() {
+				mock.ExpectQuery(regexp.QuoteMeta("select count(*) from producers")).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectQuery(regexp.QuoteMeta("select *,(name)::text from producers order by name asc, id asc limit 3")).
+					WillReturnRows(producerRows(2))
+			},
+			expectedItemsCount: 2,
+			expectedNextCursor: "",
+			wantErr:            false,
+		},
+		{
+			name:            "unsupported sort",
+			inputPagination: entity.Pagination{Limit: 2, SortBy: entity.SortByPrice},
+			mockBehavior:    func() {},
+			wantErr:         true,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+			page, err := r.GetPage(context.Background(), testCase.inputPagination)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, page.Items, testCase.expectedItemsCount)
+				assert.Equal(t, testCase.expectedNextCursor, page.NextCursor)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -38,6 +38,51 @@ func (p *ProducerRepoPg) GetAll(ctx context.Context) ([]*entity.Producer, error)
 	return producers, nil
 }
 
+func (p *ProducerRepoPg) GetPage(ctx context.Context, pagination entity.Pagination) (*entity.ProducerPage, error) {
+	keyset, err := newKeysetPage(pagination, map[entity.SortBy]sortColumn{
+		entity.SortByCreatedAt: {expression: "created_at", cast: "timestamp"},
+		entity.SortByName:      {expression: "name", cast: "text"},
+	}, "id")
+	if err != nil {
+		return nil, err
+	}
+	page := new(entity.ProducerPage)
+	err = p.db.QueryRowContext(ctx, "select count(*) from producers").Scan(&page.TotalCount)
+	if err != nil {
+		return nil, err
+	}
+	condition, args, err := keyset.condition([]any{})
+	if err != nil {
+		return nil, err
+	}
+	where := ""
+	if condition != "" {
+		where = " where " + condition
+	}
+	rows, err := p.db.QueryContext(ctx, "select *,"+keyset.sortValue()+" from producers"+where+keyset.orderBy(), args...)
+	if err != nil {
+		return nil, err
+	}
+	page.Items = []*entity.Producer{}
+	values := []string{}
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var value string
+		producer, err := p.scanProducer(sortedRow{Row: rows, value: &value})
+		if err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, producer)
+		values = append(values, value)
+		ids = append(ids, producer.Id)
+	}
+	page.NextCursor = keyset.nextCursor(values, ids)
+	if len(page.Items) > keyset.limit() {
+		page.Items = page.Items[:keyset.limit()]
+	}
+	return page, nil
+}
+
 func (p *ProducerRepoPg) GetById(ctx context.Context, id uuid.UUID) (*entity.Producer, error) {
 	row := p.db.QueryRowContext(ctx, "select * from producers where id = $1", id)
 	producer, err := p.scanProducer(row)
@@ -7,12 +7,24 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/krijebr/printer-shop/internal/entity"
 	_ "github.com/lib/pq"
 )
 
+const productColumns = "products.id,products.name,products.price,products.currency,products.status,products.stock,products.tax_class,products.weight,products.length,products.width,products.height,products.created_at,producers.id,producers.name,producers.description,producers.created_at," +
+	"categories.id,categories.parent_id,categories.name,categories.created_at," +
+	"product_specs.print_technology,product_specs.color,product_specs.max_format,product_specs.dpi,product_specs.pages_per_minute," +
+	"product_specs.duplex,product_specs.wifi,product_specs.ethernet,product_specs.scan,product_specs.copy"
+
+const productFrom = " from " +
+	"products join producers on producers.id = producer_id left join product_specs on product_specs.product_id = products.id " +
+	"left join categories on categories.id = products.category_id"
+
+const productSelect = "select " + productColumns + ",''" + productFrom
+
 type ProductRepoPg struct {
 	db *sql.DB
 }
@@ -24,47 +36,133 @@ func NewProductRepoPg(db *sql.DB) Product {
 }
 
 func (p *ProductRepoPg) GetAll(ctx context.Context, filter *entity.ProductFilter) ([]*entity.Product, error) {
+	args := []any{}
+	highlight := "''"
+	order := ""
+	whereS := productFilterConditions(filter)
+	if query := productSearchQuery(filter); query != "" {
+		args = append(args, query)
+		whereS = append(whereS, productSearchCondition)
+		highlight = productSearchHighlight
+		order = " order by " + productSearchRank + " desc, products.name"
+	}
+	rows, err := p.db.QueryContext(ctx, "select "+productColumns+","+highlight+productFrom+whereClause(whereS)+order, args...)
+	if err != nil {
+		return nil, err
+	}
+	return p.scanProducts(rows)
+}
 
-	where := ""
-	if filter != nil {
-		whereS := []string{}
-		if filter.ProducerId != nil {
-			whereS = append(whereS, "producers.id = '"+(*filter.ProducerId).String()+"'")
-		}
-		if filter.Status != nil {
-			whereS = append(whereS, "products.status = '"+string(*filter.Status)+"'")
+func (p *ProductRepoPg) GetPage(ctx context.Context, filter *entity.ProductFilter, pagination entity.Pagination) (*entity.ProductPage, error) {
+	columns := map[entity.SortBy]sortColumn{
+		entity.SortByCreatedAt: {expression: "products.created_at", cast: "timestamp"},
+		entity.SortByPrice:     {expression: "products.price", cast: "bigint"},
+		entity.SortByName:      {expression: "products.name", cast: "text"},
+	}
+	args := []any{}
+	highlight := "''"
+	whereS := productFilterConditions(filter)
+	if query := productSearchQuery(filter); query != "" {
+		args = append(args, query)
+		whereS = append(whereS, productSearchCondition)
+		highlight = productSearchHighlight
+		columns[entity.SortByRelevance] = sortColumn{expression: productSearchRank, cast: "real"}
+		if pagination.SortBy == "" {
+			pagination.SortBy = entity.SortByRelevance
 		}
-		where = " where " + strings.Join(whereS, " and ")
 	}
-
+	keyset, err := newKeysetPage(pagination, columns, "products.id")
+	if err != nil {
+		return nil, err
+	}
+	page := new(entity.ProductPage)
+	err = p.db.QueryRowContext(ctx, "select count(*)"+productFrom+whereClause(whereS), args...).Scan(&page.TotalCount)
+	if err != nil {
+		return nil, err
+	}
+	condition, args, err := keyset.condition(args)
+	if err != nil {
+		return nil, err
+	}
+	if condition != "" {
+		whereS = append(whereS, condition)
+	}
 	rows, err := p.db.QueryContext(ctx,
-		"select "+
-			"products.id,products.name,products.price,products.status,products.created_at,producers.id,producers.name,producers.description,producers.created_at "+
-			"from "+
-			"products join producers on producers.id = producer_id"+
-			where)
+		"select "+productColumns+","+highlight+","+keyset.sortValue()+productFrom+whereClause(whereS)+keyset.orderBy(), args...)
 	if err != nil {
 		return nil, err
 	}
-	products := []*entity.Product{}
+	page.Items = []*entity.Product{}
+	values := []string{}
+	ids := []uuid.UUID{}
 	for rows.Next() {
-		product, err := p.scanProduct(rows)
+		var value string
+		product, err := p.scanProduct(sortedRow{Row: rows, value: &value})
 		if err != nil {
 			return nil, err
 		}
-		products = append(products, product)
+		page.Items = append(page.Items, product)
+		values = append(values, value)
+		ids = append(ids, product.Id)
 	}
-	return products, nil
+	page.NextCursor = keyset.nextCursor(values, ids)
+	if len(page.Items) > keyset.limit() {
+		page.Items = page.Items[:keyset.limit()]
+	}
+	return page, nil
+}
+
+func (p *ProductRepoPg) GetByName(ctx context.Context, name string) ([]*entity.Product, error) {
+	rows, err := p.db.QueryContext(ctx, productSelect+" where products.name = $1", name)
+	if err != nil {
+		return nil, err
+	}
+	return p.scanProducts(rows)
+}
+
+// GetCompatible returns products linked to the product by compatibility in either direction.
+func (p *ProductRepoPg) GetCompatible(ctx context.Context, id uuid.UUID, filter *entity.ProductFilter) ([]*entity.Product, error) {
+	whereS := append(productFilterConditions(filter), "products.id in ("+
+		"select compatible_id from product_compatibility where product_id = $1 "+
+		"union "+
+		"select product_id from product_compatibility where compatible_id = $1)")
+	rows, err := p.db.QueryContext(ctx, productSelect+" where "+strings.Join(whereS, " and ")+" order by products.name", id)
+	if err != nil {
+		return nil, err
+	}
+	return p.scanProducts(rows)
+}
+
+func (p *ProductRepoPg) AddCompatible(ctx context.Context, id uuid.UUID, compatibleId uuid.UUID) error {
+	_, err := p.db.ExecContext(ctx,
+		"insert into product_compatibility (product_id, compatible_id, created_at) values (least($1::uuid,$2::uuid),greatest($1::uuid,$2::uuid),$3) "+
+			"on conflict do nothing",
+		id, compatibleId, time.Now())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *ProductRepoPg) DeleteCompatible(ctx context.Context, id uuid.UUID, compatibleId uuid.UUID) error {
+	result, err := p.db.ExecContext(ctx,
+		"delete from product_compatibility where product_id = least($1::uuid,$2::uuid) and compatible_id = greatest($1::uuid,$2::uuid)",
+		id, compatibleId)
+	if err != nil {
+		return err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrCompatibilityNotFound
+	}
+	return nil
 }
 
 func (p *ProductRepoPg) GetById(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
-	row := p.db.QueryRowContext(ctx,
-		"select "+
-			"products.id,products.name,products.price,products.status,products.created_at,producers.id,producers.name,producers.description,producers.created_at "+
-			"from "+
-			"products join producers on producers.id = producer_id "+
-			"where products.id=$1",
-		id)
+	row := p.db.QueryRowContext(ctx, productSelect+" where products.id=$1", id)
 	product, err := p.scanProduct(row)
 	if err != nil {
 		switch {
@@ -78,33 +176,129 @@ func (p *ProductRepoPg) GetById(ctx context.Context, id uuid.UUID) (*entity.Prod
 }
 
 func (p *ProductRepoPg) Create(ctx context.Context, product entity.Product) error {
-	_, err := p.db.ExecContext(ctx, "insert into products (id, name, price, producer_id, status, created_at) values ($1,$2,$3,$4,$5,$6)",
-		product.Id, product.Name, product.Price, product.Producer.Id, product.Status, product.CreatedAt)
+	tx, err := p.db.Begin()
 	if err != nil {
 		return err
 	}
-	return nil
+	defer tx.Rollback()
+	var categoryId *uuid.UUID
+	if product.Category != nil {
+		categoryId = &product.Category.Id
+	}
+	var length, width, height sql.NullInt64
+	if product.Dimensions != nil {
+		length = sql.NullInt64{Int64: int64(product.Dimensions.Length), Valid: true}
+		width = sql.NullInt64{Int64: int64(product.Dimensions.Width), Valid: true}
+		height = sql.NullInt64{Int64: int64(product.Dimensions.Height), Valid: true}
+	}
+	_, err = tx.ExecContext(ctx, "insert into products (id, name, price, currency, producer_id, category_id, status, stock, tax_class, weight, length, width, height, created_at) "+
+		"values ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)",
+		product.Id, product.Name, product.Price.Amount, product.Price.Currency, product.Producer.Id, categoryId, product.Status, product.Stock,
+		sql.NullString{String: product.TaxClass, Valid: product.TaxClass != ""},
+		sql.NullInt64{Int64: int64(product.Weight), Valid: product.Weight != 0}, length, width, height, product.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if product.Stock != nil {
+		// The initial stock is placed in the preferred warehouse and recorded as its receipt.
+		var warehouseId uuid.UUID
+		err = tx.QueryRowContext(ctx,
+			"insert into warehouse_stock (warehouse_id, product_id, quantity) select id, $1, $2 from warehouses order by priority, name limit 1 returning warehouse_id",
+			product.Id, *product.Stock).Scan(&warehouseId)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			if *product.Stock > 0 {
+				return ErrNoWarehouse
+			}
+		case err != nil:
+			return err
+		case *product.Stock > 0:
+			err = insertStockMovement(ctx, tx, entity.StockMovement{
+				ProductId:   product.Id,
+				WarehouseId: &warehouseId,
+				Delta:       *product.Stock,
+				Stock:       *product.Stock,
+				Type:        entity.StockMovementTypeAdjustment,
+				Reason:      "initial stock",
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if product.Specs != nil {
+		err = p.setSpecs(ctx, tx, product.Id, product.Specs)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
 func (p *ProductRepoPg) Update(ctx context.Context, product entity.Product) error {
 	set := []string{}
+	args := []any{product.Id}
+	setValue := func(column string, value any) {
+		args = append(args, value)
+		set = append(set, column+" = $"+strconv.Itoa(len(args)))
+	}
 	if product.Name != "" {
-		set = append(set, "name = '"+product.Name+"'")
+		setValue("name", product.Name)
 	}
-	if product.Price != 0 {
-		set = append(set, "price = "+strconv.FormatFloat(float64(product.Price), 'f', 2, 32))
+	if product.Price.Amount != 0 {
+		setValue("price", product.Price.Amount)
+		setValue("currency", product.Price.Currency)
 	}
 	if product.Producer.Id != uuid.Nil {
-		set = append(set, "producer_id = '"+product.Producer.Id.String()+"'")
+		setValue("producer_id", product.Producer.Id)
+	}
+	if product.Category != nil {
+		setValue("category_id", product.Category.Id)
 	}
 	if product.Status != "" {
-		set = append(set, "status = '"+string(product.Status)+"'")
+		setValue("status", product.Status)
+	}
+	if product.TaxClass != "" {
+		setValue("tax_class", product.TaxClass)
+	}
+	if product.Weight != 0 {
+		setValue("weight", product.Weight)
 	}
-	_, err := p.db.ExecContext(ctx, "update products set "+strings.Join(set, ", ")+" where id = $1", product.Id)
+	if product.Dimensions != nil {
+		setValue("length", product.Dimensions.Length)
+		setValue("width", product.Dimensions.Width)
+		setValue("height", product.Dimensions.Height)
+	}
+	tx, err := p.db.Begin()
 	if err != nil {
 		return err
 	}
-	return nil
+	defer tx.Rollback()
+	if len(set) > 0 {
+		_, err = tx.ExecContext(ctx, "update products set "+strings.Join(set, ", ")+" where id = $1", args...)
+		if err != nil {
+			return err
+		}
+	}
+	if product.Specs != nil {
+		err = p.setSpecs(ctx, tx, product.Id, product.Specs)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (p *ProductRepoPg) setSpecs(ctx context.Context, tx *sql.Tx, productId uuid.UUID, specs *entity.ProductSpecs) error {
+	_, err := tx.ExecContext(ctx,
+		"insert into product_specs (product_id, print_technology, color, max_format, dpi, pages_per_minute, duplex, wifi, ethernet, scan, copy) "+
+			"values ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) "+
+			"on conflict (product_id) do update set print_technology = excluded.print_technology, color = excluded.color, "+
+			"max_format = excluded.max_format, dpi = excluded.dpi, pages_per_minute = excluded.pages_per_minute, duplex = excluded.duplex, "+
+			"wifi = excluded.wifi, ethernet = excluded.ethernet, scan = excluded.scan, copy = excluded.copy",
+		productId, specs.PrintTechnology, specs.Color, specs.MaxFormat, specs.Dpi, specs.PagesPerMinute,
+		specs.Duplex, specs.WiFi, specs.Ethernet, specs.Scan, specs.Copy)
+	return err
 }
 
 func (p *ProductRepoPg) DeleteById(ctx context.Context, id uuid.UUID) error {
@@ -115,16 +309,163 @@ func (p *ProductRepoPg) DeleteById(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (p *ProductRepoPg) scanProducts(rows *sql.Rows) ([]*entity.Product, error) {
+	products := []*entity.Product{}
+	for rows.Next() {
+		product, err := p.scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	return products, nil
+}
+
+// Full-text search expressions, the search query is always passed as $1.
+const (
+	productTsQuery         = "(to_tsquery('simple', $1) || to_tsquery('russian', $1) || to_tsquery('english', $1))"
+	productSearchCondition = "products.search_vector @@ " + productTsQuery
+	productSearchHighlight = "ts_headline('russian', products.name || ' ' || producers.name, " + productTsQuery + ", 'StartSel=<b>, StopSel=</b>')"
+	productSearchRank      = "ts_rank(products.search_vector, " + productTsQuery + ")"
+)
+
+func productSearchQuery(filter *entity.ProductFilter) string {
+	if filter == nil || filter.Query == nil {
+		return ""
+	}
+	return searchQuery(*filter.Query)
+}
+
+// searchQuery converts user input into a tsquery text with prefix matching on every word,
+// so that a partial model number like "LaserJet M4" still finds "LaserJet M404dn".
+// Only letters and digits are kept, which makes the result safe for to_tsquery.
+func searchQuery(q string) string {
+	words := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i := range words {
+		words[i] += ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+func productFilterConditions(filter *entity.ProductFilter) []string {
+	whereS := []string{}
+	if filter == nil {
+		return whereS
+	}
+	if filter.ProducerId != nil {
+		whereS = append(whereS, "producers.id = '"+(*filter.ProducerId).String()+"'")
+	}
+	if filter.Ids != nil {
+		in := []string{"null"}
+		for _, id := range filter.Ids {
+			in = append(in, "'"+id.String()+"'")
+		}
+		whereS = append(whereS, "products.id in ("+strings.Join(in, ",")+")")
+	}
+	if filter.Status != nil {
+		whereS = append(whereS, "products.status = '"+string(*filter.Status)+"'")
+	}
+	if filter.CategoryId != nil {
+		whereS = append(whereS, "products.category_id in ("+
+			"with recursive subcategories as ("+
+			"select id from categories where id = '"+(*filter.CategoryId).String()+"' "+
+			"union all "+
+			"select categories.id from categories join subcategories on categories.parent_id = subcategories.id"+
+			") select id from subcategories)")
+	}
+	if filter.PrintTechnology != nil {
+		whereS = append(whereS, "product_specs.print_technology = '"+string(*filter.PrintTechnology)+"'")
+	}
+	if filter.Color != nil {
+		whereS = append(whereS, "product_specs.color = "+strconv.FormatBool(*filter.Color))
+	}
+	if filter.MaxFormat != nil {
+		whereS = append(whereS, "product_specs.max_format = '"+string(*filter.MaxFormat)+"'")
+	}
+	if filter.MinDpi != nil {
+		whereS = append(whereS, "product_specs.dpi >= "+strconv.Itoa(*filter.MinDpi))
+	}
+	if filter.MinPagesPerMinute != nil {
+		whereS = append(whereS, "product_specs.pages_per_minute >= "+strconv.Itoa(*filter.MinPagesPerMinute))
+	}
+	if filter.Duplex != nil {
+		whereS = append(whereS, "product_specs.duplex = "+strconv.FormatBool(*filter.Duplex))
+	}
+	if filter.WiFi != nil {
+		whereS = append(whereS, "product_specs.wifi = "+strconv.FormatBool(*filter.WiFi))
+	}
+	if filter.Ethernet != nil {
+		whereS = append(whereS, "product_specs.ethernet = "+strconv.FormatBool(*filter.Ethernet))
+	}
+	if filter.Scan != nil {
+		whereS = append(whereS, "product_specs.scan = "+strconv.FormatBool(*filter.Scan))
+	}
+	if filter.Copy != nil {
+		whereS = append(whereS, "product_specs.copy = "+strconv.FormatBool(*filter.Copy))
+	}
+	return whereS
+}
+
 func (p *ProductRepoPg) scanProduct(row Row) (*entity.Product, error) {
 	var productCreatedAt string
 	var producerCreatedAt string
+	var (
+		stock                                        sql.NullInt64
+		taxClass                                     sql.NullString
+		weight, length, width, height                sql.NullInt64
+		categoryId, categoryParentId                 uuid.NullUUID
+		categoryName, categoryCreatedAt              sql.NullString
+		printTechnology, maxFormat                   sql.NullString
+		dpi, pagesPerMinute                          sql.NullInt64
+		color, duplex, wifi, ethernet, scan, canCopy sql.NullBool
+	)
 	product := new(entity.Product)
 	producer := new(entity.Producer)
-	err := row.Scan(&product.Id, &product.Name, &product.Price, &product.Status, &productCreatedAt,
-		&producer.Id, &producer.Name, &producer.Description, &producerCreatedAt)
+	err := row.Scan(&product.Id, &product.Name, &product.Price.Amount, &product.Price.Currency, &product.Status, &stock, &taxClass,
+		&weight, &length, &width, &height, &productCreatedAt,
+		&producer.Id, &producer.Name, &producer.Description, &producerCreatedAt,
+		&categoryId, &categoryParentId, &categoryName, &categoryCreatedAt,
+		&printTechnology, &color, &maxFormat, &dpi, &pagesPerMinute, &duplex, &wifi, &ethernet, &scan, &canCopy,
+		&product.Highlight)
 	if err != nil {
 		return nil, err
 	}
+	if stock.Valid {
+		productStock := int(stock.Int64)
+		product.Stock = &productStock
+	}
+	product.StockStatus = entity.NewStockStatus(product.Stock)
+	product.TaxClass = taxClass.String
+	setProductSize(product, weight, length, width, height)
+	if categoryId.Valid {
+		product.Category = &entity.Category{
+			Id:   categoryId.UUID,
+			Name: categoryName.String,
+		}
+		if categoryParentId.Valid {
+			product.Category.ParentId = &categoryParentId.UUID
+		}
+		product.Category.CreatedAt, err = time.Parse(time.RFC3339, categoryCreatedAt.String)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if printTechnology.Valid {
+		product.Specs = &entity.ProductSpecs{
+			PrintTechnology: entity.PrintTechnology(printTechnology.String),
+			Color:           color.Bool,
+			MaxFormat:       entity.PaperFormat(maxFormat.String),
+			Dpi:             int(dpi.Int64),
+			PagesPerMinute:  int(pagesPerMinute.Int64),
+			Duplex:          duplex.Bool,
+			WiFi:            wifi.Bool,
+			Ethernet:        ethernet.Bool,
+			Scan:            scan.Bool,
+			Copy:            canCopy.Bool,
+		}
+	}
 	product.CreatedAt, err = time.Parse(time.RFC3339, productCreatedAt)
 	if err != nil {
 		return nil, err
@@ -136,3 +477,15 @@ func (p *ProductRepoPg) scanProduct(row Row) (*entity.Product, error) {
 	product.Producer = producer
 	return product, nil
 }
+
+// setProductSize sets the weight and the dimensions of the product, which are unknown when they're null.
+func setProductSize(product *entity.Product, weight, length, width, height sql.NullInt64) {
+	product.Weight = int(weight.Int64)
+	if length.Valid && width.Valid && height.Valid {
+		product.Dimensions = &entity.Dimensions{
+			Length: int(length.Int64),
+			Width:  int(width.Int64),
+			Height: int(height.Int64),
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchQuery(t *testing.T) {
+	testTable := []struct {
+		name          string
+		input         string
+		expectedQuery string
+	}{
+		{
+			name:          "model number",
+			input:         "LaserJet M4",
+			expectedQuery: "laserjet:* & m4:*",
+		},
+		{
+			name:          "russian words",
+			input:         "Цветной  принтер",
+			expectedQuery: "цветной:* & принтер:*",
+		},
+		{
+			name:          "tsquery operators are dropped",
+			input:         "hp' | !canon & (wi-fi):*",
+			expectedQuery: "hp:* & canon:* & wi:* & fi:*",
+		},
+		{
+			name:          "no words",
+			input:         " -:& ",
+			expectedQuery: "",
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedQuery, searchQuery(testCase.input))
+		})
+	}
+}
+
+func TestProductRepoPg_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer db.Close()
+
+	r := NewProductRepoPg(db)
+
+	type mockBehavior func(ctx context.Context)
+
+	query := "LaserJet M4"
+	blankQuery := "  "
+	productId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	producerId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	productRows := func(highlight string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "name", "price", "currency", "status", "stock", "tax_class", "weight", "length", "width", "height", "created_at",
+			"producer_id", "producer_name", "description", "producer_created_at",
+			"category_id", "parent_id", "category_name", "category_created_at",
+			"print_technology", "color", "max_format", "dpi", "pages_per_minute", "duplex", "wifi", "ethernet", "scan", "copy",
+			"highlight"}).
+			AddRow(productId, "LaserJet M404dn", 2500000, "RUB", "published", 3, nil, 9500, 420, 380, 300, "2025-06-25T00:00:00Z",
+				producerId, "HP", "", "2025-06-25T00:00:00Z",
+				nil, nil, nil, nil,
+				nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+				highlight)
+	}
+	testTable := []struct {
+		name              string
+		inputFilter       *entity.ProductFilter
+		mockBehavior      mockBehavior
+		expectedHighlight string
+		wantErr           bool
+	}{
+		{
+			name:        "OK search",
+			inputFilter: &entity.ProductFilter{Query: &query},
+			mockBehavior: func(ctx context.Context) {
+				mock.ExpectQuery(`ts_headline\(.+ where products.search_vector @@ .+ order by ts_rank\(`).
+					WithArgs("laserjet:* & m4:*").
+					WillReturnRows(productRows("<b>LaserJet</b> <b>M404dn</b> HP"))
+			},
+			expectedHighlight: "<b>LaserJet</b> <b>M404dn</b> HP",
+			wantErr:           false,
+		},
+		{
+			name:        "blank query is ignored",
+			inputFilter: &entity.ProductFilter{Query: &blankQuery},
+			mockBehavior: func(ctx context.Context) {
+				mock.ExpectQuery(`left join categories on categories.id = products.category_id$`).
+					WithoutArgs().
+					WillReturnRows(productRows(""))
+			},
+			expectedHighlight: "",
+			wantErr:           false,
+		},
+		{
+			name:        "query error",
+			inputFilter: &entity.ProductFilter{Query: &query},
+			mockBehavior: func(ctx context.Context) {
+				mock.ExpectQuery("select").WillReturnError(someErr)
+			},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(context.Background())
+			products, err := r.GetAll(context.Background(), testCase.inputFilter)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, products, 1)
+				assert.Equal(t, testCase.expectedHighlight, products[0].Highlight)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductRepoPg_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer db.Close()
+
+	r := NewProductRepoPg(db)
+
+	type mockBehavior func(ctx context.Context)
+
+	productId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	testTable := []struct {
+		name         string
+		inputProduct entity.Product
+		mockBehavior mockBehavior
+		wantErr      bool
+	}{
+		{
+			name: "OK values are passed as arguments",
+			inputProduct: entity.Product{
+				Id:       productId,
+				Name:     "LaserJet M404dn",
+				Price:    entity.NewMoney(2500000, entity.CurrencyRUB),
+				Producer: &entity.Producer{},
+				TaxClass: "reduced'; drop table products; --",
+			},
+			mockBehavior: func(ctx context.Context) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^update products set name = \$2, price = \$3, currency = \$4, tax_class = \$5 where id = \$1$`).
+					WithArgs(productId, "LaserJet M404dn", int64(2500000), entity.CurrencyRUB, "reduced'; drop table products; --").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "update error",
+			inputProduct: entity.Product{
+				Id:       productId,
+				Producer: &entity.Producer{},
+				Weight:   9500,
+			},
+			mockBehavior: func(ctx context.Context) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^update products set weight = \$2 where id = \$1$`).
+					WithArgs(productId, 9500).
+					WillReturnError(someErr)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(context.Background())
+			err := r.Update(context.Background(), testCase.inputProduct)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductRepoPg_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer db.Close()
+
+	r := NewProductRepoPg(db)
+
+	type mockBehavior func(ctx context.Context)
+
+	productId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	warehouseId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	product := func(stock int) entity.Product {
+		return entity.Product{
+			Id:       productId,
+			Name:     "LaserJet M404dn",
+			Price:    entity.NewMoney(2500000, entity.CurrencyRUB),
+			Producer: &entity.Producer{},
+			Stock:    &stock,
+		}
+	}
+	testTable := []struct {
+		name         string
+		inputProduct entity.Product
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			name:         "OK initial stock is recorded as a movement",
+			inputProduct: product(5),
+			mockBehavior: func(ctx context.Context) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^insert into products `).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`^insert into warehouse_stock `).WithArgs(productId, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(warehouseId))
+				mock.ExpectExec(`^insert into stock_movements `).
+					WithArgs(sqlmock.AnyArg(), productId, &warehouseId, 5, 5, entity.StockMovementTypeAdjustment, "initial stock",
+						nil, nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name:         "OK zero stock without movement",
+			inputProduct: product(0),
+			mockBehavior: func(ctx context.Context) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^insert into products `).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`^insert into warehouse_stock `).WithArgs(productId, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}).AddRow(warehouseId))
+				mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name:         "no warehouse",
+			inputProduct: product(5),
+			mockBehavior: func(ctx context.Context) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^insert into products `).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`^insert into warehouse_stock `).WithArgs(productId, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}))
+				mock.ExpectRollback()
+			},
+			expectedErr: ErrNoWarehouse,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(context.Background())
+			err := r.Create(context.Background(), testCase.inputProduct)
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	_ "github.com/lib/pq"
+)
+
+type PromoCodeRepoPg struct {
+	db *sql.DB
+}
+
+func NewPromoCodeRepoPg(db *sql.DB) PromoCode {
+	return &PromoCodeRepoPg{
+		db: db,
+	}
+}
+
+const promoCodeSelect = "select promo_codes.id, promo_codes.code, promo_codes.description, promo_codes.discount_type, " +
+	"promo_codes.discount_value, promo_codes.min_order_amount, promo_codes.currency, promo_codes.starts_at, " +
+	"promo_codes.ends_at, promo_codes.usage_limit, promo_codes.usage_limit_per_user, promo_codes.created_at from promo_codes"
+
+func (p *PromoCodeRepoPg) GetAll(ctx context.Context) ([]*entity.PromoCode, error) {
+	rows, err := p.db.QueryContext(ctx, promoCodeSelect+" order by promo_codes.created_at")
+	if err != nil {
+		return nil, err
+	}
+	promoCodes := []*entity.PromoCode{}
+	for rows.Next() {
+		promoCode, err := p.scanPromoCode(rows)
+		if err != nil {
+			return nil, err
+		}
+		promoCodes = append(promoCodes, promoCode)
+	}
+	err = p.loadScopes(ctx, promoCodes)
+	if err != nil {
+		return nil, err
+	}
+	return promoCodes, nil
+}
+
+func (p *PromoCodeRepoPg) GetById(ctx context.Context, id uuid.UUID) (*entity.PromoCode, error) {
+	return p.getOne(ctx, promoCodeSelect+" where promo_codes.id = $1", id)
+}
+
+func (p *PromoCodeRepoPg) GetByCode(ctx context.Context, code string) (*entity.PromoCode, error) {
+	return p.getOne(ctx, promoCodeSelect+" where promo_codes.code = $1", code)
+}
+
+// GetByCartUserId returns the promo code applied to the cart of the user.
+func (p *PromoCodeRepoPg) GetByCartUserId(ctx context.Context, userId uuid.UUID) (*entity.PromoCode, error) {
+	return p.getOne(ctx, promoCodeSelect+" join cart_promo_codes on cart_promo_codes.promo_code_id = promo_codes.id "+
+		"where cart_promo_codes.user_id = $1", userId)
+}
+
+func (p *PromoCodeRepoPg) getOne(ctx context.Context, query string, arg any) (*entity.PromoCode, error) {
+	promoCode, err := p.scanPromoCode(p.db.QueryRowContext(ctx, query, arg))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrPromoCodeNotFound
+		default:
+			return nil, err
+		}
+	}
+	err = p.loadScopes(ctx, []*entity.PromoCode{promoCode})
+	if err != nil {
+		return nil, err
+	}
+	return promoCode, nil
+}
+
+// CheckIfCodeExists reports whether another promo code than excludeId has the code.
+func (p *PromoCodeRepoPg) CheckIfCodeExists(ctx context.Context, code string, excludeId uuid.UUID) (bool, error) {
+	var exists bool
+	err := p.db.QueryRowContext(ctx, "select exists (select 1 from promo_codes where code = $1 and id <> $2)",
+		code, excludeId).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (p *PromoCodeRepoPg) Create(ctx context.Context, promoCode entity.PromoCode) error {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.ExecContext(ctx,
+		"insert into promo_codes (id, code, description, discount_type, discount_value, min_order_amount, currency, "+
+			"starts_at, ends_at, usage_limit, usage_limit_per_user, created_at) values ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)",
+		promoCode.Id, promoCode.Code, promoCode.Description, promoCode.DiscountType, promoCode.DiscountValue,
+		promoCode.MinOrderAmount, promoCode.Currency, promoCode.StartsAt, promoCode.EndsAt, promoCode.UsageLimit,
+		promoCode.UsageLimitPerUser, promoCode.CreatedAt)
+	if err != nil {
+		return err
+	}
+	err = insertPromoCodeScopes(ctx, tx, promoCode)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Update replaces all fields of the promo code and its scopes.
+func (p *PromoCodeRepoPg) Update(ctx context.Context, promoCode entity.PromoCode) error {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.ExecContext(ctx,
+		"update promo_codes set code = $1, description = $2, discount_type = $3, discount_value = $4, min_order_amount = $5, "+
+			"currency = $6, starts_at = $7, ends_at = $8, usage_limit = $9, usage_limit_per_user = $10 where id = $11",
+		promoCode.Code, promoCode.Description, promoCode.DiscountType, promoCode.DiscountValue, promoCode.MinOrderAmount,
+		promoCode.Currency, promoCode.StartsAt, promoCode.EndsAt, promoCode.UsageLimit, promoCode.UsageLimitPerUser,
+		promoCode.Id)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "delete from promo_code_scopes where promo_code_id = $1", promoCode.Id)
+	if err != nil {
+		return err
+	}
+	err = insertPromoCodeScopes(ctx, tx, promoCode)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertPromoCodeScopes(ctx context.Context, tx *sql.Tx, promoCode entity.PromoCode) error {
+	for _, scope := range promoCode.Scopes {
+		_, err := tx.ExecContext(ctx,
+			"insert into promo_code_scopes (promo_code_id, scope_type, scope_id) values ($1,$2,$3) on conflict do nothing",
+			promoCode.Id, scope.Type, scope.Id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PromoCodeRepoPg) DeleteById(ctx context.Context, id uuid.UUID) error {
+	_, err := p.db.ExecContext(ctx, "delete from promo_codes where id = $1", id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CheckIfUsedById reports whether an order was placed with the promo code.
+func (p *PromoCodeRepoPg) CheckIfUsedById(ctx context.Context, id uuid.UUID) (bool, error) {
+	var used bool
+	err := p.db.QueryRowContext(ctx, "select exists (select 1 from orders where promo_code_id = $1)", id).Scan(&used)
+	if err != nil {
+		return false, err
+	}
+	return used, nil
+}
+
+// promoCodeUsageQuery counts the orders placed with the promo code, in total and by the user.
+// Cancelled orders don't count.
+const promoCodeUsageQuery = "select count(*), count(*) filter (where user_id = $2) from orders where promo_code_id = $1 and status <> $3"
+
+// GetUsage counts the orders placed with the promo code, in total and by the user.
+// Cancelled orders don't count.
+func (p *PromoCodeRepoPg) GetUsage(ctx context.Context, id uuid.UUID, userId uuid.UUID) (int, int, error) {
+	var total, byUser int
+	err := p.db.QueryRowContext(ctx, promoCodeUsageQuery, id, userId, entity.OrderStatusCancelled).Scan(&total, &byUser)
+	if err != nil {
+		return 0, 0, err
+	}
+	return total, byUser, nil
+}
+
+// checkPromoCodeUsage checks the usage limits of the promo code of the order inside the order
+// transaction. The promo code row is locked, so concurrent orders with the code are placed one
+// by one and can't go over the limits.
+func checkPromoCodeUsage(ctx context.Context, tx *sql.Tx, order *entity.Order) error {
+	if order.PromoCodeId == nil {
+		return nil
+	}
+	var usageLimit, usageLimitPerUser sql.NullInt64
+	err := tx.QueryRowContext(ctx, "select usage_limit, usage_limit_per_user from promo_codes where id = $1 for update",
+		*order.PromoCodeId).Scan(&usageLimit, &usageLimitPerUser)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPromoCodeNotFound
+		}
+		return err
+	}
+	if !usageLimit.Valid && !usageLimitPerUser.Valid {
+		return nil
+	}
+	var total, byUser int64
+	err = tx.QueryRowContext(ctx, promoCodeUsageQuery, *order.PromoCodeId, order.UserId, entity.OrderStatusCancelled).Scan(&total, &byUser)
+	if err != nil {
+		return err
+	}
+	if usageLimit.Valid && total >= usageLimit.Int64 || usageLimitPerUser.Valid && byUser >= usageLimitPerUser.Int64 {
+		return ErrPromoCodeUsageLimitReached
+	}
+	return nil
+}
+
+// GetApplicableProductIds returns the products of productIds which are in the scopes of the promo code.
+func (p *PromoCodeRepoPg) GetApplicableProductIds(ctx context.Context, id uuid.UUID, productIds []uuid.UUID) ([]uuid.UUID, error) {
+	in := []string{"null"}
+	for _, productId := range productIds {
+		in = append(in, "'"+productId.String()+"'")
+	}
+	rows, err := p.db.QueryContext(ctx,
+		"with recursive scoped_categories as ("+
+			"select scope_id as id from promo_code_scopes where promo_code_id = $1 and scope_type = $4 "+
+			"union all "+
+			"select categories.id from categories join scoped_categories on categories.parent_id = scoped_categories.id"+
+			") select products.id from products where products.id in ("+strings.Join(in, ",")+") and ("+
+			"products.id in (select scope_id from promo_code_scopes where promo_code_id = $1 and scope_type = $2) "+
+			"or products.producer_id in (select scope_id from promo_code_scopes where promo_code_id = $1 and scope_type = $3) "+
+			"or products.category_id in (select id from scoped_categories))",
+		id, entity.PromoCodeScopeProduct, entity.PromoCodeScopeProducer, entity.PromoCodeScopeCategory)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	applicable := []uuid.UUID{}
+	for rows.Next() {
+		var productId uuid.UUID
+		err = rows.Scan(&productId)
+		if err != nil {
+			return nil, err
+		}
+		applicable = append(applicable, productId)
+	}
+	return applicable, rows.Err()
+}
+
+// SetCartPromoCode applies the promo code to the cart of the user, replacing the applied one.
+func (p *PromoCodeRepoPg) SetCartPromoCode(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	_, err := p.db.ExecContext(ctx,
+		"insert into cart_promo_codes (user_id, promo_code_id) values ($1,$2) "+
+			"on conflict (user_id) do update set promo_code_id = excluded.promo_code_id",
+		userId, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *PromoCodeRepoPg) DeleteCartPromoCode(ctx context.Context, userId uuid.UUID) error {
+	_, err := p.db.ExecContext(ctx, "delete from cart_promo_codes where user_id = $1", userId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// loadScopes fills the scopes of the promo codes.
+func (p *PromoCodeRepoPg) loadScopes(ctx context.Context, promoCodes []*entity.PromoCode) error {
+	if len(promoCodes) == 0 {
+		return nil
+	}
+	byId := map[uuid.UUID]*entity.PromoCode{}
+	in := []string{}
+	for _, promoCode := range promoCodes {
+		promoCode.Scopes = []*entity.PromoCodeScope{}
+		byId[promoCode.Id] = promoCode
+		in = append(in, "'"+promoCode.Id.String()+"'")
+	}
+	rows, err := p.db.QueryContext(ctx,
+		"select promo_code_id, scope_type, scope_id from promo_code_scopes "+
+			"where promo_code_id in ("+strings.Join(in, ",")+") order by scope_type, scope_id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var promoCodeId uuid.UUID
+		scope := new(entity.PromoCodeScope)
+		err := rows.Scan(&promoCodeId, &scope.Type, &scope.Id)
+		if err != nil {
+			return err
+		}
+		promoCode, ok := byId[promoCodeId]
+		if !ok {
+			continue
+		}
+		promoCode.Scopes = append(promoCode.Scopes, scope)
+	}
+	return rows.Err()
+}
+
+func (p *PromoCodeRepoPg) scanPromoCode(row Row) (*entity.PromoCode, error) {
+	var createdAt string
+	var startsAt, endsAt sql.NullString
+	var usageLimit, usageLimitPerUser sql.NullInt64
+	promoCode := new(entity.PromoCode)
+	err := row.Scan(&promoCode.Id, &promoCode.Code, &promoCode.Description, &promoCode.DiscountType,
+		&promoCode.DiscountValue, &promoCode.MinOrderAmount, &promoCode.Currency, &startsAt, &endsAt,
+		&usageLimit, &usageLimitPerUser, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if usageLimit.Valid {
+		limit := int(usageLimit.Int64)
+		promoCode.UsageLimit = &limit
+	}
+	if usageLimitPerUser.Valid {
+		limit := int(usageLimitPerUser.Int64)
+		promoCode.UsageLimitPerUser = &limit
+	}
+	promoCode.StartsAt, err = parseNullTime(startsAt)
+	if err != nil {
+		return nil, err
+	}
+	promoCode.EndsAt, err = parseNullTime(endsAt)
+	if err != nil {
+		return nil, err
+	}
+	promoCode.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	return promoCode, nil
+}
@@ -0,0 +1,238 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	_ "github.com/lib/pq"
+)
+
+type PromotionRepoPg struct {
+	db *sql.DB
+}
+
+func NewPromotionRepoPg(db *sql.DB) Promotion {
+	return &PromotionRepoPg{
+		db: db,
+	}
+}
+
+const promotionSelect = "select id, name, type, priority, percent, buy_count, free_count, currency, active, " +
+	"starts_at, ends_at, created_at from promotions"
+
+func (p *PromotionRepoPg) GetAll(ctx context.Context) ([]*entity.Promotion, error) {
+	return p.getMany(ctx, promotionSelect+" order by priority, created_at")
+}
+
+// GetActive returns the promotions which are switched on and running at now, in the order they apply.
+func (p *PromotionRepoPg) GetActive(ctx context.Context, now time.Time) ([]*entity.Promotion, error) {
+	return p.getMany(ctx, promotionSelect+" where active and (starts_at is null or starts_at <= $1) "+
+		"and (ends_at is null or ends_at > $1) order by priority, created_at", now)
+}
+
+func (p *PromotionRepoPg) getMany(ctx context.Context, query string, args ...any) ([]*entity.Promotion, error) {
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	promotions := []*entity.Promotion{}
+	for rows.Next() {
+		promotion, err := p.scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+	err = p.loadRules(ctx, promotions)
+	if err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+func (p *PromotionRepoPg) GetById(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	promotion, err := p.scanPromotion(p.db.QueryRowContext(ctx, promotionSelect+" where id = $1", id))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrPromotionNotFound
+		default:
+			return nil, err
+		}
+	}
+	err = p.loadRules(ctx, []*entity.Promotion{promotion})
+	if err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+func (p *PromotionRepoPg) Create(ctx context.Context, promotion entity.Promotion) error {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.ExecContext(ctx,
+		"insert into promotions (id, name, type, priority, percent, buy_count, free_count, currency, active, "+
+			"starts_at, ends_at, created_at) values ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)",
+		promotion.Id, promotion.Name, promotion.Type, promotion.Priority, promotion.Percent, promotion.BuyCount,
+		promotion.FreeCount, promotion.Currency, promotion.Active, promotion.StartsAt, promotion.EndsAt, promotion.CreatedAt)
+	if err != nil {
+		return err
+	}
+	err = insertPromotionRules(ctx, tx, promotion)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Update replaces all fields of the promotion with its products and tiers.
+func (p *PromotionRepoPg) Update(ctx context.Context, promotion entity.Promotion) error {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.ExecContext(ctx,
+		"update promotions set name = $1, type = $2, priority = $3, percent = $4, buy_count = $5, free_count = $6, "+
+			"currency = $7, active = $8, starts_at = $9, ends_at = $10 where id = $11",
+		promotion.Name, promotion.Type, promotion.Priority, promotion.Percent, promotion.BuyCount, promotion.FreeCount,
+		promotion.Currency, promotion.Active, promotion.StartsAt, promotion.EndsAt, promotion.Id)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "delete from promotion_products where promotion_id = $1", promotion.Id)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "delete from promotion_tiers where promotion_id = $1", promotion.Id)
+	if err != nil {
+		return err
+	}
+	err = insertPromotionRules(ctx, tx, promotion)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertPromotionRules(ctx context.Context, tx *sql.Tx, promotion entity.Promotion) error {
+	for _, product := range promotion.Products {
+		_, err := tx.ExecContext(ctx,
+			"insert into promotion_products (promotion_id, product_id, count) values ($1,$2,$3) "+
+				"on conflict (promotion_id, product_id) do update set count = excluded.count",
+			promotion.Id, product.ProductId, product.Count)
+		if err != nil {
+			return err
+		}
+	}
+	for _, tier := range promotion.Tiers {
+		_, err := tx.ExecContext(ctx,
+			"insert into promotion_tiers (promotion_id, min_count, price) values ($1,$2,$3) "+
+				"on conflict (promotion_id, min_count) do update set price = excluded.price",
+			promotion.Id, tier.MinCount, tier.Price)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteById deletes the promotion, orders keep the explanations of the discounts it gave.
+func (p *PromotionRepoPg) DeleteById(ctx context.Context, id uuid.UUID) error {
+	_, err := p.db.ExecContext(ctx, "delete from promotions where id = $1", id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// loadRules fills the products and the tiers of the promotions.
+func (p *PromotionRepoPg) loadRules(ctx context.Context, promotions []*entity.Promotion) error {
+	if len(promotions) == 0 {
+		return nil
+	}
+	byId := map[uuid.UUID]*entity.Promotion{}
+	in := []string{}
+	for _, promotion := range promotions {
+		promotion.Products = []*entity.PromotionProduct{}
+		byId[promotion.Id] = promotion
+		in = append(in, "'"+promotion.Id.String()+"'")
+	}
+	rows, err := p.db.QueryContext(ctx,
+		"select promotion_id, product_id, count from promotion_products "+
+			"where promotion_id in ("+strings.Join(in, ",")+") order by product_id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var promotionId uuid.UUID
+		product := new(entity.PromotionProduct)
+		err := rows.Scan(&promotionId, &product.ProductId, &product.Count)
+		if err != nil {
+			return err
+		}
+		promotion, ok := byId[promotionId]
+		if !ok {
+			continue
+		}
+		promotion.Products = append(promotion.Products, product)
+	}
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+	tierRows, err := p.db.QueryContext(ctx,
+		"select promotion_id, min_count, price from promotion_tiers "+
+			"where promotion_id in ("+strings.Join(in, ",")+") order by min_count")
+	if err != nil {
+		return err
+	}
+	defer tierRows.Close()
+	for tierRows.Next() {
+		var promotionId uuid.UUID
+		tier := new(entity.PromotionTier)
+		err := tierRows.Scan(&promotionId, &tier.MinCount, &tier.Price)
+		if err != nil {
+			return err
+		}
+		promotion, ok := byId[promotionId]
+		if !ok {
+			continue
+		}
+		promotion.Tiers = append(promotion.Tiers, tier)
+	}
+	return tierRows.Err()
+}
+
+func (p *PromotionRepoPg) scanPromotion(row Row) (*entity.Promotion, error) {
+	var createdAt string
+	var startsAt, endsAt sql.NullString
+	promotion := new(entity.Promotion)
+	err := row.Scan(&promotion.Id, &promotion.Name, &promotion.Type, &promotion.Priority, &promotion.Percent,
+		&promotion.BuyCount, &promotion.FreeCount, &promotion.Currency, &promotion.Active, &startsAt, &endsAt, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	promotion.StartsAt, err = parseNullTime(startsAt)
+	if err != nil {
+		return nil, err
+	}
+	promotion.EndsAt, err = parseNullTime(endsAt)
+	if err != nil {
+		return nil, err
+	}
+	promotion.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
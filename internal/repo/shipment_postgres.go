@@ -0,0 +1,158 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	_ "github.com/lib/pq"
+)
+
+type ShipmentRepoPg struct {
+	db *sql.DB
+}
+
+func NewShipmentRepoPg(db *sql.DB) Shipment {
+	return &ShipmentRepoPg{
+		db: db,
+	}
+}
+
+// Create records the shipment and moves the order from status from to status to in one transaction.
+// The order is locked and its shipped counts are read again, so concurrent shipments can't ship more
+// than was ordered. ErrOrderChanged is returned when the order isn't in status from anymore or
+// whether it's shipped in full doesn't match to.
+func (s *ShipmentRepoPg) Create(ctx context.Context, shipment *entity.Shipment, from entity.OrderStatus, to entity.OrderStatus, events []entity.OrderEvent) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var status entity.OrderStatus
+	err = tx.QueryRowContext(ctx, "select status from orders where id = $1 for update", shipment.OrderId).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		return err
+	}
+	if status != from {
+		return ErrOrderChanged
+	}
+	left, err := leftToShip(ctx, tx, shipment.OrderId)
+	if err != nil {
+		return err
+	}
+	for _, line := range shipment.Lines {
+		if line.Count > left[line.ProductId] {
+			return ErrShipmentExceedsOrder
+		}
+		left[line.ProductId] -= line.Count
+	}
+	shippedInFull := true
+	for _, count := range left {
+		if count > 0 {
+			shippedInFull = false
+		}
+	}
+	if shippedInFull != (to == entity.OrderStatusShipped) {
+		return ErrOrderChanged
+	}
+	_, err = tx.ExecContext(ctx,
+		"insert into shipments (id, order_id, carrier, tracking_number, shipped_at, created_at) values ($1,$2,$3,$4,$5,$6)",
+		shipment.Id, shipment.OrderId, shipment.Carrier, shipment.TrackingNumber, shipment.ShippedAt, shipment.CreatedAt)
+	if err != nil {
+		return err
+	}
+	for _, line := range shipment.Lines {
+		_, err = tx.ExecContext(ctx, "insert into shipment_lines (shipment_id, product_id, count) values ($1,$2,$3)",
+			shipment.Id, line.ProductId, line.Count)
+		if err != nil {
+			return err
+		}
+	}
+	if to != from {
+		_, err = tx.ExecContext(ctx, "update orders set status = $1 where id = $2", to, shipment.OrderId)
+		if err != nil {
+			return err
+		}
+	}
+	err = insertOrderEvents(ctx, tx, events...)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// leftToShip returns the counts of the order products which aren't shipped yet.
+func leftToShip(ctx context.Context, tx *sql.Tx, orderId uuid.UUID) (map[uuid.UUID]int, error) {
+	rows, err := tx.QueryContext(ctx,
+		"select ordered.product_id, ordered.count - coalesce(shipped.count, 0) from "+
+			"(select product_id, sum(product_count) as count from order_products where order_id = $1 group by product_id) ordered "+
+			"left join (select shipment_lines.product_id, sum(shipment_lines.count) as count from shipment_lines "+
+			"join shipments on shipments.id = shipment_lines.shipment_id where shipments.order_id = $1 group by shipment_lines.product_id) shipped "+
+			"on shipped.product_id = ordered.product_id",
+		orderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	left := map[uuid.UUID]int{}
+	for rows.Next() {
+		var productId uuid.UUID
+		var count int
+		err = rows.Scan(&productId, &count)
+		if err != nil {
+			return nil, err
+		}
+		left[productId] = count
+	}
+	return left, rows.Err()
+}
+
+func (s *ShipmentRepoPg) GetByOrderId(ctx context.Context, orderId uuid.UUID) ([]*entity.Shipment, error) {
+	return getShipments(ctx, s.db, orderId)
+}
+
+// getShipments returns the shipments of the order with their lines, the earliest shipped first.
+func getShipments(ctx context.Context, db *sql.DB, orderId uuid.UUID) ([]*entity.Shipment, error) {
+	rows, err := db.QueryContext(ctx,
+		"select shipments.id, shipments.order_id, shipments.carrier, shipments.tracking_number, shipments.shipped_at, shipments.created_at, "+
+			"shipment_lines.product_id, shipment_lines.count "+
+			"from shipments join shipment_lines on shipment_lines.shipment_id = shipments.id "+
+			"where shipments.order_id = $1 order by shipments.shipped_at, shipments.created_at, shipments.id",
+		orderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	shipments := []*entity.Shipment{}
+	var shipment *entity.Shipment
+	for rows.Next() {
+		var shippedAt, createdAt string
+		current := new(entity.Shipment)
+		line := new(entity.ShipmentLine)
+		err := rows.Scan(&current.Id, &current.OrderId, &current.Carrier, &current.TrackingNumber, &shippedAt, &createdAt,
+			&line.ProductId, &line.Count)
+		if err != nil {
+			return nil, err
+		}
+		if shipment == nil || shipment.Id != current.Id {
+			current.ShippedAt, err = time.Parse(time.RFC3339, shippedAt)
+			if err != nil {
+				return nil, err
+			}
+			current.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
+			if err != nil {
+				return nil, err
+			}
+			shipment = current
+			shipments = append(shipments, shipment)
+		}
+		shipment.Lines = append(shipment.Lines, line)
+	}
+	return shipments, rows.Err()
+}
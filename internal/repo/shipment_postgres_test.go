@@ -0,0 +1,124 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShipmentRepoPg_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer db.Close()
+
+	r := NewShipmentRepoPg(db)
+
+	type mockBehavior func(ctx context.Context)
+
+	orderId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	printerId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	cartridgeId := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	shipment := func(lines ...*entity.ShipmentLine) *entity.Shipment {
+		return &entity.Shipment{
+			Id:        uuid.New(),
+			OrderId:   orderId,
+			Carrier:   "CDEK",
+			Lines:     lines,
+			ShippedAt: time.Date(2025, 6, 25, 0, 0, 0, 0, time.UTC),
+			CreatedAt: time.Date(2025, 6, 25, 0, 0, 0, 0, time.UTC),
+		}
+	}
+	orderLocked := func(status entity.OrderStatus) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`select status from orders where id = \$1 for update`).
+			WithArgs(orderId).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(status))
+	}
+	leftToShip := func(printer, cartridge int) {
+		mock.ExpectQuery(`select ordered.product_id, ordered.count - coalesce\(shipped.count, 0\)`).
+			WithArgs(orderId).
+			WillReturnRows(sqlmock.NewRows([]string{"product_id", "count"}).AddRow(printerId, printer).AddRow(cartridgeId, cartridge))
+	}
+	testTable := []struct {
+		name          string
+		inputShipment *entity.Shipment
+		inputFrom     entity.OrderStatus
+		inputTo       entity.OrderStatus
+		mockBehavior  mockBehavior
+		expectedErr   error
+	}{
+		{
+			name:          "OK last lines move the order to shipped",
+			inputShipment: shipment(&entity.ShipmentLine{ProductId: cartridgeId, Count: 2}),
+			inputFrom:     entity.OrderStatusInProgress,
+			inputTo:       entity.OrderStatusShipped,
+			mockBehavior: func(ctx context.Context) {
+				orderLocked(entity.OrderStatusInProgress)
+				leftToShip(0, 2)
+				mock.ExpectExec("insert into shipments").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("insert into shipment_lines").WithArgs(sqlmock.AnyArg(), cartridgeId, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`update orders set status = \$1 where id = \$2`).
+					WithArgs(entity.OrderStatusShipped, orderId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name:          "concurrent shipment shipped the lines",
+			inputShipment: shipment(&entity.ShipmentLine{ProductId: cartridgeId, Count: 2}),
+			inputFrom:     entity.OrderStatusInProgress,
+			inputTo:       entity.OrderStatusShipped,
+			mockBehavior: func(ctx context.Context) {
+				orderLocked(entity.OrderStatusInProgress)
+				leftToShip(0, 1)
+				mock.ExpectRollback()
+			},
+			expectedErr: ErrShipmentExceedsOrder,
+		},
+		{
+			name:          "order shipped in full by a concurrent shipment",
+			inputShipment: shipment(&entity.ShipmentLine{ProductId: cartridgeId, Count: 1}),
+			inputFrom:     entity.OrderStatusInProgress,
+			inputTo:       entity.OrderStatusInProgress,
+			mockBehavior: func(ctx context.Context) {
+				orderLocked(entity.OrderStatusInProgress)
+				leftToShip(0, 1)
+				mock.ExpectRollback()
+			},
+			expectedErr: ErrOrderChanged,
+		},
+		{
+			name:          "order status changed",
+			inputShipment: shipment(&entity.ShipmentLine{ProductId: printerId, Count: 1}),
+			inputFrom:     entity.OrderStatusPaid,
+			inputTo:       entity.OrderStatusInProgress,
+			mockBehavior: func(ctx context.Context) {
+				orderLocked(entity.OrderStatusRefunded)
+				mock.ExpectRollback()
+			},
+			expectedErr: ErrOrderChanged,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(context.Background())
+			err := r.Create(context.Background(), testCase.inputShipment, testCase.inputFrom, testCase.inputTo, nil)
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
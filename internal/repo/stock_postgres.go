@@ -0,0 +1,261 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	_ "github.com/lib/pq"
+)
+
+type StockRepoPg struct {
+	db *sql.DB
+}
+
+func NewStockRepoPg(db *sql.DB) Stock {
+	return &StockRepoPg{
+		db: db,
+	}
+}
+
+// Adjust changes the stock of the product in movement.WarehouseId by movement.Delta and
+// records the movement. The product stock, which is the sum over all warehouses, is changed
+// by the same delta.
+func (s *StockRepoPg) Adjust(ctx context.Context, movement entity.StockMovement) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	query := "update warehouse_stock set quantity = quantity + $3 where warehouse_id = $1 and product_id = $2 and quantity + $3 >= 0 returning quantity"
+	if movement.Delta > 0 {
+		query = "insert into warehouse_stock (warehouse_id, product_id, quantity) values ($1,$2,$3) " +
+			"on conflict (warehouse_id, product_id) do update set quantity = warehouse_stock.quantity + excluded.quantity returning quantity"
+	}
+	err = tx.QueryRowContext(ctx, query, movement.WarehouseId, movement.ProductId, movement.Delta).Scan(&movement.Stock)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrOutOfStock
+		default:
+			return err
+		}
+	}
+	var stock int
+	err = tx.QueryRowContext(ctx,
+		"update products set stock = coalesce(stock, 0) + $1 where id = $2 and coalesce(stock, 0) + $1 >= 0 returning stock",
+		movement.Delta, movement.ProductId).Scan(&stock)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrOutOfStock
+		default:
+			return err
+		}
+	}
+	err = insertStockMovement(ctx, tx, movement)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *StockRepoPg) GetMovements(ctx context.Context, productId uuid.UUID) ([]*entity.StockMovement, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"select id, product_id, warehouse_id, delta, stock, type, reason, order_id, user_id, created_at from stock_movements where product_id = $1 order by created_at",
+		productId)
+	if err != nil {
+		return nil, err
+	}
+	movements := []*entity.StockMovement{}
+	for rows.Next() {
+		movement, err := s.scanStockMovement(rows)
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, movement)
+	}
+	return movements, nil
+}
+
+func (s *StockRepoPg) scanStockMovement(row Row) (*entity.StockMovement, error) {
+	var (
+		createdAt   string
+		warehouseId uuid.NullUUID
+		orderId     uuid.NullUUID
+		userId      uuid.NullUUID
+	)
+	movement := new(entity.StockMovement)
+	err := row.Scan(&movement.Id, &movement.ProductId, &warehouseId, &movement.Delta, &movement.Stock, &movement.Type, &movement.Reason,
+		&orderId, &userId, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if warehouseId.Valid {
+		movement.WarehouseId = &warehouseId.UUID
+	}
+	if orderId.Valid {
+		movement.OrderId = &orderId.UUID
+	}
+	if userId.Valid {
+		movement.UserId = &userId.UUID
+	}
+	movement.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+func insertStockMovement(ctx context.Context, tx *sql.Tx, movement entity.StockMovement) error {
+	_, err := tx.ExecContext(ctx,
+		"insert into stock_movements (id, product_id, warehouse_id, delta, stock, type, reason, order_id, user_id, created_at) values ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)",
+		uuid.New(), movement.ProductId, movement.WarehouseId, movement.Delta, movement.Stock, movement.Type, movement.Reason,
+		movement.OrderId, movement.UserId, time.Now())
+	return err
+}
+
+// takeStock decrements stock of the order products inside the order transaction and
+// records the allocation of the order lines to warehouses.
+// Products are locked in id order so that concurrent orders can't deadlock.
+// Products which stock is not tracked are skipped.
+func takeStock(ctx context.Context, tx *sql.Tx, order *entity.Order, movementType entity.StockMovementType) error {
+	products := slices.Clone(order.Products)
+	slices.SortFunc(products, func(a, b *entity.ProductInCart) int {
+		return strings.Compare(a.Product.Id.String(), b.Product.Id.String())
+	})
+	for _, product := range products {
+		var stock sql.NullInt64
+		err := tx.QueryRowContext(ctx,
+			"update products set stock = stock - $1 where id = $2 and (stock is null or stock >= $1) returning stock",
+			product.Count, product.Product.Id).Scan(&stock)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return fmt.Errorf("%w: %s", ErrOutOfStock, product.Product.Id)
+			default:
+				return err
+			}
+		}
+		for _, allocation := range product.Allocations {
+			var quantity int
+			err := tx.QueryRowContext(ctx,
+				"update warehouse_stock set quantity = quantity - $1 where warehouse_id = $2 and product_id = $3 and quantity >= $1 returning quantity",
+				allocation.Count, allocation.WarehouseId, product.Product.Id).Scan(&quantity)
+			if err != nil {
+				switch {
+				case errors.Is(err, sql.ErrNoRows):
+					return fmt.Errorf("%w: %s in warehouse %s", ErrOutOfStock, product.Product.Id, allocation.WarehouseId)
+				default:
+					return err
+				}
+			}
+			_, err = tx.ExecContext(ctx,
+				"insert into order_allocations (order_id, product_id, warehouse_id, count) values ($1,$2,$3,$4)",
+				order.Id, product.Product.Id, allocation.WarehouseId, allocation.Count)
+			if err != nil {
+				return err
+			}
+			err = insertStockMovement(ctx, tx, entity.StockMovement{
+				ProductId:   product.Product.Id,
+				WarehouseId: &allocation.WarehouseId,
+				Delta:       -allocation.Count,
+				Stock:       quantity,
+				Type:        movementType,
+				OrderId:     &order.Id,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if !stock.Valid || len(product.Allocations) != 0 {
+			continue
+		}
+		err = insertStockMovement(ctx, tx, entity.StockMovement{
+			ProductId: product.Product.Id,
+			Delta:     -product.Count,
+			Stock:     int(stock.Int64),
+			Type:      movementType,
+			OrderId:   &order.Id,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseStock returns stock of the products of the order inside the order transaction
+// and drops the allocation of the order lines.
+func releaseStock(ctx context.Context, tx *sql.Tx, orderId uuid.UUID, movementType entity.StockMovementType) error {
+	productMovements, err := queryStockMovements(ctx, tx, orderId, movementType,
+		"update products set stock = products.stock + order_products.product_count from order_products "+
+			"where order_products.order_id = $1 and order_products.product_id = products.id and products.stock is not null "+
+			"returning products.id, null::uuid, order_products.product_count, products.stock")
+	if err != nil {
+		return err
+	}
+	warehouseMovements, err := queryStockMovements(ctx, tx, orderId, movementType,
+		"update warehouse_stock set quantity = warehouse_stock.quantity + order_allocations.count from order_allocations "+
+			"where order_allocations.order_id = $1 and order_allocations.warehouse_id = warehouse_stock.warehouse_id "+
+			"and order_allocations.product_id = warehouse_stock.product_id "+
+			"returning warehouse_stock.product_id, warehouse_stock.warehouse_id, order_allocations.count, warehouse_stock.quantity")
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "delete from order_allocations where order_id = $1", orderId)
+	if err != nil {
+		return err
+	}
+	allocated := map[uuid.UUID]bool{}
+	for _, movement := range warehouseMovements {
+		allocated[movement.ProductId] = true
+	}
+	movements := warehouseMovements
+	for _, movement := range productMovements {
+		if !allocated[movement.ProductId] {
+			movements = append(movements, movement)
+		}
+	}
+	for _, movement := range movements {
+		err = insertStockMovement(ctx, tx, movement)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queryStockMovements runs the stock returning query and collects the movements from its
+// product id, warehouse id, delta and stock columns.
+func queryStockMovements(ctx context.Context, tx *sql.Tx, orderId uuid.UUID, movementType entity.StockMovementType,
+	query string) ([]entity.StockMovement, error) {
+	rows, err := tx.QueryContext(ctx, query, orderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	movements := []entity.StockMovement{}
+	for rows.Next() {
+		var warehouseId uuid.NullUUID
+		movement := entity.StockMovement{
+			Type:    movementType,
+			OrderId: &orderId,
+		}
+		err := rows.Scan(&movement.ProductId, &warehouseId, &movement.Delta, &movement.Stock)
+		if err != nil {
+			return nil, err
+		}
+		if warehouseId.Valid {
+			movement.WarehouseId = &warehouseId.UUID
+		}
+		movements = append(movements, movement)
+	}
+	return movements, rows.Err()
+}
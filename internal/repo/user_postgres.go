@@ -23,18 +23,7 @@ func NewUserRepoPg(db *sql.DB) User {
 }
 
 func (u *UserRepoPg) GetAll(ctx context.Context, filter *entity.UserFilter) ([]*entity.User, error) {
-	where := ""
-	if filter != nil {
-		whereS := []string{}
-		if filter.UserStatus != nil {
-			whereS = append(whereS, "status = '"+string(*filter.UserStatus)+"'")
-		}
-		if filter.UserRole != nil {
-			whereS = append(whereS, "role = '"+string(*filter.UserRole)+"'")
-		}
-		where = " where " + strings.Join(whereS, " and ")
-	}
-	rows, err := u.db.QueryContext(ctx, "select * from users"+where)
+	rows, err := u.db.QueryContext(ctx, "select * from users"+whereClause(userFilterConditions(filter)))
 	if err != nil {
 		return nil, err
 	}
@@ -49,6 +38,65 @@ func (u *UserRepoPg) GetAll(ctx context.Context, filter *entity.UserFilter) ([]*
 	return users, nil
 }
 
+func (u *UserRepoPg) GetPage(ctx context.Context, filter *entity.UserFilter, pagination entity.Pagination) (*entity.UserPage, error) {
+	keyset, err := newKeysetPage(pagination, map[entity.SortBy]sortColumn{
+		entity.SortByCreatedAt: {expression: "created_at", cast: "timestamp"},
+		entity.SortByName:      {expression: "last_name", cast: "text"},
+	}, "id")
+	if err != nil {
+		return nil, err
+	}
+	whereS := userFilterConditions(filter)
+	page := new(entity.UserPage)
+	err = u.db.QueryRowContext(ctx, "select count(*) from users"+whereClause(whereS)).Scan(&page.TotalCount)
+	if err != nil {
+		return nil, err
+	}
+	condition, args, err := keyset.condition([]any{})
+	if err != nil {
+		return nil, err
+	}
+	if condition != "" {
+		whereS = append(whereS, condition)
+	}
+	rows, err := u.db.QueryContext(ctx, "select *,"+keyset.sortValue()+" from users"+whereClause(whereS)+keyset.orderBy(), args...)
+	if err != nil {
+		return nil, err
+	}
+	page.Items = []*entity.User{}
+	values := []string{}
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var value string
+		user, err := u.scanUser(sortedRow{Row: rows, value: &value})
+		if err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, user)
+		values = append(values, value)
+		ids = append(ids, user.Id)
+	}
+	page.NextCursor = keyset.nextCursor(values, ids)
+	if len(page.Items) > keyset.limit() {
+		page.Items = page.Items[:keyset.limit()]
+	}
+	return page, nil
+}
+
+func userFilterConditions(filter *entity.UserFilter) []string {
+	whereS := []string{}
+	if filter == nil {
+		return whereS
+	}
+	if filter.UserStatus != nil {
+		whereS = append(whereS, "status = '"+string(*filter.UserStatus)+"'")
+	}
+	if filter.UserRole != nil {
+		whereS = append(whereS, "role = '"+string(*filter.UserRole)+"'")
+	}
+	return whereS
+}
+
 func (u *UserRepoPg) GetById(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	row := u.db.QueryRowContext(ctx, "select * from users where id = $1", id)
 	user, err := u.scanUser(row)
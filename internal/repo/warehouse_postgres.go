@@ -0,0 +1,140 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	_ "github.com/lib/pq"
+)
+
+type WarehouseRepoPg struct {
+	db *sql.DB
+}
+
+func NewWarehouseRepoPg(db *sql.DB) Warehouse {
+	return &WarehouseRepoPg{
+		db: db,
+	}
+}
+
+func (w *WarehouseRepoPg) GetAll(ctx context.Context) ([]*entity.Warehouse, error) {
+	rows, err := w.db.QueryContext(ctx, "select id, name, city, priority, created_at from warehouses order by priority, name")
+	if err != nil {
+		return nil, err
+	}
+	warehouses := []*entity.Warehouse{}
+	for rows.Next() {
+		warehouse, err := w.scanWarehouse(rows)
+		if err != nil {
+			return nil, err
+		}
+		warehouses = append(warehouses, warehouse)
+	}
+	return warehouses, nil
+}
+
+func (w *WarehouseRepoPg) GetById(ctx context.Context, id uuid.UUID) (*entity.Warehouse, error) {
+	row := w.db.QueryRowContext(ctx, "select id, name, city, priority, created_at from warehouses where id = $1", id)
+	warehouse, err := w.scanWarehouse(row)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrWarehouseNotFound
+		default:
+			return nil, err
+		}
+	}
+	return warehouse, nil
+}
+
+func (w *WarehouseRepoPg) Create(ctx context.Context, warehouse entity.Warehouse) error {
+	_, err := w.db.ExecContext(ctx, "insert into warehouses (id, name, city, priority, created_at) values ($1,$2,$3,$4,$5)",
+		warehouse.Id, warehouse.Name, warehouse.City, warehouse.Priority, warehouse.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *WarehouseRepoPg) Update(ctx context.Context, warehouse entity.Warehouse) error {
+	_, err := w.db.ExecContext(ctx, "update warehouses set name = $1, city = $2, priority = $3 where id = $4",
+		warehouse.Name, warehouse.City, warehouse.Priority, warehouse.Id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *WarehouseRepoPg) DeleteById(ctx context.Context, id uuid.UUID) error {
+	_, err := w.db.ExecContext(ctx, "delete from warehouses where id = $1", id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CheckIfUsedById reports whether the warehouse holds products or has order lines allocated to it.
+func (w *WarehouseRepoPg) CheckIfUsedById(ctx context.Context, id uuid.UUID) (bool, error) {
+	var used bool
+	err := w.db.QueryRowContext(ctx,
+		"select exists (select 1 from warehouse_stock where warehouse_id = $1 and quantity > 0) "+
+			"or exists (select 1 from order_allocations where warehouse_id = $1)",
+		id).Scan(&used)
+	if err != nil {
+		return false, err
+	}
+	return used, nil
+}
+
+func (w *WarehouseRepoPg) GetStock(ctx context.Context, filter *entity.WarehouseStockFilter) ([]*entity.WarehouseStock, error) {
+	whereS := []string{}
+	if filter != nil {
+		if filter.WarehouseId != nil {
+			whereS = append(whereS, "warehouse_stock.warehouse_id = '"+filter.WarehouseId.String()+"'")
+		}
+		if filter.ProductIds != nil {
+			in := []string{"null"}
+			for _, id := range filter.ProductIds {
+				in = append(in, "'"+id.String()+"'")
+			}
+			whereS = append(whereS, "warehouse_stock.product_id in ("+strings.Join(in, ",")+")")
+		}
+	}
+	rows, err := w.db.QueryContext(ctx,
+		"select warehouse_stock.warehouse_id, warehouses.name, warehouse_stock.product_id, products.name, warehouse_stock.quantity "+
+			"from warehouse_stock join warehouses on warehouses.id = warehouse_stock.warehouse_id "+
+			"join products on products.id = warehouse_stock.product_id"+
+			whereClause(whereS)+" order by warehouses.priority, warehouses.name, products.name")
+	if err != nil {
+		return nil, err
+	}
+	stock := []*entity.WarehouseStock{}
+	for rows.Next() {
+		item := new(entity.WarehouseStock)
+		err := rows.Scan(&item.WarehouseId, &item.WarehouseName, &item.ProductId, &item.ProductName, &item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		stock = append(stock, item)
+	}
+	return stock, nil
+}
+
+func (w *WarehouseRepoPg) scanWarehouse(row Row) (*entity.Warehouse, error) {
+	var dateStr string
+	warehouse := new(entity.Warehouse)
+	err := row.Scan(&warehouse.Id, &warehouse.Name, &warehouse.City, &warehouse.Priority, &dateStr)
+	if err != nil {
+		return nil, err
+	}
+	warehouse.CreatedAt, err = time.Parse(time.RFC3339, dateStr)
+	if err != nil {
+		return nil, err
+	}
+	return warehouse, nil
+}
@@ -0,0 +1,171 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	_ "github.com/lib/pq"
+)
+
+type WishlistRepoPg struct {
+	db *sql.DB
+}
+
+func NewWishlistRepoPg(db *sql.DB) Wishlist {
+	return &WishlistRepoPg{
+		db: db,
+	}
+}
+
+const wishlistSelect = "select id, user_id, name, share_token, created_at from wishlists"
+
+func (w *WishlistRepoPg) GetAllByUserId(ctx context.Context, userId uuid.UUID) ([]*entity.Wishlist, error) {
+	rows, err := w.db.QueryContext(ctx, wishlistSelect+" where user_id = $1 order by created_at", userId)
+	if err != nil {
+		return nil, err
+	}
+	wishlists := []*entity.Wishlist{}
+	for rows.Next() {
+		wishlist, err := w.scanWishlist(rows)
+		if err != nil {
+			return nil, err
+		}
+		wishlists = append(wishlists, wishlist)
+	}
+	return wishlists, nil
+}
+
+func (w *WishlistRepoPg) GetById(ctx context.Context, id uuid.UUID) (*entity.Wishlist, error) {
+	wishlist, err := w.scanWishlist(w.db.QueryRowContext(ctx, wishlistSelect+" where id = $1", id))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrWishlistNotFound
+		default:
+			return nil, err
+		}
+	}
+	return wishlist, nil
+}
+
+func (w *WishlistRepoPg) GetByShareToken(ctx context.Context, token uuid.UUID) (*entity.Wishlist, error) {
+	wishlist, err := w.scanWishlist(w.db.QueryRowContext(ctx, wishlistSelect+" where share_token = $1", token))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrWishlistNotFound
+		default:
+			return nil, err
+		}
+	}
+	return wishlist, nil
+}
+
+// CheckIfNameExists reports whether the user has another wishlist than excludeId with the name.
+func (w *WishlistRepoPg) CheckIfNameExists(ctx context.Context, userId uuid.UUID, name string, excludeId uuid.UUID) (bool, error) {
+	var exists bool
+	err := w.db.QueryRowContext(ctx,
+		"select exists (select 1 from wishlists where user_id = $1 and name = $2 and id <> $3)",
+		userId, name, excludeId).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (w *WishlistRepoPg) Create(ctx context.Context, wishlist entity.Wishlist) error {
+	_, err := w.db.ExecContext(ctx, "insert into wishlists (id, user_id, name, created_at) values ($1,$2,$3,$4)",
+		wishlist.Id, wishlist.UserId, wishlist.Name, wishlist.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *WishlistRepoPg) Update(ctx context.Context, wishlist entity.Wishlist) error {
+	_, err := w.db.ExecContext(ctx, "update wishlists set name = $1 where id = $2", wishlist.Name, wishlist.Id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetShareToken shares the wishlist by the token, nil token stops sharing it.
+func (w *WishlistRepoPg) SetShareToken(ctx context.Context, id uuid.UUID, token *uuid.UUID) error {
+	_, err := w.db.ExecContext(ctx, "update wishlists set share_token = $1 where id = $2", token, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *WishlistRepoPg) DeleteById(ctx context.Context, id uuid.UUID) error {
+	_, err := w.db.ExecContext(ctx, "delete from wishlists where id = $1", id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetProductIds returns the products of the wishlist in the order they were added.
+func (w *WishlistRepoPg) GetProductIds(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := w.db.QueryContext(ctx,
+		"select product_id from wishlist_products where wishlist_id = $1 order by created_at", id)
+	if err != nil {
+		return nil, err
+	}
+	productIds := []uuid.UUID{}
+	for rows.Next() {
+		var productId uuid.UUID
+		err = rows.Scan(&productId)
+		if err != nil {
+			return nil, err
+		}
+		productIds = append(productIds, productId)
+	}
+	return productIds, nil
+}
+
+func (w *WishlistRepoPg) AddProduct(ctx context.Context, id uuid.UUID, productId uuid.UUID) error {
+	_, err := w.db.ExecContext(ctx,
+		"insert into wishlist_products (wishlist_id, product_id, created_at) values ($1,$2,$3) on conflict do nothing",
+		id, productId, time.Now())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *WishlistRepoPg) DeleteProduct(ctx context.Context, id uuid.UUID, productId uuid.UUID) (bool, error) {
+	result, err := w.db.ExecContext(ctx, "delete from wishlist_products where wishlist_id = $1 and product_id = $2", id, productId)
+	if err != nil {
+		return false, err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (w *WishlistRepoPg) scanWishlist(row Row) (*entity.Wishlist, error) {
+	var createdAt string
+	var shareToken uuid.NullUUID
+	wishlist := new(entity.Wishlist)
+	err := row.Scan(&wishlist.Id, &wishlist.UserId, &wishlist.Name, &shareToken, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if shareToken.Valid {
+		wishlist.ShareToken = &shareToken.UUID
+	}
+	wishlist.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	return wishlist, nil
+}
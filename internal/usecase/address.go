@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+)
+
+type address struct {
+	repo repo.Address
+}
+
+func NewAddress(r repo.Address) Address {
+	return &address{
+		repo: r,
+	}
+}
+
+// getOwnedAddress returns the address of the user, addresses of other users are not found.
+func getOwnedAddress(ctx context.Context, r repo.Address, userId uuid.UUID, id uuid.UUID) (*entity.Address, error) {
+	receivedAddress, err := r.GetById(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrAddressNotFound):
+			return nil, ErrAddressNotFound
+		default:
+			return nil, err
+		}
+	}
+	if receivedAddress.UserId != userId {
+		return nil, ErrAddressNotFound
+	}
+	return receivedAddress, nil
+}
+
+func (a *address) GetAll(ctx context.Context, userId uuid.UUID) ([]*entity.Address, error) {
+	return a.repo.GetAllByUserId(ctx, userId)
+}
+
+func (a *address) GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*entity.Address, error) {
+	return getOwnedAddress(ctx, a.repo, userId, id)
+}
+
+// Create adds the address to the address book, the first address of the user becomes the default one.
+func (a *address) Create(ctx context.Context, addressToCreate entity.Address) (*entity.Address, error) {
+	addresses, err := a.repo.GetAllByUserId(ctx, addressToCreate.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if len(addresses) == 0 {
+		addressToCreate.IsDefault = true
+	}
+	addressToCreate.Id = uuid.New()
+	addressToCreate.CreatedAt = time.Now()
+	err = a.repo.Create(ctx, addressToCreate)
+	if err != nil {
+		return nil, err
+	}
+	return a.GetById(ctx, addressToCreate.UserId, addressToCreate.Id)
+}
+
+// Update replaces the address. The default address stays the default one until another
+// address is made the default.
+func (a *address) Update(ctx context.Context, addressToUpdate entity.Address) (*entity.Address, error) {
+	existingAddress, err := getOwnedAddress(ctx, a.repo, addressToUpdate.UserId, addressToUpdate.Id)
+	if err != nil {
+		return nil, err
+	}
+	if existingAddress.IsDefault {
+		addressToUpdate.IsDefault = true
+	}
+	err = a.repo.Update(ctx, addressToUpdate)
+	if err != nil {
+		return nil, err
+	}
+	return a.GetById(ctx, addressToUpdate.UserId, addressToUpdate.Id)
+}
+
+// DeleteById deletes the address, placed orders keep their copies of it.
+func (a *address) DeleteById(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	_, err := getOwnedAddress(ctx, a.repo, userId, id)
+	if err != nil {
+		return err
+	}
+	err = a.repo.DeleteById(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrAddressNotFound):
+			return ErrAddressNotFound
+		default:
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+	mock_repo "github.com/krijebr/printer-shop/internal/repo/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddress_Create(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockAddress, ctx context.Context)
+
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	testTable := []struct {
+		name              string
+		inputAddress      entity.Address
+		mockBehavior      mockBehavior
+		expectedIsDefault bool
+	}{
+		{
+			name:         "first address becomes default",
+			inputAddress: entity.Address{UserId: userId, City: "Moscow"},
+			mockBehavior: func(s *mock_repo.MockAddress, ctx context.Context) {
+				s.EXPECT().GetAllByUserId(ctx, userId).Return([]*entity.Address{}, nil)
+			},
+			expectedIsDefault: true,
+		},
+		{
+			name:         "another address",
+			inputAddress: entity.Address{UserId: userId, City: "Kazan"},
+			mockBehavior: func(s *mock_repo.MockAddress, ctx context.Context) {
+				s.EXPECT().GetAllByUserId(ctx, userId).Return([]*entity.Address{{Id: uuid.New(), UserId: userId, IsDefault: true}}, nil)
+			},
+			expectedIsDefault: false,
+		},
+		{
+			name:         "another default address",
+			inputAddress: entity.Address{UserId: userId, City: "Kazan", IsDefault: true},
+			mockBehavior: func(s *mock_repo.MockAddress, ctx context.Context) {
+				s.EXPECT().GetAllByUserId(ctx, userId).Return([]*entity.Address{{Id: uuid.New(), UserId: userId, IsDefault: true}}, nil)
+			},
+			expectedIsDefault: true,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			addressRepo := mock_repo.NewMockAddress(c)
+			testCase.mockBehavior(addressRepo, ctx)
+			var created entity.Address
+			addressRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, address entity.Address) error {
+				created = address
+				return nil
+			})
+			addressRepo.EXPECT().GetById(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, id uuid.UUID) (*entity.Address, error) {
+				return &created, nil
+			})
+
+			addressUsecase := NewAddress(addressRepo)
+
+			address, err := addressUsecase.Create(ctx, testCase.inputAddress)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedIsDefault, address.IsDefault)
+			assert.Equal(t, testCase.inputAddress.City, address.City)
+		})
+	}
+}
+
+func TestAddress_Update(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockAddress, ctx context.Context)
+
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	addressId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	testTable := []struct {
+		name         string
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			name: "default address stays default",
+			mockBehavior: func(s *mock_repo.MockAddress, ctx context.Context) {
+				s.EXPECT().GetById(ctx, addressId).Return(&entity.Address{Id: addressId, UserId: userId, IsDefault: true}, nil)
+				s.EXPECT().Update(ctx, entity.Address{Id: addressId, UserId: userId, City: "Kazan", IsDefault: true}).Return(nil)
+				s.EXPECT().GetById(ctx, addressId).Return(&entity.Address{Id: addressId, UserId: userId, City: "Kazan", IsDefault: true}, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "address of another user",
+			mockBehavior: func(s *mock_repo.MockAddress, ctx context.Context) {
+				s.EXPECT().GetById(ctx, addressId).Return(&entity.Address{Id: addressId, UserId: uuid.New()}, nil)
+			},
+			expectedErr: ErrAddressNotFound,
+		},
+		{
+			name: "address not found",
+			mockBehavior: func(s *mock_repo.MockAddress, ctx context.Context) {
+				s.EXPECT().GetById(ctx, addressId).Return(nil, repo.ErrAddressNotFound)
+			},
+			expectedErr: ErrAddressNotFound,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			addressRepo := mock_repo.NewMockAddress(c)
+			testCase.mockBehavior(addressRepo, ctx)
+
+			addressUsecase := NewAddress(addressRepo)
+
+			address, err := addressUsecase.Update(ctx, entity.Address{Id: addressId, UserId: userId, City: "Kazan"})
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				assert.Nil(t, address)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, address.IsDefault)
+			}
+		})
+	}
+}
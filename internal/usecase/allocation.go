@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+)
+
+// allocateOrder picks warehouses for the order lines. Warehouses are tried in the given
+// order, by priority: the whole order goes to the first warehouse that has every line
+// in full, otherwise each line is split between warehouses in the same order.
+// The shipping address isn't taken into account, the priority is set by the admin.
+// Lines of products without warehouse stock are left unallocated.
+func allocateOrder(lines []*entity.ProductInCart, warehouses []*entity.Warehouse, stock []*entity.WarehouseStock) error {
+	available := map[uuid.UUID]map[uuid.UUID]int{}
+	tracked := map[uuid.UUID]bool{}
+	for _, s := range stock {
+		if available[s.WarehouseId] == nil {
+			available[s.WarehouseId] = map[uuid.UUID]int{}
+		}
+		available[s.WarehouseId][s.ProductId] += s.Quantity
+		tracked[s.ProductId] = true
+	}
+	required := map[uuid.UUID]int{}
+	trackedLines := []*entity.ProductInCart{}
+	for _, line := range lines {
+		line.Allocations = nil
+		if tracked[line.Product.Id] {
+			required[line.Product.Id] += line.Count
+			trackedLines = append(trackedLines, line)
+		}
+	}
+	if len(trackedLines) == 0 {
+		return nil
+	}
+	for _, warehouse := range warehouses {
+		full := true
+		for productId, count := range required {
+			if available[warehouse.Id][productId] < count {
+				full = false
+				break
+			}
+		}
+		if !full {
+			continue
+		}
+		for _, line := range trackedLines {
+			line.Allocations = []*entity.OrderAllocation{{
+				WarehouseId:   warehouse.Id,
+				WarehouseName: warehouse.Name,
+				Count:         line.Count,
+			}}
+		}
+		return nil
+	}
+	for _, line := range trackedLines {
+		left := line.Count
+		for _, warehouse := range warehouses {
+			count := min(left, available[warehouse.Id][line.Product.Id])
+			if count <= 0 {
+				continue
+			}
+			line.Allocations = append(line.Allocations, &entity.OrderAllocation{
+				WarehouseId:   warehouse.Id,
+				WarehouseName: warehouse.Name,
+				Count:         count,
+			})
+			available[warehouse.Id][line.Product.Id] -= count
+			left -= count
+			if left == 0 {
+				break
+			}
+		}
+		if left > 0 {
+			return ErrOutOfStock
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocateOrder(t *testing.T) {
+	moscow := &entity.Warehouse{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Name: "Москва", Priority: 1}
+	novosibirsk := &entity.Warehouse{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Name: "Новосибирск", Priority: 2}
+	printerId := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	cartridgeId := uuid.MustParse("00000000-0000-0000-0000-000000000004")
+	paperId := uuid.MustParse("00000000-0000-0000-0000-000000000005")
+	lines := func() []*entity.ProductInCart {
+		return []*entity.ProductInCart{
+			{Product: &entity.Product{Id: printerId}, Count: 2},
+			{Product: &entity.Product{Id: cartridgeId}, Count: 5},
+		}
+	}
+	allocation := func(w *entity.Warehouse, count int) *entity.OrderAllocation {
+		return &entity.OrderAllocation{WarehouseId: w.Id, WarehouseName: w.Name, Count: count}
+	}
+	testTable := []struct {
+		name                string
+		inputLines          []*entity.ProductInCart
+		inputStock          []*entity.WarehouseStock
+		expectedAllocations [][]*entity.OrderAllocation
+		expectedErr         error
+	}{
+		{
+			name:       "first warehouse by priority has everything",
+			inputLines: lines(),
+			inputStock: []*entity.WarehouseStock{
+				{WarehouseId: moscow.Id, ProductId: printerId, Quantity: 2},
+				{WarehouseId: moscow.Id, ProductId: cartridgeId, Quantity: 10},
+				{WarehouseId: novosibirsk.Id, ProductId: printerId, Quantity: 10},
+				{WarehouseId: novosibirsk.Id, ProductId: cartridgeId, Quantity: 10},
+			},
+			expectedAllocations: [][]*entity.OrderAllocation{
+				{allocation(moscow, 2)},
+				{allocation(moscow, 5)},
+			},
+		},
+		{
+			name:       "farther warehouse has everything",
+			inputLines: lines(),
+			inputStock: []*entity.WarehouseStock{
+				{WarehouseId: moscow.Id, ProductId: printerId, Quantity: 2},
+				{WarehouseId: moscow.Id, ProductId: cartridgeId, Quantity: 4},
+				{WarehouseId: novosibirsk.Id, ProductId: printerId, Quantity: 2},
+				{WarehouseId: novosibirsk.Id, ProductId: cartridgeId, Quantity: 5},
+			},
+			expectedAllocations: [][]*entity.OrderAllocation{
+				{allocation(novosibirsk, 2)},
+				{allocation(novosibirsk, 5)},
+			},
+		},
+		{
+			name:       "split between warehouses",
+			inputLines: lines(),
+			inputStock: []*entity.WarehouseStock{
+				{WarehouseId: moscow.Id, ProductId: printerId, Quantity: 2},
+				{WarehouseId: moscow.Id, ProductId: cartridgeId, Quantity: 3},
+				{WarehouseId: novosibirsk.Id, ProductId: cartridgeId, Quantity: 4},
+			},
+			expectedAllocations: [][]*entity.OrderAllocation{
+				{allocation(moscow, 2)},
+				{allocation(moscow, 3), allocation(novosibirsk, 2)},
+			},
+		},
+		{
+			name: "product without warehouse stock is not allocated",
+			inputLines: []*entity.ProductInCart{
+				{Product: &entity.Product{Id: printerId}, Count: 1},
+				{Product: &entity.Product{Id: paperId}, Count: 100},
+			},
+			inputStock: []*entity.WarehouseStock{
+				{WarehouseId: novosibirsk.Id, ProductId: printerId, Quantity: 1},
+			},
+			expectedAllocations: [][]*entity.OrderAllocation{
+				{allocation(novosibirsk, 1)},
+				nil,
+			},
+		},
+		{
+			name:       "not enough in all warehouses",
+			inputLines: lines(),
+			inputStock: []*entity.WarehouseStock{
+				{WarehouseId: moscow.Id, ProductId: printerId, Quantity: 1},
+				{WarehouseId: novosibirsk.Id, ProductId: printerId, Quantity: 0},
+				{WarehouseId: novosibirsk.Id, ProductId: cartridgeId, Quantity: 5},
+			},
+			expectedErr: ErrOutOfStock,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := allocateOrder(testCase.inputLines, []*entity.Warehouse{moscow, novosibirsk}, testCase.inputStock)
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			for i, line := range testCase.inputLines {
+				assert.Equal(t, testCase.expectedAllocations[i], line.Allocations)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"log/slog"
 	"math/rand"
 	"time"
 	"unsafe"
@@ -18,6 +19,7 @@ import (
 type auth struct {
 	userRepo        repo.User
 	tokenRepo       repo.Token
+	guestCart       GuestCart
 	tokenTTL        time.Duration
 	refreshTokenTTL time.Duration
 	hashSalt        string
@@ -25,10 +27,11 @@ type auth struct {
 
 var src rand.Source = rand.NewSource(time.Now().UnixNano())
 
-func NewAuth(u repo.User, t repo.Token, tokenTTL time.Duration, refreshTokenTTL time.Duration, salt string) Auth {
+func NewAuth(u repo.User, t repo.Token, g GuestCart, tokenTTL time.Duration, refreshTokenTTL time.Duration, salt string) Auth {
 	return &auth{
 		userRepo:        u,
 		tokenRepo:       t,
+		guestCart:       g,
 		tokenTTL:        tokenTTL,
 		refreshTokenTTL: refreshTokenTTL,
 		hashSalt:        salt,
@@ -70,13 +73,27 @@ func (a *auth) generateRandomKey() string {
 	return *(*string)(unsafe.Pointer(&b))
 }
 
-func (a *auth) Register(ctx context.Context, user entity.User) (*entity.User, error) {
+// mergeGuestCart moves the guest cart into the cart of the user. The user is already signed in
+// at this point, so a failed merge doesn't fail it. The guest cart is kept, the error is logged
+// and false is returned.
+func (a *auth) mergeGuestCart(ctx context.Context, token uuid.UUID, userId uuid.UUID) bool {
+	err := a.guestCart.Merge(ctx, token, userId)
+	if err != nil {
+		slog.Error("guest cart merging error", slog.Any("error", err), slog.String("user_id", userId.String()))
+		return false
+	}
+	return true
+}
+
+// Register creates a customer. The guest cart of cartToken, if any, becomes the cart of the customer,
+// cartMerged reports whether it did.
+func (a *auth) Register(ctx context.Context, user entity.User, cartToken *uuid.UUID) (*entity.User, bool, error) {
 	someUser, err := a.userRepo.GetByEmail(ctx, user.Email)
 	if err != nil && err != repo.ErrUserNotFound {
-		return nil, err
+		return nil, false, err
 	}
 	if someUser != nil {
-		return nil, ErrEmailAlreadyExists
+		return nil, false, ErrEmailAlreadyExists
 	}
 	user.Id = uuid.New()
 	user.PasswordHash = a.HashPassword(user.PasswordHash)
@@ -85,30 +102,40 @@ func (a *auth) Register(ctx context.Context, user entity.User) (*entity.User, er
 	user.Role = entity.UserRoleCustomer
 	err = a.userRepo.Create(ctx, user)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+	cartMerged := false
+	if cartToken != nil {
+		cartMerged = a.mergeGuestCart(ctx, *cartToken, user.Id)
 	}
 	newUser, err := a.userRepo.GetById(ctx, user.Id)
 	if err != nil {
-		return nil, err
+		return nil, cartMerged, err
 	}
-	return newUser, nil
+	return newUser, cartMerged, nil
 }
 
-func (a *auth) Login(ctx context.Context, email, password string) (string, string, error) {
+// Login issues tokens of the user. The guest cart of cartToken, if any, is merged into the cart of the user,
+// cartMerged reports whether it was.
+func (a *auth) Login(ctx context.Context, email, password string, cartToken *uuid.UUID) (string, string, bool, error) {
 	user, err := a.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		switch {
 		case errors.Is(err, repo.ErrUserNotFound):
-			return "", "", ErrUserNotFound
+			return "", "", false, ErrUserNotFound
 		default:
-			return "", "", err
+			return "", "", false, err
 		}
 	}
 	if !a.ValidatePassword(password, user.PasswordHash) {
-		return "", "", ErrWrongPassword
+		return "", "", false, ErrWrongPassword
 	}
 	if user.Status == entity.UserStatusBlocked {
-		return "", "", ErrUserIsBlocked
+		return "", "", false, ErrUserIsBlocked
+	}
+	cartMerged := false
+	if cartToken != nil {
+		cartMerged = a.mergeGuestCart(ctx, *cartToken, user.Id)
 	}
 	secret := a.generateRandomKey()
 	expTime := time.Now().Add(a.tokenTTL)
@@ -118,11 +145,11 @@ func (a *auth) Login(ctx context.Context, email, password string) (string, strin
 	})
 	err = a.tokenRepo.SetToken(ctx, user.Id, secret, a.tokenTTL)
 	if err != nil {
-		return "", "", err
+		return "", "", cartMerged, err
 	}
 	token, err := tokenObj.SignedString([]byte(secret))
 	if err != nil {
-		return "", "", err
+		return "", "", cartMerged, err
 	}
 
 	secret = a.generateRandomKey()
@@ -133,13 +160,13 @@ func (a *auth) Login(ctx context.Context, email, password string) (string, strin
 	})
 	err = a.tokenRepo.SetRefreshToken(ctx, user.Id, secret, a.refreshTokenTTL)
 	if err != nil {
-		return "", "", err
+		return "", "", cartMerged, err
 	}
 	refreshToken, err := refreshTokenObj.SignedString([]byte(secret))
 	if err != nil {
-		return "", "", err
+		return "", "", cartMerged, err
 	}
-	return token, refreshToken, nil
+	return token, refreshToken, cartMerged, nil
 }
 
 func (a *auth) ValidateToken(ctx context.Context, token string) (*entity.User, error) {
@@ -12,6 +12,7 @@ import (
 	"github.com/krijebr/printer-shop/internal/entity"
 	"github.com/krijebr/printer-shop/internal/repo"
 	mock_repo "github.com/krijebr/printer-shop/internal/repo/mocks"
+	mock_usecase "github.com/krijebr/printer-shop/internal/usecase/mocks"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -114,9 +115,9 @@ func TestAuth_Register(t *testing.T) {
 			token := mock_repo.NewMockToken(c)
 			testCase.mockBehavior(auth, context.Background(), testCase.inputUser)
 
-			authUsecase := NewAuth(auth, token, 0, 0, "")
+			authUsecase := NewAuth(auth, token, nil, 0, 0, "")
 
-			actualUser, err := authUsecase.Register(context.Background(), testCase.inputUser)
+			actualUser, _, err := authUsecase.Register(context.Background(), testCase.inputUser, nil)
 
 			if testCase.expectedErr != nil {
 				assert.Equal(t, testCase.expectedErr, err)
@@ -139,8 +140,8 @@ func TestAuth_Register(t *testing.T) {
 func TestAuth_HashPassword(t *testing.T) {
 	firstSaltWord := "first_salt_word"
 	secondSaltWord := "second_salt_word"
-	firstAuthUsecase := NewAuth(nil, nil, 0, 0, firstSaltWord)
-	secondAuthUsecase := NewAuth(nil, nil, 0, 0, secondSaltWord)
+	firstAuthUsecase := NewAuth(nil, nil, nil, 0, 0, firstSaltWord)
+	secondAuthUsecase := NewAuth(nil, nil, nil, 0, 0, secondSaltWord)
 	firstPassword := "firstPassword"
 	secondPassword := "secondPassword"
 	firstPasswordHash := firstAuthUsecase.HashPassword(firstPassword)
@@ -174,7 +175,7 @@ func TestAuth_generateRandomKey(t *testing.T) {
 func TestAuth_ValidatePassword(t *testing.T) {
 	firstPassword := "firstPassword"
 	secondPassword := "secondPassword"
-	authUsecase := NewAuth(nil, nil, 0, 0, "salt")
+	authUsecase := NewAuth(nil, nil, nil, 0, 0, "salt")
 	firstPasswordHash := authUsecase.HashPassword(firstPassword)
 	t.Run("validation of password and it's hash passes", func(t *testing.T) {
 		assert.True(t, authUsecase.ValidatePassword(firstPassword, firstPasswordHash))
@@ -194,9 +195,12 @@ func TestAuth_Login(t *testing.T) {
 		outputUser        entity.User
 		tokenTTL          time.Duration
 		refreshTokenTTL   time.Duration
+		inputCartToken    *uuid.UUID
+		mergeErr          error
 		mockUserBehavior  mockUserBehavior
 		mockTokenBehavior mockTokenBehavior
 		expectedErr       error
+		expectedMerged    bool
 	}{
 		{
 			name:          "OK",
@@ -223,6 +227,54 @@ func TestAuth_Login(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name:          "OK guest cart merged",
+			inputEmail:    "ivan@gmail.com",
+			inputPassword: "12345678910",
+			outputUser: entity.User{
+				Id:           uuid.MustParse("8be456fa-aa6b-4310-b321-2cacfb8193a9"),
+				Email:        "ivan@gmail.com",
+				PasswordHash: "992320c97d2edc09debf80bc3cd2b770a07a97ecee15771e158a744f38790d2e",
+				Status:       entity.UserStatusActive,
+				Role:         entity.UserRoleCustomer,
+			},
+			tokenTTL:        time.Minute * 5,
+			refreshTokenTTL: time.Hour * 4,
+			inputCartToken:  &uuid.UUID{1},
+			mockUserBehavior: func(s *mock_repo.MockUser, ctx context.Context, email string, password string, user entity.User) {
+				s.EXPECT().GetByEmail(ctx, email).Return(&user, nil)
+			},
+			mockTokenBehavior: func(s *mock_repo.MockToken, ctx context.Context, userId uuid.UUID) {
+				s.EXPECT().SetToken(ctx, userId, gomock.AssignableToTypeOf(""), gomock.AssignableToTypeOf(time.Duration(0))).Return(nil)
+				s.EXPECT().SetRefreshToken(ctx, userId, gomock.AssignableToTypeOf(""), gomock.AssignableToTypeOf(time.Duration(0))).Return(nil)
+			},
+			expectedErr:    nil,
+			expectedMerged: true,
+		},
+		{
+			name:          "OK guest cart merging error doesn't fail login",
+			inputEmail:    "ivan@gmail.com",
+			inputPassword: "12345678910",
+			outputUser: entity.User{
+				Id:           uuid.MustParse("8be456fa-aa6b-4310-b321-2cacfb8193a9"),
+				Email:        "ivan@gmail.com",
+				PasswordHash: "992320c97d2edc09debf80bc3cd2b770a07a97ecee15771e158a744f38790d2e",
+				Status:       entity.UserStatusActive,
+				Role:         entity.UserRoleCustomer,
+			},
+			tokenTTL:        time.Minute * 5,
+			refreshTokenTTL: time.Hour * 4,
+			inputCartToken:  &uuid.UUID{1},
+			mergeErr:        someErr,
+			mockUserBehavior: func(s *mock_repo.MockUser, ctx context.Context, email string, password string, user entity.User) {
+				s.EXPECT().GetByEmail(ctx, email).Return(&user, nil)
+			},
+			mockTokenBehavior: func(s *mock_repo.MockToken, ctx context.Context, userId uuid.UUID) {
+				s.EXPECT().SetToken(ctx, userId, gomock.AssignableToTypeOf(""), gomock.AssignableToTypeOf(time.Duration(0))).Return(nil)
+				s.EXPECT().SetRefreshToken(ctx, userId, gomock.AssignableToTypeOf(""), gomock.AssignableToTypeOf(time.Duration(0))).Return(nil)
+			},
+			expectedErr: nil,
+		},
 		{
 			name:          "user not found",
 			inputEmail:    "ivan1@gmail.com",
@@ -302,9 +354,13 @@ func TestAuth_Login(t *testing.T) {
 			tokenMock := mock_repo.NewMockToken(c)
 			testCase.mockUserBehavior(userMock, context.Background(), testCase.inputEmail, testCase.inputPassword, testCase.outputUser)
 			testCase.mockTokenBehavior(tokenMock, context.Background(), testCase.outputUser.Id)
-			authUsecase := NewAuth(userMock, tokenMock, testCase.tokenTTL, testCase.refreshTokenTTL, "qwerty")
+			guestCartMock := mock_usecase.NewMockGuestCart(c)
+			if testCase.inputCartToken != nil {
+				guestCartMock.EXPECT().Merge(context.Background(), *testCase.inputCartToken, testCase.outputUser.Id).Return(testCase.mergeErr)
+			}
+			authUsecase := NewAuth(userMock, tokenMock, guestCartMock, testCase.tokenTTL, testCase.refreshTokenTTL, "qwerty")
 
-			token, refreshToken, err := authUsecase.Login(context.Background(), testCase.inputEmail, testCase.inputPassword)
+			token, refreshToken, merged, err := authUsecase.Login(context.Background(), testCase.inputEmail, testCase.inputPassword, testCase.inputCartToken)
 
 			if testCase.expectedErr != nil {
 				assert.Equal(t, testCase.expectedErr, err)
@@ -316,6 +372,7 @@ func TestAuth_Login(t *testing.T) {
 				assert.NotEqual(t, refreshToken, "")
 				assert.NotEqual(t, token, refreshToken)
 			}
+			assert.Equal(t, testCase.expectedMerged, merged)
 		})
 	}
 }
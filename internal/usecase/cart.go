@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/krijebr/printer-shop/internal/entity"
@@ -10,66 +11,272 @@ import (
 )
 
 type cart struct {
-	repo        repo.Cart
-	repoProduct repo.Product
+	repo          repo.Cart
+	repoProduct   repo.Product
+	repoPromoCode repo.PromoCode
+	repoPromotion repo.Promotion
+	exchangeRate  ExchangeRate
+	tax           entity.TaxSettings
 }
 
-func NewCart(r repo.Cart, p repo.Product) Cart {
+func NewCart(r repo.Cart, p repo.Product, pc repo.PromoCode, pm repo.Promotion, e ExchangeRate, tax entity.TaxSettings) Cart {
 	return &cart{
-		repo:        r,
-		repoProduct: p,
+		repo:          r,
+		repoProduct:   p,
+		repoPromoCode: pc,
+		repoPromotion: pm,
+		exchangeRate:  e,
+		tax:           tax,
 	}
 }
 
-func (c *cart) GetAllProducts(ctx context.Context, userId uuid.UUID) ([]*entity.ProductInCart, error) {
-	ProductsInCart, err := c.repo.GetAllProducts(ctx, userId)
+// cartLineWarnings explains why the cart line can't be ordered as it is.
+// It must be called before the line price is converted.
+func cartLineWarnings(line *entity.ProductInCart) []*entity.CartWarning {
+	var warnings []*entity.CartWarning
+	if line.Product.Status == entity.ProductStatusHidden {
+		warnings = append(warnings, &entity.CartWarning{
+			Type:    entity.CartWarningTypeProductHidden,
+			Message: "product is no longer available",
+		})
+	}
+	if line.PriceWhenAdded != nil && *line.PriceWhenAdded != line.Product.Price {
+		warnings = append(warnings, &entity.CartWarning{
+			Type:    entity.CartWarningTypePriceChanged,
+			Message: "price has changed since the product was added to the cart",
+		})
+	}
+	if line.Product.Stock != nil && line.Count > *line.Product.Stock {
+		warnings = append(warnings, &entity.CartWarning{
+			Type:    entity.CartWarningTypeInsufficientStock,
+			Message: "not enough products in stock",
+		})
+	}
+	return warnings
+}
+
+func (c *cart) GetSummary(ctx context.Context, userId uuid.UUID, currency entity.Currency) (*entity.CartSummary, error) {
+	productsInCart, err := c.repo.GetAllProducts(ctx, userId)
 	if err != nil {
 		return nil, err
 	}
-	return ProductsInCart, nil
+	promotions, err := c.repoPromotion.GetActive(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	promo, err := loadCartPromoCode(ctx, c.repoPromoCode, userId, productsInCart)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeCart(ctx, c.exchangeRate, c.tax, productsInCart, currency, promotions, promo)
 }
 
-func (c *cart) AddProduct(ctx context.Context, userId uuid.UUID, productId uuid.UUID, count int) error {
-	product, err := c.repoProduct.GetById(ctx, productId)
+// summarizeCart adds warnings and totals in the currency to the cart lines, applies the
+// promotions and then the promo code, if there is one, to what is left to pay and taxes it.
+func summarizeCart(ctx context.Context, exchangeRate ExchangeRate, tax entity.TaxSettings, productsInCart []*entity.ProductInCart,
+	currency entity.Currency, promotions []*entity.Promotion, promo *cartPromoCode) (*entity.CartSummary, error) {
+	if currency == "" {
+		currency = exchangeRate.BaseCurrency()
+	}
+	rate, err := exchangeRate.GetRate(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+	summary := &entity.CartSummary{
+		Products: productsInCart,
+		Subtotal: entity.NewMoney(0, currency),
+		Discount: entity.NewMoney(0, currency),
+	}
+	for _, p := range productsInCart {
+		p.Warnings = cartLineWarnings(p)
+		if len(p.Warnings) != 0 {
+			summary.HasWarnings = true
+		}
+		if p.PriceWhenAdded != nil {
+			change := p.PriceWhenAdded.ChangePercent(p.Product.Price)
+			p.PriceChange = &change
+			converted := p.PriceWhenAdded.Convert(currency, rate)
+			p.PriceWhenAdded = &converted
+		}
+		p.Product.Price = p.Product.Price.Convert(currency, rate)
+		if p.PriceWhenAdded != nil {
+			currentPrice := p.Product.Price
+			p.CurrentPrice = &currentPrice
+		}
+		p.Product.Stock = nil
+		p.Total = p.Product.Price.Mul(p.Count)
+		if p.Product.Status == entity.ProductStatusHidden {
+			continue
+		}
+		summary.ItemsCount += p.Count
+		summary.Subtotal = summary.Subtotal.Add(p.Total)
+	}
+	applyPromotions(promotions, productsInCart, currency, rate)
+	if promo != nil {
+		summary.PromoCode = &promo.promoCode.Code
+		promo.apply(productsInCart, currency, rate)
+		if promo.err != nil {
+			summary.PromoCodeWarning = &entity.CartWarning{
+				Type:    entity.CartWarningTypePromoCode,
+				Message: promo.err.Error(),
+			}
+			summary.HasWarnings = true
+		}
+	}
+	for _, p := range productsInCart {
+		if p.Discount != nil {
+			summary.Discount = summary.Discount.Add(*p.Discount)
+		}
+	}
+	summary.Tax = applyTax(tax, productsInCart, currency)
+	summary.PricesIncludeTax = tax.PricesIncludeTax
+	summary.Total = summary.Subtotal.Sub(summary.Discount)
+	if !summary.PricesIncludeTax {
+		summary.Total = summary.Total.Add(summary.Tax)
+	}
+	return summary, nil
+}
+
+// ApplyPromoCode applies the promo code to the cart of the user, replacing the applied one.
+// A code which gives no discount to the cart isn't applied.
+func (c *cart) ApplyPromoCode(ctx context.Context, userId uuid.UUID, code string, currency entity.Currency) (*entity.CartSummary, error) {
+	promo, err := c.repoPromoCode.GetByCode(ctx, normalizePromoCode(code))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrPromoCodeNotFound):
+			return nil, ErrPromoCodeNotFound
+		default:
+			return nil, err
+		}
+	}
+	err = checkPromoCode(ctx, c.repoPromoCode, promo, userId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	productsInCart, err := c.repo.GetAllProducts(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(productsInCart) == 0 {
+		return nil, ErrCartIsEmpty
+	}
+	promotions, err := c.repoPromotion.GetActive(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	applied, err := newCartPromoCode(ctx, c.repoPromoCode, promo, productsInCart)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := summarizeCart(ctx, c.exchangeRate, c.tax, productsInCart, currency, promotions, applied)
+	if err != nil {
+		return nil, err
+	}
+	if applied.err != nil {
+		return nil, applied.err
+	}
+	err = c.repoPromoCode.SetCartPromoCode(ctx, userId, promo.Id)
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+func (c *cart) RemovePromoCode(ctx context.Context, userId uuid.UUID) error {
+	return c.repoPromoCode.DeleteCartPromoCode(ctx, userId)
+}
+
+// checkCartProduct checks that count items of the product can be put in a cart.
+func checkCartProduct(ctx context.Context, repoProduct repo.Product, productId uuid.UUID, count int) (*entity.Product, error) {
+	product, err := repoProduct.GetById(ctx, productId)
 	if err != nil {
 		switch {
 		case errors.Is(err, repo.ErrProductNotFound):
-			return ErrProductNotFound
+			return nil, ErrProductNotFound
 		default:
-			return err
+			return nil, err
 		}
 	}
-	if product.Status == entity.ProductStatusHidden && count > 0 {
-		return ErrProductIsHidden
+	if product.Status == entity.ProductStatusHidden {
+		return nil, ErrProductIsHidden
+	}
+	if product.Stock != nil && count > *product.Stock {
+		return nil, ErrOutOfStock
 	}
+	return product, nil
+}
+
+// AddProduct adds count items of the product to the cart, on top of the items already in it.
+func (c *cart) AddProduct(ctx context.Context, userId uuid.UUID, productId uuid.UUID, count int) error {
 	existingCount, err := c.repo.GetProductCountById(ctx, userId, productId)
 	if err != nil {
 		return err
 	}
-
+	product, err := checkCartProduct(ctx, c.repoProduct, productId, existingCount+count)
+	if err != nil {
+		return err
+	}
 	if existingCount == 0 {
-		if count == 0 {
-			return nil
-		}
-		err = c.repo.AddProduct(ctx, userId, productId, count)
-		if err != nil {
-			return err
-		}
+		return c.repo.AddProduct(ctx, userId, productId, count, product.Price)
+	}
+	return c.repo.UpdateCount(ctx, userId, productId, existingCount+count)
+}
+
+// UpdateCount replaces the number of items of the product in the cart, zero count removes the product.
+func (c *cart) UpdateCount(ctx context.Context, userId uuid.UUID, productId uuid.UUID, count int) error {
+	existingCount, err := c.repo.GetProductCountById(ctx, userId, productId)
+	if err != nil {
+		return err
 	}
 	if count == 0 {
-		err = c.repo.DeleteByProductId(ctx, userId, productId)
-		if err != nil {
-			return err
+		if existingCount == 0 {
+			return ErrProductNotInCart
 		}
+		return c.repo.DeleteByProductId(ctx, userId, productId)
 	}
-	err = c.repo.UpdateCount(ctx, userId, productId, count)
+	product, err := checkCartProduct(ctx, c.repoProduct, productId, count)
 	if err != nil {
 		return err
 	}
+	if existingCount == 0 {
+		return c.repo.AddProduct(ctx, userId, productId, count, product.Price)
+	}
+	return c.repo.UpdateCount(ctx, userId, productId, count)
+}
 
-	return nil
+func (c *cart) DeleteProduct(ctx context.Context, userId uuid.UUID, productId uuid.UUID) error {
+	existingCount, err := c.repo.GetProductCountById(ctx, userId, productId)
+	if err != nil {
+		return err
+	}
+	if existingCount == 0 {
+		return ErrProductNotInCart
+	}
+	return c.repo.DeleteByProductId(ctx, userId, productId)
 }
 
-func (c *cart) UpdateCount(ctx context.Context, userId uuid.UUID, productId uuid.UUID, count int) error {
-	return nil
+func (c *cart) Clear(ctx context.Context, userId uuid.UUID) error {
+	return c.repo.ClearCart(ctx, userId)
+}
+
+// GetPriceChanges returns the user carts where the price of the product differs from the price
+// when it was added, to notify their owners. Guest carts aren't included.
+func (c *cart) GetPriceChanges(ctx context.Context, productId uuid.UUID) ([]*entity.CartPriceChange, error) {
+	product, err := c.repoProduct.GetById(ctx, productId)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrProductNotFound):
+			return nil, ErrProductNotFound
+		default:
+			return nil, err
+		}
+	}
+	changes, err := c.repo.GetPriceChanges(ctx, productId, product.Price)
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		change.ChangePercent = change.PriceWhenAdded.ChangePercent(change.CurrentPrice)
+	}
+	return changes, nil
 }
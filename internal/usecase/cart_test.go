@@ -0,0 +1,487 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+	mock_repo "github.com/krijebr/printer-shop/internal/repo/mocks"
+	mock_usecase "github.com/krijebr/printer-shop/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCart_AddProduct(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context)
+
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	productId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	stock := 5
+	testTable := []struct {
+		name         string
+		inputCount   int
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			name:       "OK new product",
+			inputCount: 2,
+			mockBehavior: func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetProductCountById(ctx, userId, productId).Return(0, nil)
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId, Status: entity.ProductStatusPublished}, nil)
+				s.EXPECT().AddProduct(ctx, userId, productId, 2, entity.Money{}).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "OK count is added to existing",
+			inputCount: 2,
+			mockBehavior: func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetProductCountById(ctx, userId, productId).Return(3, nil)
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId, Status: entity.ProductStatusPublished, Stock: &stock}, nil)
+				s.EXPECT().UpdateCount(ctx, userId, productId, 5).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "sum exceeds stock",
+			inputCount: 3,
+			mockBehavior: func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetProductCountById(ctx, userId, productId).Return(3, nil)
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId, Status: entity.ProductStatusPublished, Stock: &stock}, nil)
+			},
+			expectedErr: ErrOutOfStock,
+		},
+		{
+			name:       "product is hidden",
+			inputCount: 1,
+			mockBehavior: func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetProductCountById(ctx, userId, productId).Return(0, nil)
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId, Status: entity.ProductStatusHidden}, nil)
+			},
+			expectedErr: ErrProductIsHidden,
+		},
+		{
+			name:       "product not found",
+			inputCount: 1,
+			mockBehavior: func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetProductCountById(ctx, userId, productId).Return(0, nil)
+				p.EXPECT().GetById(ctx, productId).Return(nil, repo.ErrProductNotFound)
+			},
+			expectedErr: ErrProductNotFound,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			cartRepo := mock_repo.NewMockCart(c)
+			productRepo := mock_repo.NewMockProduct(c)
+			testCase.mockBehavior(cartRepo, productRepo, ctx)
+
+			cartUsecase := NewCart(cartRepo, productRepo, mock_repo.NewMockPromoCode(c), mock_repo.NewMockPromotion(c), mock_usecase.NewMockExchangeRate(c), entity.TaxSettings{})
+
+			err := cartUsecase.AddProduct(ctx, userId, productId, testCase.inputCount)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCart_UpdateCount(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context)
+
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	productId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	stock := 5
+	testTable := []struct {
+		name         string
+		inputCount   int
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			name:       "OK count is replaced",
+			inputCount: 4,
+			mockBehavior: func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetProductCountById(ctx, userId, productId).Return(3, nil)
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId, Status: entity.ProductStatusPublished, Stock: &stock}, nil)
+				s.EXPECT().UpdateCount(ctx, userId, productId, 4).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "OK product is added",
+			inputCount: 1,
+			mockBehavior: func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetProductCountById(ctx, userId, productId).Return(0, nil)
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId, Status: entity.ProductStatusPublished}, nil)
+				s.EXPECT().AddProduct(ctx, userId, productId, 1, entity.Money{}).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "OK zero count removes product",
+			inputCount: 0,
+			mockBehavior: func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetProductCountById(ctx, userId, productId).Return(3, nil)
+				s.EXPECT().DeleteByProductId(ctx, userId, productId).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "zero count of product not in cart",
+			inputCount: 0,
+			mockBehavior: func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetProductCountById(ctx, userId, productId).Return(0, nil)
+			},
+			expectedErr: ErrProductNotInCart,
+		},
+		{
+			name:       "count exceeds stock",
+			inputCount: 6,
+			mockBehavior: func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetProductCountById(ctx, userId, productId).Return(3, nil)
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId, Status: entity.ProductStatusPublished, Stock: &stock}, nil)
+			},
+			expectedErr: ErrOutOfStock,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			cartRepo := mock_repo.NewMockCart(c)
+			productRepo := mock_repo.NewMockProduct(c)
+			testCase.mockBehavior(cartRepo, productRepo, ctx)
+
+			cartUsecase := NewCart(cartRepo, productRepo, mock_repo.NewMockPromoCode(c), mock_repo.NewMockPromotion(c), mock_usecase.NewMockExchangeRate(c), entity.TaxSettings{})
+
+			err := cartUsecase.UpdateCount(ctx, userId, productId, testCase.inputCount)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCart_GetSummary(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, e *mock_usecase.MockExchangeRate, ctx context.Context)
+
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	price := entity.NewMoney(1000000, entity.CurrencyRUB)
+	oldPrice := entity.NewMoney(900000, entity.CurrencyRUB)
+	stock := 1
+	productsInCart := func() []*entity.ProductInCart {
+		return []*entity.ProductInCart{
+			{
+				Product:        &entity.Product{Id: uuid.New(), Price: price, Status: entity.ProductStatusPublished},
+				Count:          2,
+				PriceWhenAdded: &price,
+			},
+			{
+				Product:        &entity.Product{Id: uuid.New(), Price: price, Status: entity.ProductStatusPublished, Stock: &stock},
+				Count:          3,
+				PriceWhenAdded: &oldPrice,
+			},
+			{
+				Product:        &entity.Product{Id: uuid.New(), Price: price, Status: entity.ProductStatusHidden},
+				Count:          1,
+				PriceWhenAdded: &price,
+			},
+		}
+	}
+	testTable := []struct {
+		name             string
+		inputCurrency    entity.Currency
+		mockBehavior     mockBehavior
+		expectedCount    int
+		expectedTotal    entity.Money
+		expectedWarnings [][]entity.CartWarningType
+		expectedChanges  []float64
+		expectedErr      error
+	}{
+		{
+			name:          "OK",
+			inputCurrency: entity.CurrencyRUB,
+			mockBehavior: func(s *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				s.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(nil, repo.ErrPromoCodeNotFound)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+			},
+			expectedCount: 5,
+			expectedTotal: entity.NewMoney(5000000, entity.CurrencyRUB),
+			expectedWarnings: [][]entity.CartWarningType{
+				nil,
+				{entity.CartWarningTypePriceChanged, entity.CartWarningTypeInsufficientStock},
+				{entity.CartWarningTypeProductHidden},
+			},
+			expectedChanges: []float64{0, 11.1, 0},
+			expectedErr:     nil,
+		},
+		{
+			name:          "exchange rate not set",
+			inputCurrency: entity.CurrencyBYN,
+			mockBehavior: func(s *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				s.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(nil, repo.ErrPromoCodeNotFound)
+				e.EXPECT().GetRate(ctx, entity.CurrencyBYN).Return(0.0, ErrExchangeRateNotFound)
+			},
+			expectedErr: ErrExchangeRateNotFound,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			cartRepo := mock_repo.NewMockCart(c)
+			promoCodeRepo := mock_repo.NewMockPromoCode(c)
+			promotionRepo := mock_repo.NewMockPromotion(c)
+			exchangeRate := mock_usecase.NewMockExchangeRate(c)
+			testCase.mockBehavior(cartRepo, promoCodeRepo, promotionRepo, exchangeRate, ctx)
+
+			cartUsecase := NewCart(cartRepo, mock_repo.NewMockProduct(c), promoCodeRepo, promotionRepo, exchangeRate, entity.TaxSettings{})
+
+			summary, err := cartUsecase.GetSummary(ctx, userId, testCase.inputCurrency)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedCount, summary.ItemsCount)
+				assert.Equal(t, testCase.expectedTotal, summary.Total)
+				assert.True(t, summary.HasWarnings)
+				for i, p := range summary.Products {
+					var warnings []entity.CartWarningType
+					for _, w := range p.Warnings {
+						warnings = append(warnings, w.Type)
+					}
+					assert.Equal(t, testCase.expectedWarnings[i], warnings)
+					assert.Nil(t, p.Product.Stock)
+					assert.Equal(t, testCase.expectedChanges[i], *p.PriceChange)
+					assert.Equal(t, p.Product.Price, *p.CurrentPrice)
+				}
+			}
+		})
+	}
+}
+
+func TestCart_ApplyPromoCode(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, e *mock_usecase.MockExchangeRate, ctx context.Context)
+
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	productId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	promoCodeId := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	ended := time.Now().Add(-time.Hour)
+	limit := 1
+	productsInCart := func() []*entity.ProductInCart {
+		return []*entity.ProductInCart{
+			{
+				Product: &entity.Product{Id: productId, Price: entity.NewMoney(1000000, entity.CurrencyRUB), Status: entity.ProductStatusPublished},
+				Count:   3,
+			},
+		}
+	}
+	promo := func() *entity.PromoCode {
+		return &entity.PromoCode{
+			Id:            promoCodeId,
+			Code:          "SPRING",
+			DiscountType:  entity.DiscountTypePercentage,
+			DiscountValue: 10,
+			Currency:      entity.CurrencyRUB,
+		}
+	}
+	testTable := []struct {
+		name             string
+		mockBehavior     mockBehavior
+		expectedDiscount entity.Money
+		expectedErr      error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(s *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				pc.EXPECT().GetByCode(ctx, "SPRING").Return(promo(), nil)
+				s.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				pc.EXPECT().SetCartPromoCode(ctx, userId, promoCodeId).Return(nil)
+			},
+			expectedDiscount: entity.NewMoney(300000, entity.CurrencyRUB),
+			expectedErr:      nil,
+		},
+		{
+			name: "OK after promotion",
+			mockBehavior: func(s *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				pc.EXPECT().GetByCode(ctx, "SPRING").Return(promo(), nil)
+				s.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{{
+					Id:        uuid.New(),
+					Type:      entity.PromotionTypeBuyXGetY,
+					Products:  []*entity.PromotionProduct{{ProductId: productId, Count: 1}},
+					BuyCount:  2,
+					FreeCount: 1,
+					Currency:  entity.CurrencyRUB,
+				}}, nil)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				pc.EXPECT().SetCartPromoCode(ctx, userId, promoCodeId).Return(nil)
+			},
+			expectedDiscount: entity.NewMoney(1200000, entity.CurrencyRUB),
+			expectedErr:      nil,
+		},
+		{
+			name: "promo code not found",
+			mockBehavior: func(s *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				pc.EXPECT().GetByCode(ctx, "SPRING").Return(nil, repo.ErrPromoCodeNotFound)
+			},
+			expectedErr: ErrPromoCodeNotFound,
+		},
+		{
+			name: "promo code ended",
+			mockBehavior: func(s *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				endedPromo := promo()
+				endedPromo.EndsAt = &ended
+				pc.EXPECT().GetByCode(ctx, "SPRING").Return(endedPromo, nil)
+			},
+			expectedErr: ErrPromoCodeNotActive,
+		},
+		{
+			name: "used up by the user",
+			mockBehavior: func(s *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				limitedPromo := promo()
+				limitedPromo.UsageLimitPerUser = &limit
+				pc.EXPECT().GetByCode(ctx, "SPRING").Return(limitedPromo, nil)
+				pc.EXPECT().GetUsage(ctx, promoCodeId, userId).Return(5, 1, nil)
+			},
+			expectedErr: ErrPromoCodeUsageLimitReached,
+		},
+		{
+			name: "no products in scope",
+			mockBehavior: func(s *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				scopedPromo := promo()
+				scopedPromo.Scopes = []*entity.PromoCodeScope{{Type: entity.PromoCodeScopeProducer, Id: uuid.New()}}
+				pc.EXPECT().GetByCode(ctx, "SPRING").Return(scopedPromo, nil)
+				s.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pc.EXPECT().GetApplicableProductIds(ctx, promoCodeId, []uuid.UUID{productId}).Return([]uuid.UUID{}, nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+			},
+			expectedErr: ErrPromoCodeNotApplicable,
+		},
+		{
+			name: "cart is empty",
+			mockBehavior: func(s *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				pc.EXPECT().GetByCode(ctx, "SPRING").Return(promo(), nil)
+				s.EXPECT().GetAllProducts(ctx, userId).Return([]*entity.ProductInCart{}, nil)
+			},
+			expectedErr: ErrCartIsEmpty,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			cartRepo := mock_repo.NewMockCart(c)
+			promoCodeRepo := mock_repo.NewMockPromoCode(c)
+			promotionRepo := mock_repo.NewMockPromotion(c)
+			exchangeRate := mock_usecase.NewMockExchangeRate(c)
+			testCase.mockBehavior(cartRepo, promoCodeRepo, promotionRepo, exchangeRate, ctx)
+
+			cartUsecase := NewCart(cartRepo, mock_repo.NewMockProduct(c), promoCodeRepo, promotionRepo, exchangeRate, entity.TaxSettings{})
+
+			summary, err := cartUsecase.ApplyPromoCode(ctx, userId, " spring ", entity.CurrencyRUB)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				assert.Nil(t, summary)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedDiscount, summary.Discount)
+				assert.Equal(t, summary.Subtotal.Sub(testCase.expectedDiscount), summary.Total)
+				assert.Equal(t, "SPRING", *summary.PromoCode)
+			}
+		})
+	}
+}
+
+func TestCart_GetPriceChanges(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context)
+
+	productId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	price := entity.NewMoney(900000, entity.CurrencyRUB)
+	testTable := []struct {
+		name            string
+		mockBehavior    mockBehavior
+		expectedChanges []float64
+		expectedErr     error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId, Price: price}, nil)
+				s.EXPECT().GetPriceChanges(ctx, productId, price).Return([]*entity.CartPriceChange{
+					{
+						UserId:         uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+						ProductId:      productId,
+						Count:          1,
+						PriceWhenAdded: entity.NewMoney(1000000, entity.CurrencyRUB),
+						CurrentPrice:   price,
+					},
+				}, nil)
+			},
+			expectedChanges: []float64{-10},
+			expectedErr:     nil,
+		},
+		{
+			name: "product not found",
+			mockBehavior: func(s *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				p.EXPECT().GetById(ctx, productId).Return(nil, repo.ErrProductNotFound)
+			},
+			expectedErr: ErrProductNotFound,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			cartRepo := mock_repo.NewMockCart(c)
+			productRepo := mock_repo.NewMockProduct(c)
+			testCase.mockBehavior(cartRepo, productRepo, ctx)
+
+			cartUsecase := NewCart(cartRepo, productRepo, mock_repo.NewMockPromoCode(c), mock_repo.NewMockPromotion(c), mock_usecase.NewMockExchangeRate(c), entity.TaxSettings{})
+
+			changes, err := cartUsecase.GetPriceChanges(ctx, productId)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, changes, len(testCase.expectedChanges))
+				for i, change := range changes {
+					assert.Equal(t, testCase.expectedChanges[i], change.ChangePercent)
+				}
+			}
+		})
+	}
+}
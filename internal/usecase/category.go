@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+)
+
+type category struct {
+	repo        repo.Category
+	repoProduct repo.Product
+}
+
+func NewCategory(r repo.Category, p repo.Product) Category {
+	return &category{
+		repo:        r,
+		repoProduct: p,
+	}
+}
+
+func (c *category) GetAll(ctx context.Context) ([]*entity.Category, error) {
+	return c.repo.GetAll(ctx)
+}
+
+// GetTree returns root categories with their subcategories nested in Children.
+func (c *category) GetTree(ctx context.Context) ([]*entity.Category, error) {
+	categories, err := c.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byId := make(map[uuid.UUID]*entity.Category, len(categories))
+	for _, cat := range categories {
+		byId[cat.Id] = cat
+	}
+	roots := []*entity.Category{}
+	for _, cat := range categories {
+		if cat.ParentId == nil {
+			roots = append(roots, cat)
+			continue
+		}
+		parent, ok := byId[*cat.ParentId]
+		if !ok {
+			roots = append(roots, cat)
+			continue
+		}
+		parent.Children = append(parent.Children, cat)
+	}
+	return roots, nil
+}
+
+func (c *category) GetById(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
+	receivedCategory, err := c.repo.GetById(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrCategoryNotFound):
+			return nil, ErrCategoryNotFound
+		default:
+			return nil, err
+		}
+	}
+	return receivedCategory, nil
+}
+
+func (c *category) Create(ctx context.Context, categoryToCreate entity.Category) (*entity.Category, error) {
+	if categoryToCreate.ParentId != nil {
+		_, err := c.repo.GetById(ctx, *categoryToCreate.ParentId)
+		if err != nil {
+			switch {
+			case errors.Is(err, repo.ErrCategoryNotFound):
+				return nil, ErrParentCategoryNotFound
+			default:
+				return nil, err
+			}
+		}
+	}
+	categoryToCreate.Id = uuid.New()
+	categoryToCreate.CreatedAt = time.Now()
+	err := c.repo.Create(ctx, categoryToCreate)
+	if err != nil {
+		return nil, err
+	}
+	newCategory, err := c.repo.GetById(ctx, categoryToCreate.Id)
+	if err != nil {
+		return nil, err
+	}
+	return newCategory, nil
+}
+
+func (c *category) Update(ctx context.Context, categoryToUpdate entity.Category) (*entity.Category, error) {
+	_, err := c.GetById(ctx, categoryToUpdate.Id)
+	if err != nil {
+		return nil, err
+	}
+	if categoryToUpdate.ParentId != nil {
+		categories, err := c.repo.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		parents := make(map[uuid.UUID]*uuid.UUID, len(categories))
+		for _, cat := range categories {
+			parents[cat.Id] = cat.ParentId
+		}
+		if _, ok := parents[*categoryToUpdate.ParentId]; !ok {
+			return nil, ErrParentCategoryNotFound
+		}
+		for parentId := categoryToUpdate.ParentId; parentId != nil; parentId = parents[*parentId] {
+			if *parentId == categoryToUpdate.Id {
+				return nil, ErrCategoryCycle
+			}
+		}
+	}
+	err = c.repo.Update(ctx, categoryToUpdate)
+	if err != nil {
+		return nil, err
+	}
+	updatedCategory, err := c.repo.GetById(ctx, categoryToUpdate.Id)
+	if err != nil {
+		return nil, err
+	}
+	return updatedCategory, nil
+}
+
+func (c *category) DeleteById(ctx context.Context, id uuid.UUID) error {
+	_, err := c.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	categories, err := c.repo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, cat := range categories {
+		if cat.ParentId != nil && *cat.ParentId == id {
+			return ErrCategoryIsUsed
+		}
+	}
+	products, err := c.repoProduct.GetAll(ctx, &entity.ProductFilter{CategoryId: &id})
+	if err != nil {
+		return err
+	}
+	if len(products) != 0 {
+		return ErrCategoryIsUsed
+	}
+	err = c.repo.DeleteById(ctx, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
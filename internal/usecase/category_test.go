@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+	mock_repo "github.com/krijebr/printer-shop/internal/repo/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	printersId = uuid.MustParse("00000000-0000-0000-0000-000000000011")
+	laserId    = uuid.MustParse("00000000-0000-0000-0000-000000000012")
+	colorId    = uuid.MustParse("00000000-0000-0000-0000-000000000013")
+	paperId    = uuid.MustParse("00000000-0000-0000-0000-000000000014")
+)
+
+func testCategories() []*entity.Category {
+	return []*entity.Category{
+		{Id: colorId, ParentId: &laserId, Name: "Color"},
+		{Id: laserId, ParentId: &printersId, Name: "Laser"},
+		{Id: paperId, Name: "Paper"},
+		{Id: printersId, Name: "Printers"},
+	}
+}
+
+func TestCategory_GetTree(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	categoryRepo := mock_repo.NewMockCategory(c)
+	productRepo := mock_repo.NewMockProduct(c)
+	categoryRepo.EXPECT().GetAll(context.Background()).Return(testCategories(), nil)
+
+	tree, err := NewCategory(categoryRepo, productRepo).GetTree(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, tree, 2)
+	assert.Equal(t, paperId, tree[0].Id)
+	assert.Equal(t, printersId, tree[1].Id)
+	assert.Len(t, tree[1].Children, 1)
+	assert.Equal(t, laserId, tree[1].Children[0].Id)
+	assert.Len(t, tree[1].Children[0].Children, 1)
+	assert.Equal(t, colorId, tree[1].Children[0].Children[0].Id)
+}
+
+func TestCategory_Update(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockCategory, ctx context.Context, category entity.Category)
+
+	testTable := []struct {
+		name          string
+		inputCategory entity.Category
+		mockBehavior  mockBehavior
+		expectedErr   error
+	}{
+		{
+			name:          "OK move to another parent",
+			inputCategory: entity.Category{Id: laserId, Name: "Laser", ParentId: &paperId},
+			mockBehavior: func(s *mock_repo.MockCategory, ctx context.Context, category entity.Category) {
+				s.EXPECT().GetById(ctx, laserId).Return(testCategories()[1], nil)
+				s.EXPECT().GetAll(ctx).Return(testCategories(), nil)
+				s.EXPECT().Update(ctx, category).Return(nil)
+				s.EXPECT().GetById(ctx, laserId).Return(&category, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:          "OK move to root",
+			inputCategory: entity.Category{Id: laserId, Name: "Laser"},
+			mockBehavior: func(s *mock_repo.MockCategory, ctx context.Context, category entity.Category) {
+				s.EXPECT().GetById(ctx, laserId).Return(testCategories()[1], nil)
+				s.EXPECT().Update(ctx, category).Return(nil)
+				s.EXPECT().GetById(ctx, laserId).Return(&category, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:          "move into own subcategory",
+			inputCategory: entity.Category{Id: printersId, Name: "Printers", ParentId: &colorId},
+			mockBehavior: func(s *mock_repo.MockCategory, ctx context.Context, category entity.Category) {
+				s.EXPECT().GetById(ctx, printersId).Return(testCategories()[3], nil)
+				s.EXPECT().GetAll(ctx).Return(testCategories(), nil)
+			},
+			expectedErr: ErrCategoryCycle,
+		},
+		{
+			name:          "parent of itself",
+			inputCategory: entity.Category{Id: laserId, Name: "Laser", ParentId: &laserId},
+			mockBehavior: func(s *mock_repo.MockCategory, ctx context.Context, category entity.Category) {
+				s.EXPECT().GetById(ctx, laserId).Return(testCategories()[1], nil)
+				s.EXPECT().GetAll(ctx).Return(testCategories(), nil)
+			},
+			expectedErr: ErrCategoryCycle,
+		},
+		{
+			name:          "parent not found",
+			inputCategory: entity.Category{Id: laserId, Name: "Laser", ParentId: &uuid.Nil},
+			mockBehavior: func(s *mock_repo.MockCategory, ctx context.Context, category entity.Category) {
+				s.EXPECT().GetById(ctx, laserId).Return(testCategories()[1], nil)
+				s.EXPECT().GetAll(ctx).Return(testCategories(), nil)
+			},
+			expectedErr: ErrParentCategoryNotFound,
+		},
+		{
+			name:          "category not found",
+			inputCategory: entity.Category{Id: laserId, Name: "Laser"},
+			mockBehavior: func(s *mock_repo.MockCategory, ctx context.Context, category entity.Category) {
+				s.EXPECT().GetById(ctx, laserId).Return(nil, repo.ErrCategoryNotFound)
+			},
+			expectedErr: ErrCategoryNotFound,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			categoryRepo := mock_repo.NewMockCategory(c)
+			productRepo := mock_repo.NewMockProduct(c)
+			testCase.mockBehavior(categoryRepo, context.Background(), testCase.inputCategory)
+
+			updatedCategory, err := NewCategory(categoryRepo, productRepo).Update(context.Background(), testCase.inputCategory)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				assert.Nil(t, updatedCategory)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.inputCategory.ParentId, updatedCategory.ParentId)
+			}
+		})
+	}
+}
@@ -20,3 +20,43 @@ var ErrOrderCantBeUpdated = errors.New("order can't be updated")
 var ErrOrderCantBeDeleted = errors.New("order can't be deleted")
 var ErrUserIsUsed = errors.New("user is used")
 var ErrUserIsBlocked = errors.New("user is blocked")
+var ErrOrderStatusTransitionNotAllowed = errors.New("order status transition is not allowed")
+var ErrOrderCantBeCancelled = errors.New("order can't be cancelled")
+var ErrOrderOfAnotherUser = errors.New("order belongs to another user")
+var ErrUnsupportedCurrency = errors.New("unsupported currency")
+var ErrExchangeRateNotFound = errors.New("exchange rate not found")
+var ErrBaseCurrencyRateIsFixed = errors.New("exchange rate of the base currency can't be changed")
+var ErrCategoryNotFound = errors.New("category not found")
+var ErrParentCategoryNotFound = errors.New("parent category not found")
+var ErrCategoryCycle = errors.New("category can't be moved into its own subtree")
+var ErrCategoryIsUsed = errors.New("this category is used")
+var ErrProductCompatibleWithItself = errors.New("product can't be compatible with itself")
+var ErrCompatibleProductNotFound = errors.New("compatible product not found")
+var ErrCompatibilityNotFound = errors.New("compatibility not found")
+var ErrInvalidCursor = errors.New("invalid cursor")
+var ErrOutOfStock = errors.New("out of stock")
+var ErrWarehouseNotFound = errors.New("warehouse not found")
+var ErrWarehouseIsUsed = errors.New("this warehouse is used")
+var ErrProductNotInCart = errors.New("product is not in the cart")
+var ErrCartHasUnavailableProducts = errors.New("cart has unavailable products")
+var ErrWishlistNotFound = errors.New("wishlist not found")
+var ErrWishlistNameExists = errors.New("wishlist with this name already exists")
+var ErrProductNotInWishlist = errors.New("product is not in the wishlist")
+var ErrPromoCodeNotFound = errors.New("promo code not found")
+var ErrPromoCodeExists = errors.New("promo code with this code already exists")
+var ErrPromoCodeIsUsed = errors.New("this promo code is used")
+var ErrPromoCodeNotActive = errors.New("promo code is not active")
+var ErrPromoCodeUsageLimitReached = errors.New("promo code usage limit is reached")
+var ErrPromoCodeNotApplicable = errors.New("promo code doesn't apply to products in the cart")
+var ErrPromoCodeMinOrderAmount = errors.New("order amount is less than the promo code minimum")
+var ErrPromotionNotFound = errors.New("promotion not found")
+var ErrTaxClassNotFound = errors.New("tax class not found")
+var ErrAddressNotFound = errors.New("address not found")
+var ErrShippingMethodNotFound = errors.New("shipping method not found")
+var ErrShippingNotAvailable = errors.New("shipping method is not available for the order")
+var ErrOrderCantBeShipped = errors.New("order can't be shipped in its status")
+var ErrShipmentProductNotInOrder = errors.New("shipped product is not in the order")
+var ErrShipmentExceedsOrder = errors.New("shipped count exceeds the count left to ship")
+var ErrNoWarehouse = errors.New("there is no warehouse for the stock")
+var ErrOrderHasUnavailableProducts = errors.New("order has unavailable products")
+var ErrOrderChanged = errors.New("order was changed meanwhile")
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+)
+
+type exchangeRate struct {
+	repo         repo.ExchangeRate
+	baseCurrency entity.Currency
+}
+
+func NewExchangeRate(r repo.ExchangeRate, baseCurrency entity.Currency) ExchangeRate {
+	if baseCurrency == "" {
+		baseCurrency = entity.DefaultCurrency
+	}
+	return &exchangeRate{
+		repo:         r,
+		baseCurrency: baseCurrency,
+	}
+}
+
+func (e *exchangeRate) BaseCurrency() entity.Currency {
+	return e.baseCurrency
+}
+
+func (e *exchangeRate) GetAll(ctx context.Context) ([]*entity.ExchangeRate, error) {
+	return e.repo.GetAll(ctx)
+}
+
+func (e *exchangeRate) GetByCurrency(ctx context.Context, currency entity.Currency) (*entity.ExchangeRate, error) {
+	rate, err := e.repo.GetByCurrency(ctx, currency)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrExchangeRateNotFound):
+			return nil, ErrExchangeRateNotFound
+		default:
+			return nil, err
+		}
+	}
+	return rate, nil
+}
+
+func (e *exchangeRate) Set(ctx context.Context, rate entity.ExchangeRate) (*entity.ExchangeRate, error) {
+	if rate.Currency == e.baseCurrency {
+		return nil, ErrBaseCurrencyRateIsFixed
+	}
+	rate.UpdatedAt = time.Now()
+	err := e.repo.Set(ctx, rate)
+	if err != nil {
+		return nil, err
+	}
+	return e.repo.GetByCurrency(ctx, rate.Currency)
+}
+
+func (e *exchangeRate) DeleteByCurrency(ctx context.Context, currency entity.Currency) error {
+	_, err := e.GetByCurrency(ctx, currency)
+	if err != nil {
+		return err
+	}
+	return e.repo.DeleteByCurrency(ctx, currency)
+}
+
+func (e *exchangeRate) GetRate(ctx context.Context, currency entity.Currency) (float64, error) {
+	if currency == e.baseCurrency {
+		return 1, nil
+	}
+	rate, err := e.GetByCurrency(ctx, currency)
+	if err != nil {
+		if errors.Is(err, ErrExchangeRateNotFound) {
+			// Only the base currency and currencies with a rate are supported.
+			return 0, fmt.Errorf("%w: %w", ErrUnsupportedCurrency, err)
+		}
+		return 0, err
+	}
+	return rate.Rate, nil
+}
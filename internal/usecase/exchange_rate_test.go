@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+	mock_repo "github.com/krijebr/printer-shop/internal/repo/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExchangeRate_GetRate(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockExchangeRate, ctx context.Context)
+
+	testTable := []struct {
+		name          string
+		inputCurrency entity.Currency
+		mockBehavior  mockBehavior
+		expectedRate  float64
+		expectedErr   error
+	}{
+		{
+			name:          "OK base currency",
+			inputCurrency: entity.CurrencyRUB,
+			mockBehavior:  func(s *mock_repo.MockExchangeRate, ctx context.Context) {},
+			expectedRate:  1,
+			expectedErr:   nil,
+		},
+		{
+			name:          "OK currency added by admin",
+			inputCurrency: "USD",
+			mockBehavior: func(s *mock_repo.MockExchangeRate, ctx context.Context) {
+				s.EXPECT().GetByCurrency(ctx, entity.Currency("USD")).Return(&entity.ExchangeRate{Currency: "USD", Rate: 0.0125}, nil)
+			},
+			expectedRate: 0.0125,
+			expectedErr:  nil,
+		},
+		{
+			name:          "currency without rate",
+			inputCurrency: "EUR",
+			mockBehavior: func(s *mock_repo.MockExchangeRate, ctx context.Context) {
+				s.EXPECT().GetByCurrency(ctx, entity.Currency("EUR")).Return(nil, repo.ErrExchangeRateNotFound)
+			},
+			expectedRate: 0,
+			expectedErr:  ErrUnsupportedCurrency,
+		},
+		{
+			name:          "some error",
+			inputCurrency: "EUR",
+			mockBehavior: func(s *mock_repo.MockExchangeRate, ctx context.Context) {
+				s.EXPECT().GetByCurrency(ctx, entity.Currency("EUR")).Return(nil, someErr)
+			},
+			expectedRate: 0,
+			expectedErr:  someErr,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			exchangeRateRepo := mock_repo.NewMockExchangeRate(c)
+			testCase.mockBehavior(exchangeRateRepo, ctx)
+
+			exchangeRateUsecase := NewExchangeRate(exchangeRateRepo, entity.CurrencyRUB)
+
+			rate, err := exchangeRateUsecase.GetRate(ctx, testCase.inputCurrency)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expectedRate, rate)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+)
+
+type guestCart struct {
+	repo          repo.GuestCart
+	repoCart      repo.Cart
+	repoProduct   repo.Product
+	repoPromotion repo.Promotion
+	exchangeRate  ExchangeRate
+	tax           entity.TaxSettings
+	ttl           time.Duration
+}
+
+func NewGuestCart(r repo.GuestCart, c repo.Cart, p repo.Product, pm repo.Promotion, e ExchangeRate, tax entity.TaxSettings,
+	ttl time.Duration) GuestCart {
+	return &guestCart{
+		repo:          r,
+		repoCart:      c,
+		repoProduct:   p,
+		repoPromotion: pm,
+		exchangeRate:  e,
+		tax:           tax,
+		ttl:           ttl,
+	}
+}
+
+// getLine returns the line of the product in the guest cart, nil if the product isn't in it.
+func (g *guestCart) getLine(ctx context.Context, token uuid.UUID, productId uuid.UUID) (*entity.GuestCartLine, error) {
+	lines, err := g.repo.GetAll(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		if line.ProductId == productId {
+			return line, nil
+		}
+	}
+	return nil, nil
+}
+
+func (g *guestCart) GetSummary(ctx context.Context, token uuid.UUID, currency entity.Currency) (*entity.CartSummary, error) {
+	lines, err := g.repo.GetAll(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	productsInCart := make([]*entity.ProductInCart, 0, len(lines))
+	for _, line := range lines {
+		product, err := g.repoProduct.GetById(ctx, line.ProductId)
+		if err != nil {
+			// Products deleted after they were added just disappear from the cart.
+			if errors.Is(err, repo.ErrProductNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		priceWhenAdded := line.Price
+		productsInCart = append(productsInCart, &entity.ProductInCart{
+			Product:        product,
+			Count:          line.Count,
+			PriceWhenAdded: &priceWhenAdded,
+		})
+	}
+	promotions, err := g.repoPromotion.GetActive(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return summarizeCart(ctx, g.exchangeRate, g.tax, productsInCart, currency, promotions, nil)
+}
+
+// AddProduct adds count items of the product to the guest cart, on top of the items already in it.
+func (g *guestCart) AddProduct(ctx context.Context, token uuid.UUID, productId uuid.UUID, count int) error {
+	line, err := g.getLine(ctx, token, productId)
+	if err != nil {
+		return err
+	}
+	if line != nil {
+		count += line.Count
+	}
+	product, err := checkCartProduct(ctx, g.repoProduct, productId, count)
+	if err != nil {
+		return err
+	}
+	if line == nil {
+		line = &entity.GuestCartLine{ProductId: productId, Price: product.Price}
+	}
+	line.Count = count
+	return g.repo.SetLine(ctx, token, line, g.ttl)
+}
+
+// UpdateCount replaces the number of items of the product in the guest cart, zero count removes the product.
+func (g *guestCart) UpdateCount(ctx context.Context, token uuid.UUID, productId uuid.UUID, count int) error {
+	line, err := g.getLine(ctx, token, productId)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		if line == nil {
+			return ErrProductNotInCart
+		}
+		return g.repo.DeleteLine(ctx, token, productId)
+	}
+	product, err := checkCartProduct(ctx, g.repoProduct, productId, count)
+	if err != nil {
+		return err
+	}
+	if line == nil {
+		line = &entity.GuestCartLine{ProductId: productId, Price: product.Price}
+	}
+	line.Count = count
+	return g.repo.SetLine(ctx, token, line, g.ttl)
+}
+
+func (g *guestCart) DeleteProduct(ctx context.Context, token uuid.UUID, productId uuid.UUID) error {
+	line, err := g.getLine(ctx, token, productId)
+	if err != nil {
+		return err
+	}
+	if line == nil {
+		return ErrProductNotInCart
+	}
+	return g.repo.DeleteLine(ctx, token, productId)
+}
+
+func (g *guestCart) Clear(ctx context.Context, token uuid.UUID) error {
+	return g.repo.Delete(ctx, token)
+}
+
+// Merge moves the guest cart into the cart of the user in one go. Counts of products which are in
+// both carts are added up, the user's price when added is kept. Like adding to the cart, deleted and
+// hidden products are skipped and counts are limited by the stock. The guest cart is deleted once
+// its lines are in the cart of the user.
+func (g *guestCart) Merge(ctx context.Context, token uuid.UUID, userId uuid.UUID) error {
+	lines, err := g.repo.GetAll(ctx, token)
+	if err != nil {
+		return err
+	}
+	merged := make([]*entity.GuestCartLine, 0, len(lines))
+	for _, line := range lines {
+		product, err := g.repoProduct.GetById(ctx, line.ProductId)
+		if err != nil {
+			if errors.Is(err, repo.ErrProductNotFound) {
+				continue
+			}
+			return err
+		}
+		if product.Status == entity.ProductStatusHidden {
+			continue
+		}
+		if product.Stock != nil {
+			existingCount, err := g.repoCart.GetProductCountById(ctx, userId, line.ProductId)
+			if err != nil {
+				return err
+			}
+			line.Count = min(line.Count, *product.Stock-existingCount)
+			if line.Count <= 0 {
+				continue
+			}
+		}
+		merged = append(merged, line)
+	}
+	if len(merged) != 0 {
+		err = g.repoCart.AddLines(ctx, userId, merged)
+		if err != nil {
+			return err
+		}
+	}
+	return g.repo.Delete(ctx, token)
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+	mock_repo "github.com/krijebr/printer-shop/internal/repo/mocks"
+	mock_usecase "github.com/krijebr/printer-shop/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuestCart_AddProduct(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockGuestCart, p *mock_repo.MockProduct, ctx context.Context)
+
+	token := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	ttl := time.Hour * 24
+	productId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	price := entity.NewMoney(1000000, entity.CurrencyRUB)
+	oldPrice := entity.NewMoney(900000, entity.CurrencyRUB)
+	stock := 5
+	testTable := []struct {
+		name         string
+		inputCount   int
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			name:       "OK new product",
+			inputCount: 2,
+			mockBehavior: func(s *mock_repo.MockGuestCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetAll(ctx, token).Return([]*entity.GuestCartLine{}, nil)
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId, Price: price, Status: entity.ProductStatusPublished}, nil)
+				s.EXPECT().SetLine(ctx, token, &entity.GuestCartLine{ProductId: productId, Count: 2, Price: price}, ttl).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "OK count is added to existing and price is kept",
+			inputCount: 2,
+			mockBehavior: func(s *mock_repo.MockGuestCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetAll(ctx, token).Return([]*entity.GuestCartLine{{ProductId: productId, Count: 3, Price: oldPrice}}, nil)
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId, Price: price, Status: entity.ProductStatusPublished, Stock: &stock}, nil)
+				s.EXPECT().SetLine(ctx, token, &entity.GuestCartLine{ProductId: productId, Count: 5, Price: oldPrice}, ttl).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "not enough stock",
+			inputCount: 6,
+			mockBehavior: func(s *mock_repo.MockGuestCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetAll(ctx, token).Return([]*entity.GuestCartLine{}, nil)
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId, Price: price, Status: entity.ProductStatusPublished, Stock: &stock}, nil)
+			},
+			expectedErr: ErrOutOfStock,
+		},
+		{
+			name:       "product is hidden",
+			inputCount: 1,
+			mockBehavior: func(s *mock_repo.MockGuestCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetAll(ctx, token).Return([]*entity.GuestCartLine{}, nil)
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId, Price: price, Status: entity.ProductStatusHidden}, nil)
+			},
+			expectedErr: ErrProductIsHidden,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			guestCartRepo := mock_repo.NewMockGuestCart(c)
+			productRepo := mock_repo.NewMockProduct(c)
+			testCase.mockBehavior(guestCartRepo, productRepo, ctx)
+
+			guestCartUsecase := NewGuestCart(guestCartRepo, mock_repo.NewMockCart(c), productRepo, mock_repo.NewMockPromotion(c), mock_usecase.NewMockExchangeRate(c), entity.TaxSettings{}, ttl)
+
+			err := guestCartUsecase.AddProduct(ctx, token, productId, testCase.inputCount)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGuestCart_Merge(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockGuestCart, cr *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context)
+
+	token := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	ttl := time.Hour * 24
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	newProductId := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	existingProductId := uuid.MustParse("00000000-0000-0000-0000-000000000004")
+	deletedProductId := uuid.MustParse("00000000-0000-0000-0000-000000000005")
+	hiddenProductId := uuid.MustParse("00000000-0000-0000-0000-000000000006")
+	price := entity.NewMoney(1000000, entity.CurrencyRUB)
+	stock := 5
+	testTable := []struct {
+		name         string
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(s *mock_repo.MockGuestCart, cr *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetAll(ctx, token).Return([]*entity.GuestCartLine{
+					{ProductId: newProductId, Count: 1, Price: price},
+					{ProductId: existingProductId, Count: 2, Price: price},
+					{ProductId: deletedProductId, Count: 3, Price: price},
+					{ProductId: hiddenProductId, Count: 1, Price: price},
+				}, nil)
+				p.EXPECT().GetById(ctx, newProductId).Return(&entity.Product{Id: newProductId, Status: entity.ProductStatusPublished}, nil)
+				p.EXPECT().GetById(ctx, existingProductId).Return(&entity.Product{Id: existingProductId, Status: entity.ProductStatusPublished, Stock: &stock}, nil)
+				cr.EXPECT().GetProductCountById(ctx, userId, existingProductId).Return(4, nil)
+				p.EXPECT().GetById(ctx, deletedProductId).Return(nil, repo.ErrProductNotFound)
+				p.EXPECT().GetById(ctx, hiddenProductId).Return(&entity.Product{Id: hiddenProductId, Status: entity.ProductStatusHidden}, nil)
+				cr.EXPECT().AddLines(ctx, userId, []*entity.GuestCartLine{
+					{ProductId: newProductId, Count: 1, Price: price},
+					{ProductId: existingProductId, Count: 1, Price: price},
+				}).Return(nil)
+				s.EXPECT().Delete(ctx, token).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "OK nothing left to merge",
+			mockBehavior: func(s *mock_repo.MockGuestCart, cr *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetAll(ctx, token).Return([]*entity.GuestCartLine{{ProductId: existingProductId, Count: 2, Price: price}}, nil)
+				p.EXPECT().GetById(ctx, existingProductId).Return(&entity.Product{Id: existingProductId, Status: entity.ProductStatusPublished, Stock: &stock}, nil)
+				cr.EXPECT().GetProductCountById(ctx, userId, existingProductId).Return(5, nil)
+				s.EXPECT().Delete(ctx, token).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "adding error keeps guest cart",
+			mockBehavior: func(s *mock_repo.MockGuestCart, cr *mock_repo.MockCart, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetAll(ctx, token).Return([]*entity.GuestCartLine{{ProductId: newProductId, Count: 1, Price: price}}, nil)
+				p.EXPECT().GetById(ctx, newProductId).Return(&entity.Product{Id: newProductId, Status: entity.ProductStatusPublished}, nil)
+				cr.EXPECT().AddLines(ctx, userId, gomock.Len(1)).Return(someErr)
+			},
+			expectedErr: someErr,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			guestCartRepo := mock_repo.NewMockGuestCart(c)
+			cartRepo := mock_repo.NewMockCart(c)
+			productRepo := mock_repo.NewMockProduct(c)
+			testCase.mockBehavior(guestCartRepo, cartRepo, productRepo, ctx)
+
+			guestCartUsecase := NewGuestCart(guestCartRepo, cartRepo, productRepo, mock_repo.NewMockPromotion(c), mock_usecase.NewMockExchangeRate(c), entity.TaxSettings{}, ttl)
+
+			err := guestCartUsecase.Merge(ctx, token, userId)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
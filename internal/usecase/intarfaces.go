@@ -9,8 +9,8 @@ import (
 
 //go:generate mockgen -source=intarfaces.go -destination=mocks/mock.go
 type Auth interface {
-	Register(ctx context.Context, user entity.User) (createdUser *entity.User, err error)
-	Login(ctx context.Context, email, password string) (token string, refreshToken string, err error)
+	Register(ctx context.Context, user entity.User, cartToken *uuid.UUID) (createdUser *entity.User, cartMerged bool, err error)
+	Login(ctx context.Context, email, password string, cartToken *uuid.UUID) (token string, refreshToken string, cartMerged bool, err error)
 	ValidateToken(ctx context.Context, token string) (user *entity.User, err error)
 	RefreshToken(ctx context.Context, refreshToken string) (token string, newRefreshToken string, err error)
 	ValidatePassword(password, hash string) (result bool)
@@ -18,38 +18,140 @@ type Auth interface {
 }
 
 type User interface {
-	GetAll(ctx context.Context, filter *entity.UserFilter) (allUsers []*entity.User, err error)
+	GetAll(ctx context.Context, filter *entity.UserFilter, pagination entity.Pagination) (page *entity.UserPage, err error)
 	GetById(ctx context.Context, id uuid.UUID) (user *entity.User, err error)
 	Update(ctx context.Context, user entity.User) (updatedUser *entity.User, err error)
 	DeleteById(ctx context.Context, id uuid.UUID) (err error)
 }
 
 type Product interface {
-	GetAll(ctx context.Context, filter *entity.ProductFilter) (allProducts []*entity.Product, err error)
-	GetById(ctx context.Context, id uuid.UUID) (product *entity.Product, err error)
+	GetAll(ctx context.Context, filter *entity.ProductFilter, pagination entity.Pagination, currency entity.Currency) (page *entity.ProductPage, err error)
+	GetById(ctx context.Context, id uuid.UUID, currency entity.Currency) (product *entity.Product, err error)
 	Create(ctx context.Context, product entity.Product) (createdProduct *entity.Product, err error)
 	Update(ctx context.Context, product entity.Product) (updatedProduct *entity.Product, err error)
 	DeleteById(ctx context.Context, id uuid.UUID) (err error)
+	GetByName(ctx context.Context, name string) (products []*entity.Product, err error)
+	GetCompatible(ctx context.Context, id uuid.UUID, filter *entity.ProductFilter, currency entity.Currency) (compatibleProducts []*entity.Product, err error)
+	AddCompatible(ctx context.Context, id uuid.UUID, compatibleId uuid.UUID) (err error)
+	DeleteCompatible(ctx context.Context, id uuid.UUID, compatibleId uuid.UUID) (err error)
 }
 
 type Producer interface {
-	GetAll(ctx context.Context) (allProducers []*entity.Producer, err error)
+	GetAll(ctx context.Context, pagination entity.Pagination) (page *entity.ProducerPage, err error)
 	GetById(ctx context.Context, id uuid.UUID) (producer *entity.Producer, err error)
 	Create(ctx context.Context, producer entity.Producer) (createdProducer *entity.Producer, err error)
 	Update(ctx context.Context, producer entity.Producer) (updatedProducer *entity.Producer, err error)
 	DeleteById(ctx context.Context, id uuid.UUID) (err error)
 }
 
+type Category interface {
+	GetAll(ctx context.Context) (allCategories []*entity.Category, err error)
+	GetTree(ctx context.Context) (rootCategories []*entity.Category, err error)
+	GetById(ctx context.Context, id uuid.UUID) (category *entity.Category, err error)
+	Create(ctx context.Context, category entity.Category) (createdCategory *entity.Category, err error)
+	Update(ctx context.Context, category entity.Category) (updatedCategory *entity.Category, err error)
+	DeleteById(ctx context.Context, id uuid.UUID) (err error)
+}
+
 type Cart interface {
-	GetAllProducts(ctx context.Context, userId uuid.UUID) (allProducts []*entity.ProductInCart, err error)
+	GetSummary(ctx context.Context, userId uuid.UUID, currency entity.Currency) (summary *entity.CartSummary, err error)
 	AddProduct(ctx context.Context, userId uuid.UUID, productId uuid.UUID, count int) (err error)
 	UpdateCount(ctx context.Context, userId uuid.UUID, productId uuid.UUID, count int) (err error)
+	DeleteProduct(ctx context.Context, userId uuid.UUID, productId uuid.UUID) (err error)
+	Clear(ctx context.Context, userId uuid.UUID) (err error)
+	GetPriceChanges(ctx context.Context, productId uuid.UUID) (changes []*entity.CartPriceChange, err error)
+	ApplyPromoCode(ctx context.Context, userId uuid.UUID, code string, currency entity.Currency) (summary *entity.CartSummary, err error)
+	RemovePromoCode(ctx context.Context, userId uuid.UUID) (err error)
+}
+
+// GuestCart is the cart of a guest, identified by the cart token instead of the user.
+type GuestCart interface {
+	GetSummary(ctx context.Context, token uuid.UUID, currency entity.Currency) (summary *entity.CartSummary, err error)
+	AddProduct(ctx context.Context, token uuid.UUID, productId uuid.UUID, count int) (err error)
+	UpdateCount(ctx context.Context, token uuid.UUID, productId uuid.UUID, count int) (err error)
+	DeleteProduct(ctx context.Context, token uuid.UUID, productId uuid.UUID) (err error)
+	Clear(ctx context.Context, token uuid.UUID) (err error)
+	Merge(ctx context.Context, token uuid.UUID, userId uuid.UUID) (err error)
 }
 
 type Order interface {
-	Create(ctx context.Context, userId uuid.UUID) (order *entity.Order, err error)
-	GetAll(ctx context.Context, filter *entity.OrderFilter) (allOrders []*entity.Order, err error)
+	Create(ctx context.Context, userId uuid.UUID, currency entity.Currency, shippingAddressId uuid.UUID, billingAddressId *uuid.UUID,
+		shippingMethod string) (order *entity.Order, err error)
+	GetAll(ctx context.Context, filter *entity.OrderFilter, pagination entity.Pagination) (page *entity.OrderPage, err error)
 	GetById(ctx context.Context, id uuid.UUID) (order *entity.Order, err error)
 	DeleteById(ctx context.Context, id uuid.UUID) (err error)
-	UpdateById(ctx context.Context, order *entity.Order) (updatedOrder *entity.Order, err error)
+	UpdateById(ctx context.Context, userId uuid.UUID, order *entity.Order, comment string) (updatedOrder *entity.Order, err error)
+	GetHistory(ctx context.Context, id uuid.UUID) (events []*entity.OrderEvent, err error)
+	Cancel(ctx context.Context, userId uuid.UUID, id uuid.UUID, comment string, isAdmin bool) (cancelledOrder *entity.Order, err error)
+	ReleaseExpired(ctx context.Context) (released int, err error)
+}
+
+type Shipment interface {
+	Create(ctx context.Context, userId uuid.UUID, shipment entity.Shipment) (createdShipment *entity.Shipment, err error)
+}
+
+type Stock interface {
+	Adjust(ctx context.Context, userId uuid.UUID, productId uuid.UUID, warehouseId uuid.UUID, delta int, reason string) (product *entity.Product, err error)
+	GetLevels(ctx context.Context, productId uuid.UUID) (levels []*entity.WarehouseStock, err error)
+	GetMovements(ctx context.Context, productId uuid.UUID) (movements []*entity.StockMovement, err error)
+}
+type Warehouse interface {
+	GetAll(ctx context.Context) (allWarehouses []*entity.Warehouse, err error)
+	GetById(ctx context.Context, id uuid.UUID) (warehouse *entity.Warehouse, err error)
+	Create(ctx context.Context, warehouse entity.Warehouse) (createdWarehouse *entity.Warehouse, err error)
+	Update(ctx context.Context, warehouse entity.Warehouse) (updatedWarehouse *entity.Warehouse, err error)
+	DeleteById(ctx context.Context, id uuid.UUID) (err error)
+	GetStock(ctx context.Context, id uuid.UUID) (stock []*entity.WarehouseStock, err error)
+}
+
+type ExchangeRate interface {
+	BaseCurrency() (currency entity.Currency)
+	GetAll(ctx context.Context) (allRates []*entity.ExchangeRate, err error)
+	GetByCurrency(ctx context.Context, currency entity.Currency) (rate *entity.ExchangeRate, err error)
+	Set(ctx context.Context, rate entity.ExchangeRate) (updatedRate *entity.ExchangeRate, err error)
+	DeleteByCurrency(ctx context.Context, currency entity.Currency) (err error)
+	GetRate(ctx context.Context, currency entity.Currency) (rate float64, err error)
+}
+
+type Wishlist interface {
+	GetAll(ctx context.Context, userId uuid.UUID) (wishlists []*entity.Wishlist, err error)
+	GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (wishlist *entity.Wishlist, err error)
+	GetShared(ctx context.Context, token uuid.UUID) (wishlist *entity.Wishlist, err error)
+	Create(ctx context.Context, wishlist entity.Wishlist) (createdWishlist *entity.Wishlist, err error)
+	Update(ctx context.Context, wishlist entity.Wishlist) (updatedWishlist *entity.Wishlist, err error)
+	DeleteById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (err error)
+	AddProduct(ctx context.Context, userId uuid.UUID, id uuid.UUID, productId uuid.UUID) (err error)
+	DeleteProduct(ctx context.Context, userId uuid.UUID, id uuid.UUID, productId uuid.UUID) (err error)
+	MoveToCart(ctx context.Context, userId uuid.UUID, id uuid.UUID, productId uuid.UUID, count int) (err error)
+	MoveFromCart(ctx context.Context, userId uuid.UUID, id uuid.UUID, productId uuid.UUID) (err error)
+	Share(ctx context.Context, userId uuid.UUID, id uuid.UUID) (sharedWishlist *entity.Wishlist, err error)
+	Unshare(ctx context.Context, userId uuid.UUID, id uuid.UUID) (err error)
+}
+
+type PromoCode interface {
+	GetAll(ctx context.Context) (allPromoCodes []*entity.PromoCode, err error)
+	GetById(ctx context.Context, id uuid.UUID) (promoCode *entity.PromoCode, err error)
+	Create(ctx context.Context, promoCode entity.PromoCode) (createdPromoCode *entity.PromoCode, err error)
+	Update(ctx context.Context, promoCode entity.PromoCode) (updatedPromoCode *entity.PromoCode, err error)
+	DeleteById(ctx context.Context, id uuid.UUID) (err error)
+}
+
+type Promotion interface {
+	GetAll(ctx context.Context) (allPromotions []*entity.Promotion, err error)
+	GetById(ctx context.Context, id uuid.UUID) (promotion *entity.Promotion, err error)
+	Create(ctx context.Context, promotion entity.Promotion) (createdPromotion *entity.Promotion, err error)
+	Update(ctx context.Context, promotion entity.Promotion) (updatedPromotion *entity.Promotion, err error)
+	DeleteById(ctx context.Context, id uuid.UUID) (err error)
+}
+
+type Address interface {
+	GetAll(ctx context.Context, userId uuid.UUID) (addresses []*entity.Address, err error)
+	GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (address *entity.Address, err error)
+	Create(ctx context.Context, address entity.Address) (createdAddress *entity.Address, err error)
+	Update(ctx context.Context, address entity.Address) (updatedAddress *entity.Address, err error)
+	DeleteById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (err error)
+}
+
+type Shipping interface {
+	Quote(ctx context.Context, userId uuid.UUID, currency entity.Currency, addressId *uuid.UUID) (quotes []*entity.ShippingQuote, err error)
 }
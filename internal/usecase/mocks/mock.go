@@ -55,19 +55,20 @@ func (mr *MockAuthMockRecorder) HashPassword(password interface{}) *gomock.Call
 }
 
 // Login mocks base method.
-func (m *MockAuth) Login(ctx context.Context, email, password string) (string, string, error) {
+func (m *MockAuth) Login(ctx context.Context, email, password string, cartToken *uuid.UUID) (string, string, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, email, password)
+	ret := m.ctrl.Call(m, "Login", ctx, email, password, cartToken)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockAuthMockRecorder) Login(ctx, email, password interface{}) *gomock.Call {
+func (mr *MockAuthMockRecorder) Login(ctx, email, password, cartToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuth)(nil).Login), ctx, email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuth)(nil).Login), ctx, email, password, cartToken)
 }
 
 // RefreshToken mocks base method.
@@ -87,18 +88,19 @@ func (mr *MockAuthMockRecorder) RefreshToken(ctx, refreshToken interface{}) *gom
 }
 
 // Register mocks base method.
-func (m *MockAuth) Register(ctx context.Context, user entity.User) (*entity.User, error) {
+func (m *MockAuth) Register(ctx context.Context, user entity.User, cartToken *uuid.UUID) (*entity.User, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Register", ctx, user)
+	ret := m.ctrl.Call(m, "Register", ctx, user, cartToken)
 	ret0, _ := ret[0].(*entity.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // Register indicates an expected call of Register.
-func (mr *MockAuthMockRecorder) Register(ctx, user interface{}) *gomock.Call {
+func (mr *MockAuthMockRecorder) Register(ctx, user, cartToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuth)(nil).Register), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuth)(nil).Register), ctx, user, cartToken)
 }
 
 // ValidatePassword mocks base method.
@@ -168,18 +170,18 @@ func (mr *MockUserMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
 }
 
 // GetAll mocks base method.
-func (m *MockUser) GetAll(ctx context.Context, filter *entity.UserFilter) ([]*entity.User, error) {
+func (m *MockUser) GetAll(ctx context.Context, filter *entity.UserFilter, pagination entity.Pagination) (*entity.UserPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx, filter)
-	ret0, _ := ret[0].([]*entity.User)
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter, pagination)
+	ret0, _ := ret[0].(*entity.UserPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockUserMockRecorder) GetAll(ctx, filter interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) GetAll(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUser)(nil).GetAll), ctx, filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUser)(nil).GetAll), ctx, filter, pagination)
 }
 
 // GetById mocks base method.
@@ -235,6 +237,20 @@ func (m *MockProduct) EXPECT() *MockProductMockRecorder {
 	return m.recorder
 }
 
+// AddCompatible mocks base method.
+func (m *MockProduct) AddCompatible(ctx context.Context, id, compatibleId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCompatible", ctx, id, compatibleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCompatible indicates an expected call of AddCompatible.
+func (mr *MockProductMockRecorder) AddCompatible(ctx, id, compatibleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCompatible", reflect.TypeOf((*MockProduct)(nil).AddCompatible), ctx, id, compatibleId)
+}
+
 // Create mocks base method.
 func (m *MockProduct) Create(ctx context.Context, product entity.Product) (*entity.Product, error) {
 	m.ctrl.T.Helper()
@@ -264,34 +280,78 @@ func (mr *MockProductMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockProduct)(nil).DeleteById), ctx, id)
 }
 
+// DeleteCompatible mocks base method.
+func (m *MockProduct) DeleteCompatible(ctx context.Context, id, compatibleId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCompatible", ctx, id, compatibleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCompatible indicates an expected call of DeleteCompatible.
+func (mr *MockProductMockRecorder) DeleteCompatible(ctx, id, compatibleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompatible", reflect.TypeOf((*MockProduct)(nil).DeleteCompatible), ctx, id, compatibleId)
+}
+
 // GetAll mocks base method.
-func (m *MockProduct) GetAll(ctx context.Context, filter *entity.ProductFilter) ([]*entity.Product, error) {
+func (m *MockProduct) GetAll(ctx context.Context, filter *entity.ProductFilter, pagination entity.Pagination, currency entity.Currency) (*entity.ProductPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx, filter)
-	ret0, _ := ret[0].([]*entity.Product)
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter, pagination, currency)
+	ret0, _ := ret[0].(*entity.ProductPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockProductMockRecorder) GetAll(ctx, filter interface{}) *gomock.Call {
+func (mr *MockProductMockRecorder) GetAll(ctx, filter, pagination, currency interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockProduct)(nil).GetAll), ctx, filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockProduct)(nil).GetAll), ctx, filter, pagination, currency)
 }
 
 // GetById mocks base method.
-func (m *MockProduct) GetById(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+func (m *MockProduct) GetById(ctx context.Context, id uuid.UUID, currency entity.Currency) (*entity.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret := m.ctrl.Call(m, "GetById", ctx, id, currency)
 	ret0, _ := ret[0].(*entity.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetById indicates an expected call of GetById.
-func (mr *MockProductMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+func (mr *MockProductMockRecorder) GetById(ctx, id, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockProduct)(nil).GetById), ctx, id, currency)
+}
+
+// GetByName mocks base method.
+func (m *MockProduct) GetByName(ctx context.Context, name string) ([]*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name)
+	ret0, _ := ret[0].([]*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockProductMockRecorder) GetByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockProduct)(nil).GetByName), ctx, name)
+}
+
+// GetCompatible mocks base method.
+func (m *MockProduct) GetCompatible(ctx context.Context, id uuid.UUID, filter *entity.ProductFilter, currency entity.Currency) ([]*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompatible", ctx, id, filter, currency)
+	ret0, _ := ret[0].([]*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompatible indicates an expected call of GetCompatible.
+func (mr *MockProductMockRecorder) GetCompatible(ctx, id, filter, currency interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockProduct)(nil).GetById), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompatible", reflect.TypeOf((*MockProduct)(nil).GetCompatible), ctx, id, filter, currency)
 }
 
 // Update mocks base method.
@@ -362,18 +422,18 @@ func (mr *MockProducerMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call
 }
 
 // GetAll mocks base method.
-func (m *MockProducer) GetAll(ctx context.Context) ([]*entity.Producer, error) {
+func (m *MockProducer) GetAll(ctx context.Context, pagination entity.Pagination) (*entity.ProducerPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
-	ret0, _ := ret[0].([]*entity.Producer)
+	ret := m.ctrl.Call(m, "GetAll", ctx, pagination)
+	ret0, _ := ret[0].(*entity.ProducerPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockProducerMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+func (mr *MockProducerMockRecorder) GetAll(ctx, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockProducer)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockProducer)(nil).GetAll), ctx, pagination)
 }
 
 // GetById mocks base method.
@@ -406,6 +466,118 @@ func (mr *MockProducerMockRecorder) Update(ctx, producer interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProducer)(nil).Update), ctx, producer)
 }
 
+// MockCategory is a mock of Category interface.
+type MockCategory struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryMockRecorder
+}
+
+// MockCategoryMockRecorder is the mock recorder for MockCategory.
+type MockCategoryMockRecorder struct {
+	mock *MockCategory
+}
+
+// NewMockCategory creates a new mock instance.
+func NewMockCategory(ctrl *gomock.Controller) *MockCategory {
+	mock := &MockCategory{ctrl: ctrl}
+	mock.recorder = &MockCategoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategory) EXPECT() *MockCategoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCategory) Create(ctx context.Context, category entity.Category) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, category)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCategoryMockRecorder) Create(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategory)(nil).Create), ctx, category)
+}
+
+// DeleteById mocks base method.
+func (m *MockCategory) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockCategoryMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockCategory)(nil).DeleteById), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockCategory) GetAll(ctx context.Context) ([]*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCategoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCategory)(nil).GetAll), ctx)
+}
+
+// GetById mocks base method.
+func (m *MockCategory) GetById(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockCategoryMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockCategory)(nil).GetById), ctx, id)
+}
+
+// GetTree mocks base method.
+func (m *MockCategory) GetTree(ctx context.Context) ([]*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTree", ctx)
+	ret0, _ := ret[0].([]*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTree indicates an expected call of GetTree.
+func (mr *MockCategoryMockRecorder) GetTree(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockCategory)(nil).GetTree), ctx)
+}
+
+// Update mocks base method.
+func (m *MockCategory) Update(ctx context.Context, category entity.Category) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, category)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCategoryMockRecorder) Update(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategory)(nil).Update), ctx, category)
+}
+
 // MockCart is a mock of Cart interface.
 type MockCart struct {
 	ctrl     *gomock.Controller
@@ -443,19 +615,91 @@ func (mr *MockCartMockRecorder) AddProduct(ctx, userId, productId, count interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockCart)(nil).AddProduct), ctx, userId, productId, count)
 }
 
-// GetAllProducts mocks base method.
-func (m *MockCart) GetAllProducts(ctx context.Context, userId uuid.UUID) ([]*entity.ProductInCart, error) {
+// ApplyPromoCode mocks base method.
+func (m *MockCart) ApplyPromoCode(ctx context.Context, userId uuid.UUID, code string, currency entity.Currency) (*entity.CartSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPromoCode", ctx, userId, code, currency)
+	ret0, _ := ret[0].(*entity.CartSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyPromoCode indicates an expected call of ApplyPromoCode.
+func (mr *MockCartMockRecorder) ApplyPromoCode(ctx, userId, code, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPromoCode", reflect.TypeOf((*MockCart)(nil).ApplyPromoCode), ctx, userId, code, currency)
+}
+
+// Clear mocks base method.
+func (m *MockCart) Clear(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockCartMockRecorder) Clear(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockCart)(nil).Clear), ctx, userId)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockCart) DeleteProduct(ctx context.Context, userId, productId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, userId, productId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockCartMockRecorder) DeleteProduct(ctx, userId, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockCart)(nil).DeleteProduct), ctx, userId, productId)
+}
+
+// GetPriceChanges mocks base method.
+func (m *MockCart) GetPriceChanges(ctx context.Context, productId uuid.UUID) ([]*entity.CartPriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceChanges", ctx, productId)
+	ret0, _ := ret[0].([]*entity.CartPriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceChanges indicates an expected call of GetPriceChanges.
+func (mr *MockCartMockRecorder) GetPriceChanges(ctx, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceChanges", reflect.TypeOf((*MockCart)(nil).GetPriceChanges), ctx, productId)
+}
+
+// GetSummary mocks base method.
+func (m *MockCart) GetSummary(ctx context.Context, userId uuid.UUID, currency entity.Currency) (*entity.CartSummary, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllProducts", ctx, userId)
-	ret0, _ := ret[0].([]*entity.ProductInCart)
+	ret := m.ctrl.Call(m, "GetSummary", ctx, userId, currency)
+	ret0, _ := ret[0].(*entity.CartSummary)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllProducts indicates an expected call of GetAllProducts.
-func (mr *MockCartMockRecorder) GetAllProducts(ctx, userId interface{}) *gomock.Call {
+// GetSummary indicates an expected call of GetSummary.
+func (mr *MockCartMockRecorder) GetSummary(ctx, userId, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummary", reflect.TypeOf((*MockCart)(nil).GetSummary), ctx, userId, currency)
+}
+
+// RemovePromoCode mocks base method.
+func (m *MockCart) RemovePromoCode(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePromoCode", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePromoCode indicates an expected call of RemovePromoCode.
+func (mr *MockCartMockRecorder) RemovePromoCode(ctx, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockCart)(nil).GetAllProducts), ctx, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePromoCode", reflect.TypeOf((*MockCart)(nil).RemovePromoCode), ctx, userId)
 }
 
 // UpdateCount mocks base method.
@@ -472,6 +716,114 @@ func (mr *MockCartMockRecorder) UpdateCount(ctx, userId, productId, count interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCount", reflect.TypeOf((*MockCart)(nil).UpdateCount), ctx, userId, productId, count)
 }
 
+// MockGuestCart is a mock of GuestCart interface.
+type MockGuestCart struct {
+	ctrl     *gomock.Controller
+	recorder *MockGuestCartMockRecorder
+}
+
+// MockGuestCartMockRecorder is the mock recorder for MockGuestCart.
+type MockGuestCartMockRecorder struct {
+	mock *MockGuestCart
+}
+
+// NewMockGuestCart creates a new mock instance.
+func NewMockGuestCart(ctrl *gomock.Controller) *MockGuestCart {
+	mock := &MockGuestCart{ctrl: ctrl}
+	mock.recorder = &MockGuestCartMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGuestCart) EXPECT() *MockGuestCartMockRecorder {
+	return m.recorder
+}
+
+// AddProduct mocks base method.
+func (m *MockGuestCart) AddProduct(ctx context.Context, token, productId uuid.UUID, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProduct", ctx, token, productId, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProduct indicates an expected call of AddProduct.
+func (mr *MockGuestCartMockRecorder) AddProduct(ctx, token, productId, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockGuestCart)(nil).AddProduct), ctx, token, productId, count)
+}
+
+// Clear mocks base method.
+func (m *MockGuestCart) Clear(ctx context.Context, token uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockGuestCartMockRecorder) Clear(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockGuestCart)(nil).Clear), ctx, token)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockGuestCart) DeleteProduct(ctx context.Context, token, productId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, token, productId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockGuestCartMockRecorder) DeleteProduct(ctx, token, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockGuestCart)(nil).DeleteProduct), ctx, token, productId)
+}
+
+// GetSummary mocks base method.
+func (m *MockGuestCart) GetSummary(ctx context.Context, token uuid.UUID, currency entity.Currency) (*entity.CartSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSummary", ctx, token, currency)
+	ret0, _ := ret[0].(*entity.CartSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSummary indicates an expected call of GetSummary.
+func (mr *MockGuestCartMockRecorder) GetSummary(ctx, token, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummary", reflect.TypeOf((*MockGuestCart)(nil).GetSummary), ctx, token, currency)
+}
+
+// Merge mocks base method.
+func (m *MockGuestCart) Merge(ctx context.Context, token, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, token, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockGuestCartMockRecorder) Merge(ctx, token, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockGuestCart)(nil).Merge), ctx, token, userId)
+}
+
+// UpdateCount mocks base method.
+func (m *MockGuestCart) UpdateCount(ctx context.Context, token, productId uuid.UUID, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCount", ctx, token, productId, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCount indicates an expected call of UpdateCount.
+func (mr *MockGuestCartMockRecorder) UpdateCount(ctx, token, productId, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCount", reflect.TypeOf((*MockGuestCart)(nil).UpdateCount), ctx, token, productId, count)
+}
+
 // MockOrder is a mock of Order interface.
 type MockOrder struct {
 	ctrl     *gomock.Controller
@@ -495,19 +847,34 @@ func (m *MockOrder) EXPECT() *MockOrderMockRecorder {
 	return m.recorder
 }
 
+// Cancel mocks base method.
+func (m *MockOrder) Cancel(ctx context.Context, userId, id uuid.UUID, comment string, isAdmin bool) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, userId, id, comment, isAdmin)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockOrderMockRecorder) Cancel(ctx, userId, id, comment, isAdmin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockOrder)(nil).Cancel), ctx, userId, id, comment, isAdmin)
+}
+
 // Create mocks base method.
-func (m *MockOrder) Create(ctx context.Context, userId uuid.UUID) (*entity.Order, error) {
+func (m *MockOrder) Create(ctx context.Context, userId uuid.UUID, currency entity.Currency, shippingAddressId uuid.UUID, billingAddressId *uuid.UUID, shippingMethod string) (*entity.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, userId)
+	ret := m.ctrl.Call(m, "Create", ctx, userId, currency, shippingAddressId, billingAddressId, shippingMethod)
 	ret0, _ := ret[0].(*entity.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockOrderMockRecorder) Create(ctx, userId interface{}) *gomock.Call {
+func (mr *MockOrderMockRecorder) Create(ctx, userId, currency, shippingAddressId, billingAddressId, shippingMethod interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrder)(nil).Create), ctx, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrder)(nil).Create), ctx, userId, currency, shippingAddressId, billingAddressId, shippingMethod)
 }
 
 // DeleteById mocks base method.
@@ -525,18 +892,18 @@ func (mr *MockOrderMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
 }
 
 // GetAll mocks base method.
-func (m *MockOrder) GetAll(ctx context.Context, filter *entity.OrderFilter) ([]*entity.Order, error) {
+func (m *MockOrder) GetAll(ctx context.Context, filter *entity.OrderFilter, pagination entity.Pagination) (*entity.OrderPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx, filter)
-	ret0, _ := ret[0].([]*entity.Order)
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter, pagination)
+	ret0, _ := ret[0].(*entity.OrderPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockOrderMockRecorder) GetAll(ctx, filter interface{}) *gomock.Call {
+func (mr *MockOrderMockRecorder) GetAll(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockOrder)(nil).GetAll), ctx, filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockOrder)(nil).GetAll), ctx, filter, pagination)
 }
 
 // GetById mocks base method.
@@ -554,17 +921,902 @@ func (mr *MockOrderMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockOrder)(nil).GetById), ctx, id)
 }
 
+// GetHistory mocks base method.
+func (m *MockOrder) GetHistory(ctx context.Context, id uuid.UUID) ([]*entity.OrderEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id)
+	ret0, _ := ret[0].([]*entity.OrderEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockOrderMockRecorder) GetHistory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockOrder)(nil).GetHistory), ctx, id)
+}
+
+// ReleaseExpired mocks base method.
+func (m *MockOrder) ReleaseExpired(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseExpired", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseExpired indicates an expected call of ReleaseExpired.
+func (mr *MockOrderMockRecorder) ReleaseExpired(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseExpired", reflect.TypeOf((*MockOrder)(nil).ReleaseExpired), ctx)
+}
+
 // UpdateById mocks base method.
-func (m *MockOrder) UpdateById(ctx context.Context, order *entity.Order) (*entity.Order, error) {
+func (m *MockOrder) UpdateById(ctx context.Context, userId uuid.UUID, order *entity.Order, comment string) (*entity.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateById", ctx, order)
+	ret := m.ctrl.Call(m, "UpdateById", ctx, userId, order, comment)
 	ret0, _ := ret[0].(*entity.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateById indicates an expected call of UpdateById.
-func (mr *MockOrderMockRecorder) UpdateById(ctx, order interface{}) *gomock.Call {
+func (mr *MockOrderMockRecorder) UpdateById(ctx, userId, order, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockOrder)(nil).UpdateById), ctx, userId, order, comment)
+}
+
+// MockShipment is a mock of Shipment interface.
+type MockShipment struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentMockRecorder
+}
+
+// MockShipmentMockRecorder is the mock recorder for MockShipment.
+type MockShipmentMockRecorder struct {
+	mock *MockShipment
+}
+
+// NewMockShipment creates a new mock instance.
+func NewMockShipment(ctrl *gomock.Controller) *MockShipment {
+	mock := &MockShipment{ctrl: ctrl}
+	mock.recorder = &MockShipmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipment) EXPECT() *MockShipmentMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShipment) Create(ctx context.Context, userId uuid.UUID, shipment entity.Shipment) (*entity.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, shipment)
+	ret0, _ := ret[0].(*entity.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShipmentMockRecorder) Create(ctx, userId, shipment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShipment)(nil).Create), ctx, userId, shipment)
+}
+
+// MockStock is a mock of Stock interface.
+type MockStock struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockMockRecorder
+}
+
+// MockStockMockRecorder is the mock recorder for MockStock.
+type MockStockMockRecorder struct {
+	mock *MockStock
+}
+
+// NewMockStock creates a new mock instance.
+func NewMockStock(ctrl *gomock.Controller) *MockStock {
+	mock := &MockStock{ctrl: ctrl}
+	mock.recorder = &MockStockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStock) EXPECT() *MockStockMockRecorder {
+	return m.recorder
+}
+
+// Adjust mocks base method.
+func (m *MockStock) Adjust(ctx context.Context, userId, productId, warehouseId uuid.UUID, delta int, reason string) (*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Adjust", ctx, userId, productId, warehouseId, delta, reason)
+	ret0, _ := ret[0].(*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Adjust indicates an expected call of Adjust.
+func (mr *MockStockMockRecorder) Adjust(ctx, userId, productId, warehouseId, delta, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Adjust", reflect.TypeOf((*MockStock)(nil).Adjust), ctx, userId, productId, warehouseId, delta, reason)
+}
+
+// GetLevels mocks base method.
+func (m *MockStock) GetLevels(ctx context.Context, productId uuid.UUID) ([]*entity.WarehouseStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLevels", ctx, productId)
+	ret0, _ := ret[0].([]*entity.WarehouseStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLevels indicates an expected call of GetLevels.
+func (mr *MockStockMockRecorder) GetLevels(ctx, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLevels", reflect.TypeOf((*MockStock)(nil).GetLevels), ctx, productId)
+}
+
+// GetMovements mocks base method.
+func (m *MockStock) GetMovements(ctx context.Context, productId uuid.UUID) ([]*entity.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovements", ctx, productId)
+	ret0, _ := ret[0].([]*entity.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovements indicates an expected call of GetMovements.
+func (mr *MockStockMockRecorder) GetMovements(ctx, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovements", reflect.TypeOf((*MockStock)(nil).GetMovements), ctx, productId)
+}
+
+// MockWarehouse is a mock of Warehouse interface.
+type MockWarehouse struct {
+	ctrl     *gomock.Controller
+	recorder *MockWarehouseMockRecorder
+}
+
+// MockWarehouseMockRecorder is the mock recorder for MockWarehouse.
+type MockWarehouseMockRecorder struct {
+	mock *MockWarehouse
+}
+
+// NewMockWarehouse creates a new mock instance.
+func NewMockWarehouse(ctrl *gomock.Controller) *MockWarehouse {
+	mock := &MockWarehouse{ctrl: ctrl}
+	mock.recorder = &MockWarehouseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWarehouse) EXPECT() *MockWarehouseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWarehouse) Create(ctx context.Context, warehouse entity.Warehouse) (*entity.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, warehouse)
+	ret0, _ := ret[0].(*entity.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWarehouseMockRecorder) Create(ctx, warehouse interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWarehouse)(nil).Create), ctx, warehouse)
+}
+
+// DeleteById mocks base method.
+func (m *MockWarehouse) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockWarehouseMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockWarehouse)(nil).DeleteById), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockWarehouse) GetAll(ctx context.Context) ([]*entity.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*entity.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockWarehouseMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWarehouse)(nil).GetAll), ctx)
+}
+
+// GetById mocks base method.
+func (m *MockWarehouse) GetById(ctx context.Context, id uuid.UUID) (*entity.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*entity.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockWarehouseMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockWarehouse)(nil).GetById), ctx, id)
+}
+
+// GetStock mocks base method.
+func (m *MockWarehouse) GetStock(ctx context.Context, id uuid.UUID) ([]*entity.WarehouseStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStock", ctx, id)
+	ret0, _ := ret[0].([]*entity.WarehouseStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStock indicates an expected call of GetStock.
+func (mr *MockWarehouseMockRecorder) GetStock(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStock", reflect.TypeOf((*MockWarehouse)(nil).GetStock), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockWarehouse) Update(ctx context.Context, warehouse entity.Warehouse) (*entity.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, warehouse)
+	ret0, _ := ret[0].(*entity.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWarehouseMockRecorder) Update(ctx, warehouse interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWarehouse)(nil).Update), ctx, warehouse)
+}
+
+// MockExchangeRate is a mock of ExchangeRate interface.
+type MockExchangeRate struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateMockRecorder
+}
+
+// MockExchangeRateMockRecorder is the mock recorder for MockExchangeRate.
+type MockExchangeRateMockRecorder struct {
+	mock *MockExchangeRate
+}
+
+// NewMockExchangeRate creates a new mock instance.
+func NewMockExchangeRate(ctrl *gomock.Controller) *MockExchangeRate {
+	mock := &MockExchangeRate{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRate) EXPECT() *MockExchangeRateMockRecorder {
+	return m.recorder
+}
+
+// BaseCurrency mocks base method.
+func (m *MockExchangeRate) BaseCurrency() entity.Currency {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseCurrency")
+	ret0, _ := ret[0].(entity.Currency)
+	return ret0
+}
+
+// BaseCurrency indicates an expected call of BaseCurrency.
+func (mr *MockExchangeRateMockRecorder) BaseCurrency() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseCurrency", reflect.TypeOf((*MockExchangeRate)(nil).BaseCurrency))
+}
+
+// DeleteByCurrency mocks base method.
+func (m *MockExchangeRate) DeleteByCurrency(ctx context.Context, currency entity.Currency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByCurrency", ctx, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByCurrency indicates an expected call of DeleteByCurrency.
+func (mr *MockExchangeRateMockRecorder) DeleteByCurrency(ctx, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByCurrency", reflect.TypeOf((*MockExchangeRate)(nil).DeleteByCurrency), ctx, currency)
+}
+
+// GetAll mocks base method.
+func (m *MockExchangeRate) GetAll(ctx context.Context) ([]*entity.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*entity.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockExchangeRateMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockExchangeRate)(nil).GetAll), ctx)
+}
+
+// GetByCurrency mocks base method.
+func (m *MockExchangeRate) GetByCurrency(ctx context.Context, currency entity.Currency) (*entity.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCurrency", ctx, currency)
+	ret0, _ := ret[0].(*entity.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCurrency indicates an expected call of GetByCurrency.
+func (mr *MockExchangeRateMockRecorder) GetByCurrency(ctx, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCurrency", reflect.TypeOf((*MockExchangeRate)(nil).GetByCurrency), ctx, currency)
+}
+
+// GetRate mocks base method.
+func (m *MockExchangeRate) GetRate(ctx context.Context, currency entity.Currency) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRate", ctx, currency)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRate indicates an expected call of GetRate.
+func (mr *MockExchangeRateMockRecorder) GetRate(ctx, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRate", reflect.TypeOf((*MockExchangeRate)(nil).GetRate), ctx, currency)
+}
+
+// Set mocks base method.
+func (m *MockExchangeRate) Set(ctx context.Context, rate entity.ExchangeRate) (*entity.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, rate)
+	ret0, _ := ret[0].(*entity.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockExchangeRateMockRecorder) Set(ctx, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockExchangeRate)(nil).Set), ctx, rate)
+}
+
+// MockWishlist is a mock of Wishlist interface.
+type MockWishlist struct {
+	ctrl     *gomock.Controller
+	recorder *MockWishlistMockRecorder
+}
+
+// MockWishlistMockRecorder is the mock recorder for MockWishlist.
+type MockWishlistMockRecorder struct {
+	mock *MockWishlist
+}
+
+// NewMockWishlist creates a new mock instance.
+func NewMockWishlist(ctrl *gomock.Controller) *MockWishlist {
+	mock := &MockWishlist{ctrl: ctrl}
+	mock.recorder = &MockWishlistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWishlist) EXPECT() *MockWishlistMockRecorder {
+	return m.recorder
+}
+
+// AddProduct mocks base method.
+func (m *MockWishlist) AddProduct(ctx context.Context, userId, id, productId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProduct", ctx, userId, id, productId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProduct indicates an expected call of AddProduct.
+func (mr *MockWishlistMockRecorder) AddProduct(ctx, userId, id, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockWishlist)(nil).AddProduct), ctx, userId, id, productId)
+}
+
+// Create mocks base method.
+func (m *MockWishlist) Create(ctx context.Context, wishlist entity.Wishlist) (*entity.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, wishlist)
+	ret0, _ := ret[0].(*entity.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWishlistMockRecorder) Create(ctx, wishlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWishlist)(nil).Create), ctx, wishlist)
+}
+
+// DeleteById mocks base method.
+func (m *MockWishlist) DeleteById(ctx context.Context, userId, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockWishlistMockRecorder) DeleteById(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockWishlist)(nil).DeleteById), ctx, userId, id)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockWishlist) DeleteProduct(ctx context.Context, userId, id, productId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, userId, id, productId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockWishlistMockRecorder) DeleteProduct(ctx, userId, id, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockWishlist)(nil).DeleteProduct), ctx, userId, id, productId)
+}
+
+// GetAll mocks base method.
+func (m *MockWishlist) GetAll(ctx context.Context, userId uuid.UUID) ([]*entity.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userId)
+	ret0, _ := ret[0].([]*entity.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockWishlistMockRecorder) GetAll(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWishlist)(nil).GetAll), ctx, userId)
+}
+
+// GetById mocks base method.
+func (m *MockWishlist) GetById(ctx context.Context, userId, id uuid.UUID) (*entity.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, userId, id)
+	ret0, _ := ret[0].(*entity.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockWishlistMockRecorder) GetById(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockWishlist)(nil).GetById), ctx, userId, id)
+}
+
+// GetShared mocks base method.
+func (m *MockWishlist) GetShared(ctx context.Context, token uuid.UUID) (*entity.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShared", ctx, token)
+	ret0, _ := ret[0].(*entity.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShared indicates an expected call of GetShared.
+func (mr *MockWishlistMockRecorder) GetShared(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShared", reflect.TypeOf((*MockWishlist)(nil).GetShared), ctx, token)
+}
+
+// MoveFromCart mocks base method.
+func (m *MockWishlist) MoveFromCart(ctx context.Context, userId, id, productId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveFromCart", ctx, userId, id, productId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveFromCart indicates an expected call of MoveFromCart.
+func (mr *MockWishlistMockRecorder) MoveFromCart(ctx, userId, id, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveFromCart", reflect.TypeOf((*MockWishlist)(nil).MoveFromCart), ctx, userId, id, productId)
+}
+
+// MoveToCart mocks base method.
+func (m *MockWishlist) MoveToCart(ctx context.Context, userId, id, productId uuid.UUID, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveToCart", ctx, userId, id, productId, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveToCart indicates an expected call of MoveToCart.
+func (mr *MockWishlistMockRecorder) MoveToCart(ctx, userId, id, productId, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveToCart", reflect.TypeOf((*MockWishlist)(nil).MoveToCart), ctx, userId, id, productId, count)
+}
+
+// Share mocks base method.
+func (m *MockWishlist) Share(ctx context.Context, userId, id uuid.UUID) (*entity.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Share", ctx, userId, id)
+	ret0, _ := ret[0].(*entity.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Share indicates an expected call of Share.
+func (mr *MockWishlistMockRecorder) Share(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Share", reflect.TypeOf((*MockWishlist)(nil).Share), ctx, userId, id)
+}
+
+// Unshare mocks base method.
+func (m *MockWishlist) Unshare(ctx context.Context, userId, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unshare", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unshare indicates an expected call of Unshare.
+func (mr *MockWishlistMockRecorder) Unshare(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unshare", reflect.TypeOf((*MockWishlist)(nil).Unshare), ctx, userId, id)
+}
+
+// Update mocks base method.
+func (m *MockWishlist) Update(ctx context.Context, wishlist entity.Wishlist) (*entity.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, wishlist)
+	ret0, _ := ret[0].(*entity.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWishlistMockRecorder) Update(ctx, wishlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWishlist)(nil).Update), ctx, wishlist)
+}
+
+// MockPromoCode is a mock of PromoCode interface.
+type MockPromoCode struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromoCodeMockRecorder
+}
+
+// MockPromoCodeMockRecorder is the mock recorder for MockPromoCode.
+type MockPromoCodeMockRecorder struct {
+	mock *MockPromoCode
+}
+
+// NewMockPromoCode creates a new mock instance.
+func NewMockPromoCode(ctrl *gomock.Controller) *MockPromoCode {
+	mock := &MockPromoCode{ctrl: ctrl}
+	mock.recorder = &MockPromoCodeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromoCode) EXPECT() *MockPromoCodeMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPromoCode) Create(ctx context.Context, promoCode entity.PromoCode) (*entity.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, promoCode)
+	ret0, _ := ret[0].(*entity.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromoCodeMockRecorder) Create(ctx, promoCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromoCode)(nil).Create), ctx, promoCode)
+}
+
+// DeleteById mocks base method.
+func (m *MockPromoCode) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockPromoCodeMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockPromoCode)(nil).DeleteById), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockPromoCode) GetAll(ctx context.Context) ([]*entity.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*entity.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPromoCodeMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPromoCode)(nil).GetAll), ctx)
+}
+
+// GetById mocks base method.
+func (m *MockPromoCode) GetById(ctx context.Context, id uuid.UUID) (*entity.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*entity.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockPromoCodeMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPromoCode)(nil).GetById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockPromoCode) Update(ctx context.Context, promoCode entity.PromoCode) (*entity.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, promoCode)
+	ret0, _ := ret[0].(*entity.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPromoCodeMockRecorder) Update(ctx, promoCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPromoCode)(nil).Update), ctx, promoCode)
+}
+
+// MockPromotion is a mock of Promotion interface.
+type MockPromotion struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionMockRecorder
+}
+
+// MockPromotionMockRecorder is the mock recorder for MockPromotion.
+type MockPromotionMockRecorder struct {
+	mock *MockPromotion
+}
+
+// NewMockPromotion creates a new mock instance.
+func NewMockPromotion(ctrl *gomock.Controller) *MockPromotion {
+	mock := &MockPromotion{ctrl: ctrl}
+	mock.recorder = &MockPromotionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotion) EXPECT() *MockPromotionMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPromotion) Create(ctx context.Context, promotion entity.Promotion) (*entity.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, promotion)
+	ret0, _ := ret[0].(*entity.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromotionMockRecorder) Create(ctx, promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromotion)(nil).Create), ctx, promotion)
+}
+
+// DeleteById mocks base method.
+func (m *MockPromotion) DeleteById(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockPromotionMockRecorder) DeleteById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockPromotion)(nil).DeleteById), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockPromotion) GetAll(ctx context.Context) ([]*entity.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*entity.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPromotionMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPromotion)(nil).GetAll), ctx)
+}
+
+// GetById mocks base method.
+func (m *MockPromotion) GetById(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(*entity.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockPromotionMockRecorder) GetById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPromotion)(nil).GetById), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockPromotion) Update(ctx context.Context, promotion entity.Promotion) (*entity.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, promotion)
+	ret0, _ := ret[0].(*entity.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPromotionMockRecorder) Update(ctx, promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPromotion)(nil).Update), ctx, promotion)
+}
+
+// MockAddress is a mock of Address interface.
+type MockAddress struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddressMockRecorder
+}
+
+// MockAddressMockRecorder is the mock recorder for MockAddress.
+type MockAddressMockRecorder struct {
+	mock *MockAddress
+}
+
+// NewMockAddress creates a new mock instance.
+func NewMockAddress(ctrl *gomock.Controller) *MockAddress {
+	mock := &MockAddress{ctrl: ctrl}
+	mock.recorder = &MockAddressMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAddress) EXPECT() *MockAddressMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAddress) Create(ctx context.Context, address entity.Address) (*entity.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, address)
+	ret0, _ := ret[0].(*entity.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAddressMockRecorder) Create(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAddress)(nil).Create), ctx, address)
+}
+
+// DeleteById mocks base method.
+func (m *MockAddress) DeleteById(ctx context.Context, userId, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockAddressMockRecorder) DeleteById(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockAddress)(nil).DeleteById), ctx, userId, id)
+}
+
+// GetAll mocks base method.
+func (m *MockAddress) GetAll(ctx context.Context, userId uuid.UUID) ([]*entity.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userId)
+	ret0, _ := ret[0].([]*entity.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAddressMockRecorder) GetAll(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAddress)(nil).GetAll), ctx, userId)
+}
+
+// GetById mocks base method.
+func (m *MockAddress) GetById(ctx context.Context, userId, id uuid.UUID) (*entity.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, userId, id)
+	ret0, _ := ret[0].(*entity.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockAddressMockRecorder) GetById(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockAddress)(nil).GetById), ctx, userId, id)
+}
+
+// Update mocks base method.
+func (m *MockAddress) Update(ctx context.Context, address entity.Address) (*entity.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, address)
+	ret0, _ := ret[0].(*entity.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAddressMockRecorder) Update(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAddress)(nil).Update), ctx, address)
+}
+
+// MockShipping is a mock of Shipping interface.
+type MockShipping struct {
+	ctrl     *gomock.Controller
+	recorder *MockShippingMockRecorder
+}
+
+// MockShippingMockRecorder is the mock recorder for MockShipping.
+type MockShippingMockRecorder struct {
+	mock *MockShipping
+}
+
+// NewMockShipping creates a new mock instance.
+func NewMockShipping(ctrl *gomock.Controller) *MockShipping {
+	mock := &MockShipping{ctrl: ctrl}
+	mock.recorder = &MockShippingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipping) EXPECT() *MockShippingMockRecorder {
+	return m.recorder
+}
+
+// Quote mocks base method.
+func (m *MockShipping) Quote(ctx context.Context, userId uuid.UUID, currency entity.Currency, addressId *uuid.UUID) ([]*entity.ShippingQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", ctx, userId, currency, addressId)
+	ret0, _ := ret[0].([]*entity.ShippingQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockShippingMockRecorder) Quote(ctx, userId, currency, addressId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockOrder)(nil).UpdateById), ctx, order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockShipping)(nil).Quote), ctx, userId, currency, addressId)
 }
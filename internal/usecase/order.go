@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,65 +12,301 @@ import (
 	"github.com/krijebr/printer-shop/internal/repo"
 )
 
+var orderStatusTransitions = map[entity.OrderStatus][]entity.OrderStatus{
+	entity.OrderStatusNew:        {entity.OrderStatusPaid, entity.OrderStatusCancelled},
+	entity.OrderStatusPaid:       {entity.OrderStatusInProgress, entity.OrderStatusCancelled, entity.OrderStatusRefunded},
+	entity.OrderStatusInProgress: {entity.OrderStatusShipped, entity.OrderStatusRefunded},
+	entity.OrderStatusShipped:    {entity.OrderStatusDelivered, entity.OrderStatusDone},
+	entity.OrderStatusDelivered:  {entity.OrderStatusDone, entity.OrderStatusRefunded},
+	entity.OrderStatusDone:       {entity.OrderStatusRefunded},
+	entity.OrderStatusCancelled:  {},
+	entity.OrderStatusRefunded:   {},
+}
+
+func checkOrderStatusTransition(from, to entity.OrderStatus) error {
+	allowed, ok := orderStatusTransitions[from]
+	if !ok || !slices.Contains(allowed, to) {
+		return fmt.Errorf("%w: from %s to %s", ErrOrderStatusTransitionNotAllowed, from, to)
+	}
+	return nil
+}
+
+// calculateOrderTotals sums the order lines, the order discount and tax are the sums of the line
+// ones. The tax is added to the total unless prices include it, the shipping cost is always added.
+func calculateOrderTotals(o *entity.Order, currency entity.Currency, tax entity.TaxSettings) {
+	o.ItemsCount = 0
+	o.Subtotal = entity.NewMoney(0, currency)
+	o.Discount = entity.NewMoney(0, currency)
+	for _, p := range o.Products {
+		p.Total = p.Product.Price.Mul(p.Count)
+		o.ItemsCount += p.Count
+		o.Subtotal = o.Subtotal.Add(p.Total)
+		if p.Discount != nil {
+			o.Discount = o.Discount.Add(*p.Discount)
+		}
+	}
+	o.Tax = applyTax(tax, o.Products, currency)
+	o.PricesIncludeTax = tax.PricesIncludeTax
+	o.Total = o.Subtotal.Sub(o.Discount)
+	if !o.PricesIncludeTax {
+		o.Total = o.Total.Add(o.Tax)
+	}
+	o.Shipping.Currency = currency
+	o.Total = o.Total.Add(o.Shipping)
+}
+
 type order struct {
-	repo        repo.Order
-	repoCart    repo.Cart
-	repoProduct repo.Product
+	repo           repo.Order
+	repoCart       repo.Cart
+	repoProduct    repo.Product
+	repoWarehouse  repo.Warehouse
+	repoEvent      repo.OrderEvent
+	repoPromoCode  repo.PromoCode
+	repoPromotion  repo.Promotion
+	repoAddress    repo.Address
+	exchangeRate   ExchangeRate
+	tax            entity.TaxSettings
+	shipping       []*entity.ShippingMethod
+	reservationTTL time.Duration
 }
 
-func NewOrder(r repo.Order, c repo.Cart, p repo.Product) Order {
+// NewOrder creates the order usecase. Stock of a placed order is reserved for reservationTTL,
+// a zero TTL keeps the reservation until the order is paid or cancelled.
+func NewOrder(r repo.Order, c repo.Cart, p repo.Product, w repo.Warehouse, ev repo.OrderEvent, pc repo.PromoCode, pm repo.Promotion,
+	a repo.Address, e ExchangeRate, tax entity.TaxSettings, shipping []*entity.ShippingMethod, reservationTTL time.Duration) Order {
 	return &order{
-		repo:        r,
-		repoCart:    c,
-		repoProduct: p,
+		repo:           r,
+		repoCart:       c,
+		repoProduct:    p,
+		repoWarehouse:  w,
+		repoEvent:      ev,
+		repoPromoCode:  pc,
+		repoPromotion:  pm,
+		repoAddress:    a,
+		exchangeRate:   e,
+		tax:            tax,
+		shipping:       shipping,
+		reservationTTL: reservationTTL,
 	}
 }
 
-func (o *order) Create(ctx context.Context, userId uuid.UUID) (*entity.Order, error) {
+// allocate picks the warehouses the order lines are shipped from. Stock allocated to the
+// released lines, the current lines of an order being changed, is counted as available.
+func (o *order) allocate(ctx context.Context, lines []*entity.ProductInCart, released []*entity.ProductInCart) error {
+	warehouses, err := o.repoWarehouse.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	productIds := make([]uuid.UUID, 0, len(lines))
+	for _, line := range lines {
+		productIds = append(productIds, line.Product.Id)
+	}
+	stock, err := o.repoWarehouse.GetStock(ctx, &entity.WarehouseStockFilter{ProductIds: productIds})
+	if err != nil {
+		return err
+	}
+	for _, line := range released {
+		for _, allocation := range line.Allocations {
+			stock = append(stock, &entity.WarehouseStock{
+				WarehouseId: allocation.WarehouseId,
+				ProductId:   line.Product.Id,
+				Quantity:    allocation.Count,
+			})
+		}
+	}
+	return allocateOrder(lines, warehouses, stock)
+}
+
+// newOrderEvent makes the event for the history of the order, a nil user id means it was made by the system.
+// The event is saved by the repo together with the change of the order.
+func newOrderEvent(orderId uuid.UUID, userId uuid.UUID, eventType entity.OrderEventType, from, to entity.OrderStatus, comment string) entity.OrderEvent {
+	event := entity.OrderEvent{
+		Id:        uuid.New(),
+		OrderId:   orderId,
+		Type:      eventType,
+		Comment:   comment,
+		CreatedAt: time.Now(),
+	}
+	if userId != uuid.Nil {
+		event.UserId = &userId
+	}
+	if from != "" {
+		event.FromStatus = &from
+	}
+	if to != "" {
+		event.ToStatus = &to
+	}
+	return event
+}
+
+// Create places the order with the products of the cart. The shipping address and the billing
+// address, if there is one, are copied from the address book of the user onto the order, which
+// is shipped by the shipping method with the code.
+func (o *order) Create(ctx context.Context, userId uuid.UUID, currency entity.Currency, shippingAddressId uuid.UUID,
+	billingAddressId *uuid.UUID, shippingMethod string) (*entity.Order, error) {
 	productsInCart, err := o.repoCart.GetAllProducts(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
 	if len(productsInCart) == 0 {
 		return nil, ErrCartIsEmpty
 	}
+	warnings := make(map[uuid.UUID][]*entity.CartWarning)
+	for _, p := range productsInCart {
+		lineWarnings := cartLineWarnings(p)
+		for _, w := range lineWarnings {
+			switch w.Type {
+			case entity.CartWarningTypeProductHidden:
+				return nil, ErrCartHasUnavailableProducts
+			case entity.CartWarningTypeInsufficientStock:
+				return nil, ErrOutOfStock
+			}
+		}
+		if len(lineWarnings) != 0 {
+			warnings[p.Product.Id] = lineWarnings
+		}
+	}
+	promotions, err := o.repoPromotion.GetActive(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	promo, err := loadCartPromoCode(ctx, o.repoPromoCode, userId, productsInCart)
+	if err != nil {
+		return nil, err
+	}
+	if currency == "" {
+		currency = o.exchangeRate.BaseCurrency()
+	}
+	rate, err := o.exchangeRate.GetRate(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+	shippingAddress, err := getOwnedAddress(ctx, o.repoAddress, userId, shippingAddressId)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(o.shipping, func(m *entity.ShippingMethod) bool { return m.Code == shippingMethod })
+	if i < 0 {
+		return nil, ErrShippingMethodNotFound
+	}
+	method := o.shipping[i]
 	newOrder := &entity.Order{
-		Id:        uuid.New(),
-		UserId:    userId,
-		Status:    entity.OrderStatusNew,
-		CreatedAt: time.Now(),
+		Id:              uuid.New(),
+		UserId:          userId,
+		Status:          entity.OrderStatusNew,
+		CreatedAt:       time.Now(),
+		Currency:        currency,
+		ExchangeRate:    rate,
+		ShippingAddress: shippingAddress.ToOrderAddress(),
+		ShippingMethod:  method.Code,
+	}
+	if billingAddressId != nil {
+		billingAddress, err := getOwnedAddress(ctx, o.repoAddress, userId, *billingAddressId)
+		if err != nil {
+			return nil, err
+		}
+		newOrder.BillingAddress = billingAddress.ToOrderAddress()
+	}
+	if o.reservationTTL > 0 {
+		reservedUntil := newOrder.CreatedAt.Add(o.reservationTTL)
+		newOrder.ReservedUntil = &reservedUntil
 	}
-	publishedProducts := make([]*entity.ProductInCart, 0, len(productsInCart))
 	for _, p := range productsInCart {
-		if p.Product.Status != entity.ProductStatusHidden {
-			publishedProducts = append(publishedProducts, p)
+		p.Product.Price = p.Product.Price.Convert(currency, rate)
+		p.PriceWhenAdded = nil
+	}
+	newOrder.Products = productsInCart
+	applyPromotions(promotions, newOrder.Products, currency, rate)
+	if promo != nil {
+		promo.apply(newOrder.Products, currency, rate)
+		if promo.err != nil {
+			return nil, promo.err
 		}
+		newOrder.PromoCodeId = &promo.promoCode.Id
+		newOrder.PromoCode = &promo.promoCode.Code
 	}
-	newOrder.Products = publishedProducts
-
-	err = o.repo.Create(ctx, newOrder)
+	parcel := newParcel(newOrder.Products, o.exchangeRate.BaseCurrency(), rate, shippingAddress)
+	shippingCost, ok := quoteShipping(method, parcel, currency, rate)
+	if !ok {
+		return nil, ErrShippingNotAvailable
+	}
+	newOrder.Shipping = shippingCost
+	calculateOrderTotals(newOrder, newOrder.Currency, o.tax)
+	err = o.allocate(ctx, newOrder.Products, nil)
 	if err != nil {
 		return nil, err
 	}
+
+	err = o.repo.Create(ctx, newOrder, newOrderEvent(newOrder.Id, userId, entity.OrderEventTypeCreated, "", newOrder.Status, ""))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrOutOfStock):
+			return nil, ErrOutOfStock
+		case errors.Is(err, repo.ErrPromoCodeUsageLimitReached):
+			return nil, ErrPromoCodeUsageLimitReached
+		case errors.Is(err, repo.ErrPromoCodeNotFound):
+			// The promo code was deleted after it had been checked.
+			return nil, ErrPromoCodeNotActive
+		default:
+			return nil, err
+		}
+	}
 	err = o.repoCart.ClearCart(ctx, userId)
 	if err != nil {
 		return nil, err
 	}
+	if promo != nil {
+		err = o.repoPromoCode.DeleteCartPromoCode(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+	}
 	createdOrder, err := o.repo.GetById(ctx, newOrder.Id)
 	if err != nil {
 		return nil, err
 	}
+	// The order is placed at current prices, so changed ones are reported on its lines.
+	for _, p := range createdOrder.Products {
+		p.Warnings = warnings[p.Product.Id]
+	}
 	return createdOrder, nil
 }
 
-func (o *order) GetAll(ctx context.Context, filter *entity.OrderFilter) ([]*entity.Order, error) {
-	orders, err := o.repo.GetAll(ctx, filter)
+// ReleaseExpired cancels new orders which weren't paid before their reservation ended,
+// which returns their stock. It returns the number of cancelled orders.
+func (o *order) ReleaseExpired(ctx context.Context) (int, error) {
+	now := time.Now()
+	ids, err := o.repo.GetExpiredReservations(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	released := 0
+	for _, id := range ids {
+		expired, err := o.repo.ExpireReservation(ctx, id, now, newOrderEvent(id, uuid.Nil, entity.OrderEventTypeStatusChanged,
+			entity.OrderStatusNew, entity.OrderStatusCancelled, "reservation expired"))
+		if err != nil {
+			return released, err
+		}
+		if expired {
+			released++
+		}
+	}
+	return released, nil
+}
+
+func (o *order) GetAll(ctx context.Context, filter *entity.OrderFilter, pagination entity.Pagination) (*entity.OrderPage, error) {
+	page, err := o.repo.GetPage(ctx, filter, pagination)
 	if err != nil {
 		switch {
 		case errors.Is(err, repo.ErrOrderNotFound):
 			return nil, ErrOrderNotFound
+		case errors.Is(err, repo.ErrInvalidCursor):
+			return nil, ErrInvalidCursor
 		default:
 			return nil, err
 		}
 	}
-	return orders, nil
+	return page, nil
 }
 
 func (o *order) GetById(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
@@ -104,7 +342,78 @@ func (o *order) DeleteById(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (o *order) UpdateById(ctx context.Context, orderToUpdate *entity.Order) (*entity.Order, error) {
+func (o *order) GetHistory(ctx context.Context, id uuid.UUID) ([]*entity.OrderEvent, error) {
+	_, err := o.repo.GetById(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrOrderNotFound):
+			return nil, ErrOrderNotFound
+		default:
+			return nil, err
+		}
+	}
+	return o.repoEvent.GetByOrderId(ctx, id)
+}
+
+// Cancel cancels the new order for the user, customers can cancel only their own orders. The
+// status is checked again when the order is cancelled, so an order paid meanwhile isn't cancelled.
+func (o *order) Cancel(ctx context.Context, userId uuid.UUID, id uuid.UUID, comment string, isAdmin bool) (*entity.Order, error) {
+	orderToCancel, err := o.repo.GetById(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrOrderNotFound):
+			return nil, ErrOrderNotFound
+		default:
+			return nil, err
+		}
+	}
+	if !isAdmin && orderToCancel.UserId != userId {
+		return nil, ErrOrderOfAnotherUser
+	}
+	if orderToCancel.Status != entity.OrderStatusNew {
+		return nil, ErrOrderCantBeCancelled
+	}
+	cancelled, err := o.repo.Cancel(ctx, id,
+		newOrderEvent(id, userId, entity.OrderEventTypeStatusChanged, entity.OrderStatusNew, entity.OrderStatusCancelled, comment))
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, ErrOrderCantBeCancelled
+	}
+	cancelledOrder, err := o.repo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return cancelledOrder, nil
+}
+
+// applyOrderDiscounts discounts the changed lines of the order with the promotions active now
+// and the promo code the order was placed with. The code isn't checked again, the lines get
+// no discount from it when its conditions aren't met anymore or the code was changed so that
+// it doesn't apply to them.
+func (o *order) applyOrderDiscounts(ctx context.Context, existingOrder *entity.Order, lines []*entity.ProductInCart) error {
+	promotions, err := o.repoPromotion.GetActive(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	applyPromotions(promotions, lines, existingOrder.Currency, existingOrder.ExchangeRate)
+	if existingOrder.PromoCodeId == nil {
+		return nil
+	}
+	promo, err := o.repoPromoCode.GetById(ctx, *existingOrder.PromoCodeId)
+	if err != nil {
+		return err
+	}
+	applied, err := newCartPromoCode(ctx, o.repoPromoCode, promo, lines)
+	if err != nil {
+		return err
+	}
+	applied.apply(lines, existingOrder.Currency, existingOrder.ExchangeRate)
+	return nil
+}
+
+func (o *order) UpdateById(ctx context.Context, userId uuid.UUID, orderToUpdate *entity.Order, comment string) (*entity.Order, error) {
 	existingOrder, err := o.repo.GetById(ctx, orderToUpdate.Id)
 	if err != nil {
 		switch {
@@ -114,11 +423,19 @@ func (o *order) UpdateById(ctx context.Context, orderToUpdate *entity.Order) (*e
 			return nil, err
 		}
 	}
+	if orderToUpdate.Status == existingOrder.Status {
+		orderToUpdate.Status = ""
+	}
+	if orderToUpdate.Status != "" {
+		err = checkOrderStatusTransition(existingOrder.Status, orderToUpdate.Status)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if orderToUpdate.Products != nil {
 		if existingOrder.Status != entity.OrderStatusNew {
 			return nil, ErrOrderCantBeUpdated
 		}
-		publishedProducts := make([]*entity.ProductInCart, 0, len(orderToUpdate.Products))
 		for _, newProduct := range orderToUpdate.Products {
 			product, err := o.repoProduct.GetById(ctx, newProduct.Product.Id)
 			if err != nil {
@@ -129,17 +446,46 @@ func (o *order) UpdateById(ctx context.Context, orderToUpdate *entity.Order) (*e
 					return nil, err
 				}
 			}
-			newProduct.Product.Price = product.Price
-			if product.Status != entity.ProductStatusHidden {
-				publishedProducts = append(publishedProducts, newProduct)
+			if product.Status == entity.ProductStatusHidden {
+				return nil, fmt.Errorf("%w: %s", ErrOrderHasUnavailableProducts, product.Id)
 			}
-
+			newProduct.Product.Price = product.Price.Convert(existingOrder.Currency, existingOrder.ExchangeRate)
+		}
+		err = o.applyOrderDiscounts(ctx, existingOrder, orderToUpdate.Products)
+		if err != nil {
+			return nil, err
 		}
-		orderToUpdate.Products = publishedProducts
+		// Changed lines are taxed at current rates, but the order keeps whether its prices include the tax.
+		tax := o.tax
+		tax.PricesIncludeTax = existingOrder.PricesIncludeTax
+		// The order is shipped at the cost it was placed with.
+		orderToUpdate.Shipping = existingOrder.Shipping
+		calculateOrderTotals(orderToUpdate, existingOrder.Currency, tax)
+		err = o.allocate(ctx, orderToUpdate.Products, existingOrder.Products)
+		if err != nil {
+			return nil, err
+		}
+	}
+	events := []entity.OrderEvent{}
+	if orderToUpdate.Products != nil {
+		events = append(events, newOrderEvent(orderToUpdate.Id, userId, entity.OrderEventTypeUpdated, "", "", comment))
 	}
-	err = o.repo.UpdateById(ctx, orderToUpdate)
+	if orderToUpdate.Status != "" {
+		events = append(events, newOrderEvent(orderToUpdate.Id, userId, entity.OrderEventTypeStatusChanged,
+			existingOrder.Status, orderToUpdate.Status, comment))
+	}
+	err = o.repo.UpdateById(ctx, orderToUpdate, existingOrder.Status, events)
 	if err != nil {
-		return nil, err
+		switch {
+		case errors.Is(err, repo.ErrOutOfStock):
+			return nil, ErrOutOfStock
+		case errors.Is(err, repo.ErrOrderNotFound):
+			return nil, ErrOrderNotFound
+		case errors.Is(err, repo.ErrOrderChanged):
+			return nil, ErrOrderChanged
+		default:
+			return nil, err
+		}
 	}
 	updatedOrder, err := o.repo.GetById(ctx, orderToUpdate.Id)
 	if err != nil {
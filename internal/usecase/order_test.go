@@ -0,0 +1,1003 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+	mock_repo "github.com/krijebr/printer-shop/internal/repo/mocks"
+	mock_usecase "github.com/krijebr/printer-shop/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder_UpdateById(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockOrder, ctx context.Context, order *entity.Order)
+
+	orderId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	existingOrder := func(status entity.OrderStatus) *entity.Order {
+		return &entity.Order{
+			Id:     orderId,
+			Status: status,
+		}
+	}
+	testTable := []struct {
+		name         string
+		inputOrder   *entity.Order
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			name: "OK new to paid",
+			inputOrder: &entity.Order{
+				Id:     orderId,
+				Status: entity.OrderStatusPaid,
+			},
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context, order *entity.Order) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusNew), nil)
+				s.EXPECT().UpdateById(ctx, order, entity.OrderStatusNew, gomock.Len(1)).
+					DoAndReturn(func(ctx context.Context, order *entity.Order, from entity.OrderStatus, events []entity.OrderEvent) error {
+						assert.Equal(t, entity.OrderEventTypeStatusChanged, events[0].Type)
+						assert.Equal(t, userId, *events[0].UserId)
+						assert.Equal(t, order.Status, *events[0].ToStatus)
+						return nil
+					})
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusPaid), nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "OK shipped to delivered",
+			inputOrder: &entity.Order{
+				Id:     orderId,
+				Status: entity.OrderStatusDelivered,
+			},
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context, order *entity.Order) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusShipped), nil)
+				s.EXPECT().UpdateById(ctx, order, entity.OrderStatusShipped, gomock.Len(1)).
+					DoAndReturn(func(ctx context.Context, order *entity.Order, from entity.OrderStatus, events []entity.OrderEvent) error {
+						assert.Equal(t, entity.OrderEventTypeStatusChanged, events[0].Type)
+						assert.Equal(t, userId, *events[0].UserId)
+						assert.Equal(t, order.Status, *events[0].ToStatus)
+						return nil
+					})
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusDelivered), nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "done back to new",
+			inputOrder: &entity.Order{
+				Id:     orderId,
+				Status: entity.OrderStatusNew,
+			},
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context, order *entity.Order) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusDone), nil)
+			},
+			expectedErr: ErrOrderStatusTransitionNotAllowed,
+		},
+		{
+			name: "new to shipped",
+			inputOrder: &entity.Order{
+				Id:     orderId,
+				Status: entity.OrderStatusShipped,
+			},
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context, order *entity.Order) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusNew), nil)
+			},
+			expectedErr: ErrOrderStatusTransitionNotAllowed,
+		},
+		{
+			name: "cancelled is final",
+			inputOrder: &entity.Order{
+				Id:     orderId,
+				Status: entity.OrderStatusPaid,
+			},
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context, order *entity.Order) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusCancelled), nil)
+			},
+			expectedErr: ErrOrderStatusTransitionNotAllowed,
+		},
+		{
+			name: "order not found",
+			inputOrder: &entity.Order{
+				Id:     orderId,
+				Status: entity.OrderStatusPaid,
+			},
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context, order *entity.Order) {
+				s.EXPECT().GetById(ctx, orderId).Return(nil, repo.ErrOrderNotFound)
+			},
+			expectedErr: ErrOrderNotFound,
+		},
+		{
+			name: "order expired meanwhile",
+			inputOrder: &entity.Order{
+				Id:     orderId,
+				Status: entity.OrderStatusPaid,
+			},
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context, order *entity.Order) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusNew), nil)
+				s.EXPECT().UpdateById(ctx, order, entity.OrderStatusNew, gomock.Len(1)).Return(repo.ErrOrderChanged)
+			},
+			expectedErr: ErrOrderChanged,
+		},
+		{
+			name: "update error",
+			inputOrder: &entity.Order{
+				Id:     orderId,
+				Status: entity.OrderStatusPaid,
+			},
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context, order *entity.Order) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusNew), nil)
+				s.EXPECT().UpdateById(ctx, order, entity.OrderStatusNew, gomock.Len(1)).Return(someErr)
+			},
+			expectedErr: someErr,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			orderRepo := mock_repo.NewMockOrder(c)
+			cartRepo := mock_repo.NewMockCart(c)
+			productRepo := mock_repo.NewMockProduct(c)
+			warehouseRepo := mock_repo.NewMockWarehouse(c)
+			eventRepo := mock_repo.NewMockOrderEvent(c)
+			exchangeRate := mock_usecase.NewMockExchangeRate(c)
+			testCase.mockBehavior(orderRepo, context.Background(), testCase.inputOrder)
+
+			orderUsecase := NewOrder(orderRepo, cartRepo, productRepo, warehouseRepo, eventRepo, mock_repo.NewMockPromoCode(c), mock_repo.NewMockPromotion(c), mock_repo.NewMockAddress(c), exchangeRate, entity.TaxSettings{}, nil, 0)
+
+			updatedOrder, err := orderUsecase.UpdateById(context.Background(), userId, testCase.inputOrder, "")
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				assert.Nil(t, updatedOrder)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.inputOrder.Status, updatedOrder.Status)
+			}
+		})
+	}
+}
+
+func TestCalculateOrderTotals(t *testing.T) {
+	printerId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	cartridgeId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	lines := func() []*entity.ProductInCart {
+		discount := entity.NewMoney(150000, entity.CurrencyRUB)
+		return []*entity.ProductInCart{
+			{
+				Product: &entity.Product{Id: printerId, Price: entity.NewMoney(1000000, entity.CurrencyRUB), Status: entity.ProductStatusPublished},
+				Count:   1,
+			},
+			{
+				Product:  &entity.Product{Id: cartridgeId, Price: entity.NewMoney(250000, entity.CurrencyRUB), Status: entity.ProductStatusPublished, TaxClass: "reduced"},
+				Count:    3,
+				Discount: &discount,
+			},
+		}
+	}
+	rates := map[string]float64{"standard": 20, "reduced": 10}
+	testTable := []struct {
+		name          string
+		tax           entity.TaxSettings
+		shipping      int64
+		expectedOrder *entity.Order
+	}{
+		{
+			name: "without tax",
+			expectedOrder: &entity.Order{
+				ItemsCount: 4,
+				Subtotal:   entity.NewMoney(1750000, entity.CurrencyRUB),
+				Discount:   entity.NewMoney(150000, entity.CurrencyRUB),
+				Tax:        entity.NewMoney(0, entity.CurrencyRUB),
+				Total:      entity.NewMoney(1600000, entity.CurrencyRUB),
+			},
+		},
+		{
+			name: "tax added to prices",
+			tax:  entity.TaxSettings{DefaultClass: "standard", Rates: rates},
+			expectedOrder: &entity.Order{
+				ItemsCount: 4,
+				Subtotal:   entity.NewMoney(1750000, entity.CurrencyRUB),
+				Discount:   entity.NewMoney(150000, entity.CurrencyRUB),
+				Tax:        entity.NewMoney(260000, entity.CurrencyRUB),
+				Total:      entity.NewMoney(1860000, entity.CurrencyRUB),
+			},
+		},
+		{
+			name: "tax included in prices",
+			tax:  entity.TaxSettings{PricesIncludeTax: true, DefaultClass: "standard", Rates: rates},
+			expectedOrder: &entity.Order{
+				ItemsCount:       4,
+				Subtotal:         entity.NewMoney(1750000, entity.CurrencyRUB),
+				Discount:         entity.NewMoney(150000, entity.CurrencyRUB),
+				Tax:              entity.NewMoney(221212, entity.CurrencyRUB),
+				PricesIncludeTax: true,
+				Total:            entity.NewMoney(1600000, entity.CurrencyRUB),
+			},
+		},
+		{
+			name:     "shipping added after tax",
+			tax:      entity.TaxSettings{DefaultClass: "standard", Rates: rates},
+			shipping: 50000,
+			expectedOrder: &entity.Order{
+				ItemsCount: 4,
+				Subtotal:   entity.NewMoney(1750000, entity.CurrencyRUB),
+				Discount:   entity.NewMoney(150000, entity.CurrencyRUB),
+				Tax:        entity.NewMoney(260000, entity.CurrencyRUB),
+				Shipping:   entity.NewMoney(50000, entity.CurrencyRUB),
+				Total:      entity.NewMoney(1910000, entity.CurrencyRUB),
+			},
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			order := &entity.Order{
+				Products: lines(),
+				Shipping: entity.Money{Amount: testCase.shipping},
+			}
+
+			calculateOrderTotals(order, entity.CurrencyRUB, testCase.tax)
+
+			assert.Equal(t, testCase.expectedOrder.ItemsCount, order.ItemsCount)
+			assert.Equal(t, testCase.expectedOrder.Subtotal, order.Subtotal)
+			assert.Equal(t, testCase.expectedOrder.Discount, order.Discount)
+			assert.Equal(t, testCase.expectedOrder.Tax, order.Tax)
+			assert.Equal(t, testCase.expectedOrder.PricesIncludeTax, order.PricesIncludeTax)
+			assert.Equal(t, testCase.expectedOrder.Total, order.Total)
+			assert.Equal(t, entity.NewMoney(testCase.shipping, entity.CurrencyRUB), order.Shipping)
+			assert.Equal(t, entity.NewMoney(1000000, entity.CurrencyRUB), order.Products[0].Total)
+			assert.Equal(t, entity.NewMoney(750000, entity.CurrencyRUB), order.Products[1].Total)
+		})
+	}
+}
+
+func TestOrder_UpdateByIdTotals(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockOrder, p *mock_repo.MockProduct, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, ctx context.Context)
+
+	orderId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	printerId := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	promoId := uuid.MustParse("00000000-0000-0000-0000-000000000004")
+	tax := entity.TaxSettings{DefaultClass: "standard", Rates: map[string]float64{"standard": 20}}
+	existingOrder := func(promoCodeId *uuid.UUID, pricesIncludeTax bool) *entity.Order {
+		return &entity.Order{
+			Id:               orderId,
+			UserId:           userId,
+			Status:           entity.OrderStatusNew,
+			Currency:         entity.CurrencyRUB,
+			ExchangeRate:     1,
+			PromoCodeId:      promoCodeId,
+			Discount:         entity.NewMoney(300000, entity.CurrencyRUB),
+			Tax:              entity.NewMoney(700000, entity.CurrencyRUB),
+			PricesIncludeTax: pricesIncludeTax,
+			Shipping:         entity.NewMoney(50000, entity.CurrencyRUB),
+		}
+	}
+	printer := func() *entity.Product {
+		return &entity.Product{Id: printerId, Price: entity.NewMoney(1000000, entity.CurrencyRUB), Status: entity.ProductStatusPublished}
+	}
+	testTable := []struct {
+		name          string
+		mockBehavior  mockBehavior
+		expectedOrder *entity.Order
+		expectedErr   error
+	}{
+		{
+			name: "OK promo code of order and included tax carried over",
+			mockBehavior: func(s *mock_repo.MockOrder, p *mock_repo.MockProduct, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, ctx context.Context) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(&promoId, true), nil)
+				p.EXPECT().GetById(ctx, printerId).Return(printer(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetById(ctx, promoId).Return(&entity.PromoCode{
+					Id:            promoId,
+					Code:          "SPRING",
+					DiscountType:  entity.DiscountTypeFixed,
+					DiscountValue: 100000,
+					Currency:      entity.CurrencyRUB,
+				}, nil)
+			},
+			expectedOrder: &entity.Order{
+				Subtotal:         entity.NewMoney(2000000, entity.CurrencyRUB),
+				Discount:         entity.NewMoney(100000, entity.CurrencyRUB),
+				Tax:              entity.NewMoney(316667, entity.CurrencyRUB),
+				PricesIncludeTax: true,
+				Shipping:         entity.NewMoney(50000, entity.CurrencyRUB),
+				Total:            entity.NewMoney(1950000, entity.CurrencyRUB),
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "OK tax added without promo code",
+			mockBehavior: func(s *mock_repo.MockOrder, p *mock_repo.MockProduct, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, ctx context.Context) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(nil, false), nil)
+				p.EXPECT().GetById(ctx, printerId).Return(printer(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+			},
+			expectedOrder: &entity.Order{
+				Subtotal: entity.NewMoney(2000000, entity.CurrencyRUB),
+				Discount: entity.NewMoney(0, entity.CurrencyRUB),
+				Tax:      entity.NewMoney(400000, entity.CurrencyRUB),
+				Shipping: entity.NewMoney(50000, entity.CurrencyRUB),
+				Total:    entity.NewMoney(2450000, entity.CurrencyRUB),
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "hidden product",
+			mockBehavior: func(s *mock_repo.MockOrder, p *mock_repo.MockProduct, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, ctx context.Context) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(nil, false), nil)
+				hidden := printer()
+				hidden.Status = entity.ProductStatusHidden
+				p.EXPECT().GetById(ctx, printerId).Return(hidden, nil)
+			},
+			expectedOrder: nil,
+			expectedErr:   ErrOrderHasUnavailableProducts,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			orderRepo := mock_repo.NewMockOrder(c)
+			productRepo := mock_repo.NewMockProduct(c)
+			warehouseRepo := mock_repo.NewMockWarehouse(c)
+			promoCodeRepo := mock_repo.NewMockPromoCode(c)
+			promotionRepo := mock_repo.NewMockPromotion(c)
+			testCase.mockBehavior(orderRepo, productRepo, promoCodeRepo, promotionRepo, ctx)
+
+			var savedOrder *entity.Order
+			if testCase.expectedErr == nil {
+				warehouseRepo.EXPECT().GetAll(ctx).Return([]*entity.Warehouse{}, nil)
+				warehouseRepo.EXPECT().GetStock(ctx, gomock.Any()).Return([]*entity.WarehouseStock{}, nil)
+				orderRepo.EXPECT().UpdateById(ctx, gomock.Any(), entity.OrderStatusNew, gomock.Len(1)).
+					DoAndReturn(func(ctx context.Context, order *entity.Order, from entity.OrderStatus, events []entity.OrderEvent) error {
+						assert.Equal(t, entity.OrderEventTypeUpdated, events[0].Type)
+						savedOrder = order
+						return nil
+					})
+				orderRepo.EXPECT().GetById(ctx, orderId).DoAndReturn(func(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
+					return savedOrder, nil
+				})
+			}
+
+			orderUsecase := NewOrder(orderRepo, mock_repo.NewMockCart(c), productRepo, warehouseRepo, mock_repo.NewMockOrderEvent(c),
+				promoCodeRepo, promotionRepo, mock_repo.NewMockAddress(c), mock_usecase.NewMockExchangeRate(c), tax, nil, 0)
+
+			updatedOrder, err := orderUsecase.UpdateById(ctx, userId, &entity.Order{
+				Id:       orderId,
+				Products: []*entity.ProductInCart{{Product: &entity.Product{Id: printerId}, Count: 2}},
+			}, "")
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				assert.Nil(t, updatedOrder)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, updatedOrder.ItemsCount)
+				assert.Equal(t, testCase.expectedOrder.Subtotal, updatedOrder.Subtotal)
+				assert.Equal(t, testCase.expectedOrder.Discount, updatedOrder.Discount)
+				assert.Equal(t, testCase.expectedOrder.Tax, updatedOrder.Tax)
+				assert.Equal(t, testCase.expectedOrder.PricesIncludeTax, updatedOrder.PricesIncludeTax)
+				assert.Equal(t, testCase.expectedOrder.Shipping, updatedOrder.Shipping)
+				assert.Equal(t, testCase.expectedOrder.Total, updatedOrder.Total)
+			}
+		})
+	}
+}
+
+func TestOrder_Create(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context)
+
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	addressId := uuid.MustParse("00000000-0000-0000-0000-000000000005")
+	address := func() *entity.Address {
+		return &entity.Address{
+			Id:         addressId,
+			UserId:     userId,
+			Recipient:  "Ivan Petrov",
+			Phone:      "+79991234567",
+			Country:    "Russia",
+			City:       "Moscow",
+			Street:     "Tverskaya 1, 10",
+			PostalCode: "125009",
+			IsDefault:  true,
+		}
+	}
+	shippingMethods := []*entity.ShippingMethod{
+		{
+			Code:       "pickup",
+			Calculator: entity.ShippingCalculatorFlat,
+			Currency:   entity.CurrencyRUB,
+			Rates:      []*entity.ShippingRate{{Price: 0}},
+		},
+		{
+			Code:       "courier",
+			Calculator: entity.ShippingCalculatorWeightTable,
+			Currency:   entity.CurrencyRUB,
+			Rates: []*entity.ShippingRate{{
+				Regions: []string{"Moscow"},
+				Weights: []*entity.ShippingWeightPrice{{MaxWeight: 10000, Price: 50000}, {MaxWeight: 30000, Price: 90000}},
+			}},
+		},
+	}
+	oldPrice := entity.NewMoney(4500000, entity.CurrencyRUB)
+	promo := func(minOrderAmount int64) *entity.PromoCode {
+		return &entity.PromoCode{
+			Id:             uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			Code:           "SPRING",
+			DiscountType:   entity.DiscountTypeFixed,
+			DiscountValue:  1000000,
+			MinOrderAmount: minOrderAmount,
+			Currency:       entity.CurrencyRUB,
+		}
+	}
+	productsInCart := func() []*entity.ProductInCart {
+		return []*entity.ProductInCart{
+			{
+				Product: &entity.Product{
+					Id:     uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+					Price:  entity.NewMoney(4999999, entity.CurrencyRUB),
+					Status: entity.ProductStatusPublished,
+					Weight: 9500,
+				},
+				Count: 2,
+			},
+		}
+	}
+	testTable := []struct {
+		name             string
+		inputCurrency    entity.Currency
+		shippingMethod   string
+		tax              entity.TaxSettings
+		mockBehavior     mockBehavior
+		expectedOrder    *entity.Order
+		createErr        error
+		expectedWarnings []entity.CartWarningType
+		expectedErr      error
+	}{
+		{
+			name:          "OK base currency",
+			inputCurrency: "",
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(nil, repo.ErrPromoCodeNotFound)
+				e.EXPECT().BaseCurrency().Return(entity.CurrencyRUB)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				a.EXPECT().GetById(ctx, addressId).Return(address(), nil)
+			},
+			expectedOrder: &entity.Order{
+				Currency:     entity.CurrencyRUB,
+				ExchangeRate: 1,
+				ItemsCount:   2,
+				Total:        entity.NewMoney(9999998, entity.CurrencyRUB),
+			},
+			expectedErr: nil,
+		},
+		{
+			name:          "OK converted to kzt",
+			inputCurrency: entity.CurrencyKZT,
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(nil, repo.ErrPromoCodeNotFound)
+				e.EXPECT().GetRate(ctx, entity.CurrencyKZT).Return(5.9, nil)
+				a.EXPECT().GetById(ctx, addressId).Return(address(), nil)
+			},
+			expectedOrder: &entity.Order{
+				Currency:     entity.CurrencyKZT,
+				ExchangeRate: 5.9,
+				ItemsCount:   2,
+				Total:        entity.NewMoney(58999988, entity.CurrencyKZT),
+			},
+			expectedErr: nil,
+		},
+		{
+			name:          "OK price changed is reported",
+			inputCurrency: "",
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				products := productsInCart()
+				products[0].PriceWhenAdded = &oldPrice
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(products, nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(nil, repo.ErrPromoCodeNotFound)
+				e.EXPECT().BaseCurrency().Return(entity.CurrencyRUB)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				a.EXPECT().GetById(ctx, addressId).Return(address(), nil)
+			},
+			expectedOrder: &entity.Order{
+				Currency:     entity.CurrencyRUB,
+				ExchangeRate: 1,
+				ItemsCount:   2,
+				Total:        entity.NewMoney(9999998, entity.CurrencyRUB),
+			},
+			expectedWarnings: []entity.CartWarningType{entity.CartWarningTypePriceChanged},
+			expectedErr:      nil,
+		},
+		{
+			name:          "OK promo code applied",
+			inputCurrency: entity.CurrencyRUB,
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(promo(5000000), nil)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				a.EXPECT().GetById(ctx, addressId).Return(address(), nil)
+				pc.EXPECT().DeleteCartPromoCode(ctx, userId).Return(nil)
+			},
+			expectedOrder: &entity.Order{
+				Currency:     entity.CurrencyRUB,
+				ExchangeRate: 1,
+				ItemsCount:   2,
+				Discount:     entity.NewMoney(1000000, entity.CurrencyRUB),
+				Total:        entity.NewMoney(8999998, entity.CurrencyRUB),
+			},
+			expectedErr: nil,
+		},
+		{
+			name:          "OK volume tier applied",
+			inputCurrency: entity.CurrencyRUB,
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{{
+					Id:       uuid.New(),
+					Name:     "2+ items",
+					Type:     entity.PromotionTypeVolumeTier,
+					Products: []*entity.PromotionProduct{{ProductId: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Count: 1}},
+					Tiers:    []*entity.PromotionTier{{MinCount: 2, Price: 4000000}},
+					Currency: entity.CurrencyRUB,
+				}}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(nil, repo.ErrPromoCodeNotFound)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				a.EXPECT().GetById(ctx, addressId).Return(address(), nil)
+			},
+			expectedOrder: &entity.Order{
+				Currency:     entity.CurrencyRUB,
+				ExchangeRate: 1,
+				ItemsCount:   2,
+				Discount:     entity.NewMoney(1999998, entity.CurrencyRUB),
+				Total:        entity.NewMoney(8000000, entity.CurrencyRUB),
+			},
+			expectedErr: nil,
+		},
+		{
+			name:          "OK tax added to prices",
+			inputCurrency: entity.CurrencyRUB,
+			tax:           entity.TaxSettings{DefaultClass: "standard", Rates: map[string]float64{"standard": 20, "reduced": 10}},
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(nil, repo.ErrPromoCodeNotFound)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				a.EXPECT().GetById(ctx, addressId).Return(address(), nil)
+			},
+			expectedOrder: &entity.Order{
+				Currency:     entity.CurrencyRUB,
+				ExchangeRate: 1,
+				ItemsCount:   2,
+				Tax:          entity.NewMoney(2000000, entity.CurrencyRUB),
+				Total:        entity.NewMoney(11999998, entity.CurrencyRUB),
+			},
+			expectedErr: nil,
+		},
+		{
+			name:          "OK tax included in prices after promo code",
+			inputCurrency: entity.CurrencyRUB,
+			tax:           entity.TaxSettings{PricesIncludeTax: true, DefaultClass: "standard", Rates: map[string]float64{"standard": 20}},
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(promo(0), nil)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				a.EXPECT().GetById(ctx, addressId).Return(address(), nil)
+				pc.EXPECT().DeleteCartPromoCode(ctx, userId).Return(nil)
+			},
+			expectedOrder: &entity.Order{
+				Currency:     entity.CurrencyRUB,
+				ExchangeRate: 1,
+				ItemsCount:   2,
+				Discount:     entity.NewMoney(1000000, entity.CurrencyRUB),
+				Tax:          entity.NewMoney(1500000, entity.CurrencyRUB),
+				Total:        entity.NewMoney(8999998, entity.CurrencyRUB),
+			},
+			expectedErr: nil,
+		},
+		{
+			name:           "OK courier shipping added to total",
+			inputCurrency:  entity.CurrencyKZT,
+			shippingMethod: "courier",
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(nil, repo.ErrPromoCodeNotFound)
+				e.EXPECT().GetRate(ctx, entity.CurrencyKZT).Return(5.9, nil)
+				a.EXPECT().GetById(ctx, addressId).Return(address(), nil)
+			},
+			expectedOrder: &entity.Order{
+				Currency:     entity.CurrencyKZT,
+				ExchangeRate: 5.9,
+				ItemsCount:   2,
+				Shipping:     entity.NewMoney(531000, entity.CurrencyKZT),
+				Total:        entity.NewMoney(59530988, entity.CurrencyKZT),
+			},
+			expectedErr: nil,
+		},
+		{
+			name:           "unknown shipping method",
+			inputCurrency:  entity.CurrencyRUB,
+			shippingMethod: "drone",
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(nil, repo.ErrPromoCodeNotFound)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				a.EXPECT().GetById(ctx, addressId).Return(address(), nil)
+			},
+			expectedOrder: nil,
+			expectedErr:   ErrShippingMethodNotFound,
+		},
+		{
+			name:           "courier doesn't ship to region",
+			inputCurrency:  entity.CurrencyRUB,
+			shippingMethod: "courier",
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(nil, repo.ErrPromoCodeNotFound)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				farAddress := address()
+				farAddress.City = "Vladivostok"
+				a.EXPECT().GetById(ctx, addressId).Return(farAddress, nil)
+				e.EXPECT().BaseCurrency().Return(entity.CurrencyRUB)
+			},
+			expectedOrder: nil,
+			expectedErr:   ErrShippingNotAvailable,
+		},
+		{
+			name:          "promo code minimum not reached",
+			inputCurrency: entity.CurrencyRUB,
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(promo(20000000), nil)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				a.EXPECT().GetById(ctx, addressId).Return(address(), nil)
+			},
+			expectedOrder: nil,
+			expectedErr:   ErrPromoCodeMinOrderAmount,
+		},
+		{
+			name:          "promo code used up by a concurrent order",
+			inputCurrency: entity.CurrencyRUB,
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(promo(5000000), nil)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				a.EXPECT().GetById(ctx, addressId).Return(address(), nil)
+			},
+			createErr:     repo.ErrPromoCodeUsageLimitReached,
+			expectedOrder: nil,
+			expectedErr:   ErrPromoCodeUsageLimitReached,
+		},
+		{
+			name:          "hidden product in cart",
+			inputCurrency: "",
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				products := productsInCart()
+				products[0].Product.Status = entity.ProductStatusHidden
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(products, nil)
+			},
+			expectedOrder: nil,
+			expectedErr:   ErrCartHasUnavailableProducts,
+		},
+		{
+			name:          "not enough stock",
+			inputCurrency: "",
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				products := productsInCart()
+				stock := 1
+				products[0].Product.Stock = &stock
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(products, nil)
+			},
+			expectedOrder: nil,
+			expectedErr:   ErrOutOfStock,
+		},
+		{
+			name:          "address of another user",
+			inputCurrency: entity.CurrencyRUB,
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(nil, repo.ErrPromoCodeNotFound)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				otherAddress := address()
+				otherAddress.UserId = uuid.New()
+				a.EXPECT().GetById(ctx, addressId).Return(otherAddress, nil)
+			},
+			expectedOrder: nil,
+			expectedErr:   ErrAddressNotFound,
+		},
+		{
+			name:          "exchange rate not set",
+			inputCurrency: entity.CurrencyBYN,
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				cr.EXPECT().GetAllProducts(ctx, userId).Return(productsInCart(), nil)
+				pm.EXPECT().GetActive(ctx, gomock.Any()).Return([]*entity.Promotion{}, nil)
+				pc.EXPECT().GetByCartUserId(ctx, userId).Return(nil, repo.ErrPromoCodeNotFound)
+				e.EXPECT().GetRate(ctx, entity.CurrencyBYN).Return(0.0, ErrExchangeRateNotFound)
+			},
+			expectedOrder: nil,
+			expectedErr:   ErrExchangeRateNotFound,
+		},
+		{
+			name:          "cart is empty",
+			inputCurrency: "",
+			mockBehavior: func(s *mock_repo.MockOrder, cr *mock_repo.MockCart, pc *mock_repo.MockPromoCode, pm *mock_repo.MockPromotion, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				cr.EXPECT().GetAllProducts(ctx, userId).Return([]*entity.ProductInCart{}, nil)
+			},
+			expectedOrder: nil,
+			expectedErr:   ErrCartIsEmpty,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			orderRepo := mock_repo.NewMockOrder(c)
+			cartRepo := mock_repo.NewMockCart(c)
+			productRepo := mock_repo.NewMockProduct(c)
+			warehouseRepo := mock_repo.NewMockWarehouse(c)
+			eventRepo := mock_repo.NewMockOrderEvent(c)
+			promoCodeRepo := mock_repo.NewMockPromoCode(c)
+			promotionRepo := mock_repo.NewMockPromotion(c)
+			addressRepo := mock_repo.NewMockAddress(c)
+			exchangeRate := mock_usecase.NewMockExchangeRate(c)
+			testCase.mockBehavior(orderRepo, cartRepo, promoCodeRepo, promotionRepo, addressRepo, exchangeRate, ctx)
+
+			var createdOrder *entity.Order
+			if testCase.expectedErr == nil || testCase.createErr != nil {
+				exchangeRate.EXPECT().BaseCurrency().Return(entity.CurrencyRUB)
+				warehouseRepo.EXPECT().GetAll(ctx).Return([]*entity.Warehouse{}, nil)
+				warehouseRepo.EXPECT().GetStock(ctx, gomock.Any()).Return([]*entity.WarehouseStock{}, nil)
+			}
+			if testCase.createErr != nil {
+				orderRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Return(testCase.createErr)
+			} else if testCase.expectedErr == nil {
+				orderRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, order *entity.Order, event entity.OrderEvent) error {
+					assert.Equal(t, entity.OrderEventTypeCreated, event.Type)
+					assert.Equal(t, order.Id, event.OrderId)
+					createdOrder = order
+					return nil
+				})
+				cartRepo.EXPECT().ClearCart(ctx, userId).Return(nil)
+				orderRepo.EXPECT().GetById(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
+					return createdOrder, nil
+				})
+			}
+
+			orderUsecase := NewOrder(orderRepo, cartRepo, productRepo, warehouseRepo, eventRepo, promoCodeRepo, promotionRepo, addressRepo, exchangeRate, testCase.tax,
+				shippingMethods, 0)
+
+			shippingMethod := testCase.shippingMethod
+			if shippingMethod == "" {
+				shippingMethod = "pickup"
+			}
+			order, err := orderUsecase.Create(ctx, userId, testCase.inputCurrency, addressId, nil, shippingMethod)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				assert.Nil(t, order)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedOrder.Currency, order.Currency)
+				assert.Equal(t, testCase.expectedOrder.ExchangeRate, order.ExchangeRate)
+				assert.Equal(t, testCase.expectedOrder.ItemsCount, order.ItemsCount)
+				assert.Equal(t, testCase.expectedOrder.Discount.Amount, order.Discount.Amount)
+				assert.Equal(t, testCase.expectedOrder.Tax.Amount, order.Tax.Amount)
+				assert.Equal(t, shippingMethod, order.ShippingMethod)
+				assert.Equal(t, testCase.expectedOrder.Shipping.Amount, order.Shipping.Amount)
+				assert.Equal(t, testCase.tax.PricesIncludeTax, order.PricesIncludeTax)
+				assert.Equal(t, testCase.expectedOrder.Total, order.Total)
+				assert.Equal(t, address().ToOrderAddress(), order.ShippingAddress)
+				assert.Nil(t, order.BillingAddress)
+				assert.Equal(t, testCase.expectedOrder.Currency, order.Products[0].Product.Price.Currency)
+				var warnings []entity.CartWarningType
+				for _, w := range order.Products[0].Warnings {
+					warnings = append(warnings, w.Type)
+				}
+				assert.Equal(t, testCase.expectedWarnings, warnings)
+			}
+		})
+	}
+}
+
+func TestOrder_ReleaseExpired(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockOrder, ctx context.Context)
+
+	expiredId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	paidId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	testTable := []struct {
+		name             string
+		mockBehavior     mockBehavior
+		expectedReleased int
+		expectedErr      error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context) {
+				s.EXPECT().GetExpiredReservations(ctx, gomock.Any()).Return([]uuid.UUID{expiredId, paidId}, nil)
+				s.EXPECT().ExpireReservation(ctx, expiredId, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, now time.Time, event entity.OrderEvent) (bool, error) {
+						assert.Equal(t, expiredId, event.OrderId)
+						assert.Nil(t, event.UserId)
+						assert.Equal(t, entity.OrderStatusCancelled, *event.ToStatus)
+						return true, nil
+					})
+				s.EXPECT().ExpireReservation(ctx, paidId, gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			expectedReleased: 1,
+			expectedErr:      nil,
+		},
+		{
+			name: "nothing expired",
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context) {
+				s.EXPECT().GetExpiredReservations(ctx, gomock.Any()).Return([]uuid.UUID{}, nil)
+			},
+			expectedReleased: 0,
+			expectedErr:      nil,
+		},
+		{
+			name: "expire error",
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context) {
+				s.EXPECT().GetExpiredReservations(ctx, gomock.Any()).Return([]uuid.UUID{expiredId}, nil)
+				s.EXPECT().ExpireReservation(ctx, expiredId, gomock.Any(), gomock.Any()).Return(false, someErr)
+			},
+			expectedReleased: 0,
+			expectedErr:      someErr,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			orderRepo := mock_repo.NewMockOrder(c)
+			eventRepo := mock_repo.NewMockOrderEvent(c)
+			testCase.mockBehavior(orderRepo, ctx)
+
+			orderUsecase := NewOrder(orderRepo, mock_repo.NewMockCart(c), mock_repo.NewMockProduct(c), mock_repo.NewMockWarehouse(c),
+				eventRepo, mock_repo.NewMockPromoCode(c), mock_repo.NewMockPromotion(c), mock_repo.NewMockAddress(c), mock_usecase.NewMockExchangeRate(c), entity.TaxSettings{},
+				nil, 30*time.Minute)
+
+			released, err := orderUsecase.ReleaseExpired(ctx)
+
+			assert.Equal(t, testCase.expectedReleased, released)
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOrder_Cancel(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockOrder, ctx context.Context)
+
+	orderId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	existingOrder := func(status entity.OrderStatus) *entity.Order {
+		return &entity.Order{
+			Id:     orderId,
+			UserId: userId,
+			Status: status,
+		}
+	}
+	testTable := []struct {
+		name         string
+		inputUserId  uuid.UUID
+		isAdmin      bool
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			name:        "OK new to cancelled",
+			inputUserId: userId,
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusNew), nil)
+				s.EXPECT().Cancel(ctx, orderId, gomock.AssignableToTypeOf(entity.OrderEvent{})).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, event entity.OrderEvent) (bool, error) {
+						assert.Equal(t, entity.OrderEventTypeStatusChanged, event.Type)
+						assert.Equal(t, userId, *event.UserId)
+						assert.Equal(t, entity.OrderStatusNew, *event.FromStatus)
+						assert.Equal(t, entity.OrderStatusCancelled, *event.ToStatus)
+						return true, nil
+					})
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusCancelled), nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "OK admin cancels order of customer",
+			inputUserId: uuid.New(),
+			isAdmin:     true,
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusNew), nil)
+				s.EXPECT().Cancel(ctx, orderId, gomock.Any()).Return(true, nil)
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusCancelled), nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "paid order",
+			inputUserId: userId,
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusPaid), nil)
+			},
+			expectedErr: ErrOrderCantBeCancelled,
+		},
+		{
+			name:        "paid while being cancelled",
+			inputUserId: userId,
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusNew), nil)
+				s.EXPECT().Cancel(ctx, orderId, gomock.Any()).Return(false, nil)
+			},
+			expectedErr: ErrOrderCantBeCancelled,
+		},
+		{
+			name:        "order of another user",
+			inputUserId: uuid.New(),
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context) {
+				s.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusNew), nil)
+			},
+			expectedErr: ErrOrderOfAnotherUser,
+		},
+		{
+			name:        "order not found",
+			inputUserId: userId,
+			mockBehavior: func(s *mock_repo.MockOrder, ctx context.Context) {
+				s.EXPECT().GetById(ctx, orderId).Return(nil, repo.ErrOrderNotFound)
+			},
+			expectedErr: ErrOrderNotFound,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			orderRepo := mock_repo.NewMockOrder(c)
+			testCase.mockBehavior(orderRepo, ctx)
+
+			orderUsecase := NewOrder(orderRepo, mock_repo.NewMockCart(c), mock_repo.NewMockProduct(c), mock_repo.NewMockWarehouse(c),
+				mock_repo.NewMockOrderEvent(c), mock_repo.NewMockPromoCode(c), mock_repo.NewMockPromotion(c), mock_repo.NewMockAddress(c),
+				mock_usecase.NewMockExchangeRate(c), entity.TaxSettings{}, nil, 0)
+
+			cancelledOrder, err := orderUsecase.Cancel(ctx, testCase.inputUserId, orderId, "changed my mind", testCase.isAdmin)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				assert.Nil(t, cancelledOrder)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.OrderStatusCancelled, cancelledOrder.Status)
+			}
+		})
+	}
+}
@@ -22,8 +22,17 @@ func NewProducer(r repo.Producer, p repo.Product) Producer {
 	}
 }
 
-func (p *producer) GetAll(ctx context.Context) ([]*entity.Producer, error) {
-	return p.repo.GetAll(ctx)
+func (p *producer) GetAll(ctx context.Context, pagination entity.Pagination) (*entity.ProducerPage, error) {
+	page, err := p.repo.GetPage(ctx, pagination)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrInvalidCursor):
+			return nil, ErrInvalidCursor
+		default:
+			return nil, err
+		}
+	}
+	return page, nil
 }
 
 func (p *producer) GetById(ctx context.Context, id uuid.UUID) (*entity.Producer, error) {
@@ -13,24 +13,74 @@ import (
 type product struct {
 	repo         repo.Product
 	repoProducer repo.Producer
+	repoCategory repo.Category
 	repoCart     repo.Cart
 	repoOrder    repo.Order
+	exchangeRate ExchangeRate
+	tax          entity.TaxSettings
 }
 
-func NewProduct(r repo.Product, p repo.Producer, c repo.Cart, o repo.Order) Product {
+func NewProduct(r repo.Product, p repo.Producer, cat repo.Category, c repo.Cart, o repo.Order, e ExchangeRate, tax entity.TaxSettings) Product {
 	return &product{
 		repo:         r,
 		repoProducer: p,
+		repoCategory: cat,
 		repoCart:     c,
 		repoOrder:    o,
+		exchangeRate: e,
+		tax:          tax,
 	}
 }
 
-func (p *product) GetAll(ctx context.Context, filter *entity.ProductFilter) ([]*entity.Product, error) {
-	return p.repo.GetAll(ctx, filter)
+func (p *product) checkCategory(ctx context.Context, category *entity.Category) error {
+	if category == nil {
+		return nil
+	}
+	_, err := p.repoCategory.GetById(ctx, category.Id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrCategoryNotFound):
+			return ErrCategoryNotFound
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// convertPrices converts prices stored in the base currency to the requested one.
+func (p *product) convertPrices(ctx context.Context, products []*entity.Product, currency entity.Currency) error {
+	if currency == "" {
+		return nil
+	}
+	rate, err := p.exchangeRate.GetRate(ctx, currency)
+	if err != nil {
+		return err
+	}
+	for _, pr := range products {
+		pr.Price = pr.Price.Convert(currency, rate)
+	}
+	return nil
+}
+
+func (p *product) GetAll(ctx context.Context, filter *entity.ProductFilter, pagination entity.Pagination, currency entity.Currency) (*entity.ProductPage, error) {
+	page, err := p.repo.GetPage(ctx, filter, pagination)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrInvalidCursor):
+			return nil, ErrInvalidCursor
+		default:
+			return nil, err
+		}
+	}
+	err = p.convertPrices(ctx, page.Items, currency)
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
 }
 
-func (p *product) GetById(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+func (p *product) GetById(ctx context.Context, id uuid.UUID, currency entity.Currency) (*entity.Product, error) {
 	receivedProduct, err := p.repo.GetById(ctx, id)
 	if err != nil {
 		switch {
@@ -40,6 +90,10 @@ func (p *product) GetById(ctx context.Context, id uuid.UUID) (*entity.Product, e
 			return nil, err
 		}
 	}
+	err = p.convertPrices(ctx, []*entity.Product{receivedProduct}, currency)
+	if err != nil {
+		return nil, err
+	}
 	return receivedProduct, nil
 }
 
@@ -53,11 +107,29 @@ func (p *product) Create(ctx context.Context, productToCreate entity.Product) (*
 			return nil, err
 		}
 	}
+	err = p.checkCategory(ctx, productToCreate.Category)
+	if err != nil {
+		return nil, err
+	}
+	if productToCreate.Price.Currency == "" {
+		productToCreate.Price.Currency = p.exchangeRate.BaseCurrency()
+	}
+	if productToCreate.Price.Currency != p.exchangeRate.BaseCurrency() {
+		return nil, ErrUnsupportedCurrency
+	}
+	if productToCreate.TaxClass != "" && !p.tax.HasClass(productToCreate.TaxClass) {
+		return nil, ErrTaxClassNotFound
+	}
 	productToCreate.Id = uuid.New()
 	productToCreate.CreatedAt = time.Now()
 	err = p.repo.Create(ctx, productToCreate)
 	if err != nil {
-		return nil, err
+		switch {
+		case errors.Is(err, repo.ErrNoWarehouse):
+			return nil, ErrNoWarehouse
+		default:
+			return nil, err
+		}
 	}
 	newProduct, err := p.repo.GetById(ctx, productToCreate.Id)
 	if err != nil {
@@ -76,6 +148,17 @@ func (p *product) Update(ctx context.Context, productToUpdate entity.Product) (*
 			return nil, err
 		}
 	}
+	if productToUpdate.Price.Amount != 0 {
+		if productToUpdate.Price.Currency == "" {
+			productToUpdate.Price.Currency = p.exchangeRate.BaseCurrency()
+		}
+		if productToUpdate.Price.Currency != p.exchangeRate.BaseCurrency() {
+			return nil, ErrUnsupportedCurrency
+		}
+	}
+	if productToUpdate.TaxClass != "" && !p.tax.HasClass(productToUpdate.TaxClass) {
+		return nil, ErrTaxClassNotFound
+	}
 	if productToUpdate.Producer.Id != uuid.Nil {
 		_, err = p.repoProducer.GetById(ctx, productToUpdate.Producer.Id)
 		if err != nil {
@@ -87,6 +170,10 @@ func (p *product) Update(ctx context.Context, productToUpdate entity.Product) (*
 			}
 		}
 	}
+	err = p.checkCategory(ctx, productToUpdate.Category)
+	if err != nil {
+		return nil, err
+	}
 	err = p.repo.Update(ctx, productToUpdate)
 	if err != nil {
 		return nil, err
@@ -128,3 +215,56 @@ func (p *product) DeleteById(ctx context.Context, id uuid.UUID) error {
 	}
 	return nil
 }
+
+func (p *product) GetCompatible(ctx context.Context, id uuid.UUID, filter *entity.ProductFilter, currency entity.Currency) ([]*entity.Product, error) {
+	_, err := p.GetById(ctx, id, "")
+	if err != nil {
+		return nil, err
+	}
+	products, err := p.repo.GetCompatible(ctx, id, filter)
+	if err != nil {
+		return nil, err
+	}
+	err = p.convertPrices(ctx, products, currency)
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (p *product) GetByName(ctx context.Context, name string) ([]*entity.Product, error) {
+	return p.repo.GetByName(ctx, name)
+}
+
+func (p *product) AddCompatible(ctx context.Context, id uuid.UUID, compatibleId uuid.UUID) error {
+	if id == compatibleId {
+		return ErrProductCompatibleWithItself
+	}
+	_, err := p.GetById(ctx, id, "")
+	if err != nil {
+		return err
+	}
+	_, err = p.repo.GetById(ctx, compatibleId)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrProductNotFound):
+			return ErrCompatibleProductNotFound
+		default:
+			return err
+		}
+	}
+	return p.repo.AddCompatible(ctx, id, compatibleId)
+}
+
+func (p *product) DeleteCompatible(ctx context.Context, id uuid.UUID, compatibleId uuid.UUID) error {
+	err := p.repo.DeleteCompatible(ctx, id, compatibleId)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrCompatibilityNotFound):
+			return ErrCompatibilityNotFound
+		default:
+			return err
+		}
+	}
+	return nil
+}
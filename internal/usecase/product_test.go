@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+	mock_repo "github.com/krijebr/printer-shop/internal/repo/mocks"
+	mock_usecase "github.com/krijebr/printer-shop/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProduct_AddCompatible(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockProduct, ctx context.Context, id, compatibleId uuid.UUID)
+
+	printerId := uuid.MustParse("00000000-0000-0000-0000-000000000021")
+	tonerId := uuid.MustParse("00000000-0000-0000-0000-000000000022")
+	testTable := []struct {
+		name         string
+		id           uuid.UUID
+		compatibleId uuid.UUID
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			name:         "OK",
+			id:           printerId,
+			compatibleId: tonerId,
+			mockBehavior: func(s *mock_repo.MockProduct, ctx context.Context, id, compatibleId uuid.UUID) {
+				s.EXPECT().GetById(ctx, id).Return(&entity.Product{Id: id}, nil)
+				s.EXPECT().GetById(ctx, compatibleId).Return(&entity.Product{Id: compatibleId}, nil)
+				s.EXPECT().AddCompatible(ctx, id, compatibleId).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:         "compatible with itself",
+			id:           printerId,
+			compatibleId: printerId,
+			mockBehavior: func(s *mock_repo.MockProduct, ctx context.Context, id, compatibleId uuid.UUID) {},
+			expectedErr:  ErrProductCompatibleWithItself,
+		},
+		{
+			name:         "product not found",
+			id:           printerId,
+			compatibleId: tonerId,
+			mockBehavior: func(s *mock_repo.MockProduct, ctx context.Context, id, compatibleId uuid.UUID) {
+				s.EXPECT().GetById(ctx, id).Return(nil, repo.ErrProductNotFound)
+			},
+			expectedErr: ErrProductNotFound,
+		},
+		{
+			name:         "compatible product not found",
+			id:           printerId,
+			compatibleId: tonerId,
+			mockBehavior: func(s *mock_repo.MockProduct, ctx context.Context, id, compatibleId uuid.UUID) {
+				s.EXPECT().GetById(ctx, id).Return(&entity.Product{Id: id}, nil)
+				s.EXPECT().GetById(ctx, compatibleId).Return(nil, repo.ErrProductNotFound)
+			},
+			expectedErr: ErrCompatibleProductNotFound,
+		},
+		{
+			name:         "repo error",
+			id:           printerId,
+			compatibleId: tonerId,
+			mockBehavior: func(s *mock_repo.MockProduct, ctx context.Context, id, compatibleId uuid.UUID) {
+				s.EXPECT().GetById(ctx, id).Return(&entity.Product{Id: id}, nil)
+				s.EXPECT().GetById(ctx, compatibleId).Return(&entity.Product{Id: compatibleId}, nil)
+				s.EXPECT().AddCompatible(ctx, id, compatibleId).Return(someErr)
+			},
+			expectedErr: someErr,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			productRepo := mock_repo.NewMockProduct(c)
+			testCase.mockBehavior(productRepo, context.Background(), testCase.id, testCase.compatibleId)
+
+			productUsecase := NewProduct(productRepo, mock_repo.NewMockProducer(c), mock_repo.NewMockCategory(c),
+				mock_repo.NewMockCart(c), mock_repo.NewMockOrder(c), mock_usecase.NewMockExchangeRate(c), entity.TaxSettings{})
+
+			err := productUsecase.AddCompatible(context.Background(), testCase.id, testCase.compatibleId)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+)
+
+type promoCode struct {
+	repo         repo.PromoCode
+	repoProduct  repo.Product
+	repoProducer repo.Producer
+	repoCategory repo.Category
+	exchangeRate ExchangeRate
+}
+
+func NewPromoCode(r repo.PromoCode, p repo.Product, pr repo.Producer, c repo.Category, e ExchangeRate) PromoCode {
+	return &promoCode{
+		repo:         r,
+		repoProduct:  p,
+		repoProducer: pr,
+		repoCategory: c,
+		exchangeRate: e,
+	}
+}
+
+// normalizePromoCode makes codes case insensitive, they are stored in upper case.
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// isPromoCodeError reports whether err explains why a promo code can't be applied to a cart.
+func isPromoCodeError(err error) bool {
+	return errors.Is(err, ErrPromoCodeNotActive) || errors.Is(err, ErrPromoCodeUsageLimitReached) ||
+		errors.Is(err, ErrPromoCodeNotApplicable) || errors.Is(err, ErrPromoCodeMinOrderAmount)
+}
+
+// checkPromoCode checks that the promo code is active at now and the user hasn't run out of its uses.
+func checkPromoCode(ctx context.Context, r repo.PromoCode, promo *entity.PromoCode, userId uuid.UUID, now time.Time) error {
+	if promo.StartsAt != nil && now.Before(*promo.StartsAt) || promo.EndsAt != nil && !now.Before(*promo.EndsAt) {
+		return ErrPromoCodeNotActive
+	}
+	if promo.UsageLimit == nil && promo.UsageLimitPerUser == nil {
+		return nil
+	}
+	total, byUser, err := r.GetUsage(ctx, promo.Id, userId)
+	if err != nil {
+		return err
+	}
+	if promo.UsageLimit != nil && total >= *promo.UsageLimit ||
+		promo.UsageLimitPerUser != nil && byUser >= *promo.UsageLimitPerUser {
+		return ErrPromoCodeUsageLimitReached
+	}
+	return nil
+}
+
+// cartPromoCode is a promo code applied to the lines of a cart or an order.
+type cartPromoCode struct {
+	promoCode *entity.PromoCode
+	// productIds are the products in the code scopes, nil when the code applies to all products.
+	productIds map[uuid.UUID]bool
+	// err explains why the code gives no discount.
+	err error
+}
+
+// newCartPromoCode finds which of the lines the promo code applies to.
+func newCartPromoCode(ctx context.Context, r repo.PromoCode, promo *entity.PromoCode, lines []*entity.ProductInCart) (*cartPromoCode, error) {
+	applied := &cartPromoCode{promoCode: promo}
+	if len(promo.Scopes) == 0 {
+		return applied, nil
+	}
+	ids := make([]uuid.UUID, 0, len(lines))
+	for _, line := range lines {
+		ids = append(ids, line.Product.Id)
+	}
+	applicable, err := r.GetApplicableProductIds(ctx, promo.Id, ids)
+	if err != nil {
+		return nil, err
+	}
+	applied.productIds = make(map[uuid.UUID]bool, len(applicable))
+	for _, id := range applicable {
+		applied.productIds[id] = true
+	}
+	return applied, nil
+}
+
+// loadCartPromoCode returns the promo code applied to the cart of the user, or nil when
+// there is none. The code is checked for the user, the reason it can't be used is kept in err.
+func loadCartPromoCode(ctx context.Context, r repo.PromoCode, userId uuid.UUID, lines []*entity.ProductInCart) (*cartPromoCode, error) {
+	promo, err := r.GetByCartUserId(ctx, userId)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrPromoCodeNotFound):
+			return nil, nil
+		default:
+			return nil, err
+		}
+	}
+	applied, err := newCartPromoCode(ctx, r, promo, lines)
+	if err != nil {
+		return nil, err
+	}
+	err = checkPromoCode(ctx, r, promo, userId, time.Now())
+	if err != nil {
+		if !isPromoCodeError(err) {
+			return nil, err
+		}
+		applied.err = err
+	}
+	return applied, nil
+}
+
+// apply adds the discount of the promo code to the lines it applies to, line prices must be
+// in currency already. The code discounts what is left to pay after the promotions, so the
+// minimum order amount is checked against it too. Lines of hidden products aren't discounted.
+// The discount is split between the lines in proportion to what is left to pay for them.
+func (p *cartPromoCode) apply(lines []*entity.ProductInCart, currency entity.Currency, rate float64) {
+	if p.err != nil {
+		return
+	}
+	subtotal := entity.NewMoney(0, currency)
+	applicableTotal := entity.NewMoney(0, currency)
+	applicable := []*entity.ProductInCart{}
+	for _, line := range lines {
+		if line.Product.Status == entity.ProductStatusHidden {
+			continue
+		}
+		lineTotal := cartLineNet(line)
+		subtotal = subtotal.Add(lineTotal)
+		if p.productIds == nil || p.productIds[line.Product.Id] {
+			applicable = append(applicable, line)
+			applicableTotal = applicableTotal.Add(lineTotal)
+		}
+	}
+	if len(applicable) == 0 || applicableTotal.Amount == 0 {
+		p.err = ErrPromoCodeNotApplicable
+		return
+	}
+	minOrderAmount := entity.NewMoney(p.promoCode.MinOrderAmount, p.promoCode.Currency).Convert(currency, rate)
+	if subtotal.Amount < minOrderAmount.Amount {
+		p.err = ErrPromoCodeMinOrderAmount
+		return
+	}
+	var discount int64
+	switch p.promoCode.DiscountType {
+	case entity.DiscountTypePercentage:
+		discount = int64(math.Round(float64(applicableTotal.Amount) * float64(p.promoCode.DiscountValue) / 100))
+	case entity.DiscountTypeFixed:
+		discount = entity.NewMoney(p.promoCode.DiscountValue, p.promoCode.Currency).Convert(currency, rate).Amount
+	}
+	discount = min(discount, applicableTotal.Amount)
+	left := discount
+	for i, line := range applicable {
+		share := left
+		if i != len(applicable)-1 {
+			share = discount * cartLineNet(line).Amount / applicableTotal.Amount
+		}
+		left -= share
+		lineDiscount := entity.NewMoney(share, currency)
+		if line.Discount != nil {
+			lineDiscount = line.Discount.Add(lineDiscount)
+		}
+		line.Discount = &lineDiscount
+	}
+}
+
+// cartLineNet is what is left to pay for the line after its discount.
+func cartLineNet(line *entity.ProductInCart) entity.Money {
+	lineTotal := line.Product.Price.Mul(line.Count)
+	if line.Discount == nil {
+		return lineTotal
+	}
+	return lineTotal.Sub(*line.Discount)
+}
+
+func (p *promoCode) GetAll(ctx context.Context) ([]*entity.PromoCode, error) {
+	return p.repo.GetAll(ctx)
+}
+
+func (p *promoCode) GetById(ctx context.Context, id uuid.UUID) (*entity.PromoCode, error) {
+	receivedPromoCode, err := p.repo.GetById(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrPromoCodeNotFound):
+			return nil, ErrPromoCodeNotFound
+		default:
+			return nil, err
+		}
+	}
+	return receivedPromoCode, nil
+}
+
+// check validates the currency and the scopes of the promo code and that its code is unique.
+func (p *promoCode) check(ctx context.Context, promo *entity.PromoCode) error {
+	if promo.Currency == "" {
+		promo.Currency = p.exchangeRate.BaseCurrency()
+	}
+	if promo.Currency != p.exchangeRate.BaseCurrency() {
+		return ErrUnsupportedCurrency
+	}
+	promo.Code = normalizePromoCode(promo.Code)
+	exists, err := p.repo.CheckIfCodeExists(ctx, promo.Code, promo.Id)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrPromoCodeExists
+	}
+	for _, scope := range promo.Scopes {
+		switch scope.Type {
+		case entity.PromoCodeScopeProduct:
+			_, err = p.repoProduct.GetById(ctx, scope.Id)
+			if errors.Is(err, repo.ErrProductNotFound) {
+				return ErrProductNotFound
+			}
+		case entity.PromoCodeScopeProducer:
+			_, err = p.repoProducer.GetById(ctx, scope.Id)
+			if errors.Is(err, repo.ErrProducerNotFound) {
+				return ErrProducerNotFound
+			}
+		case entity.PromoCodeScopeCategory:
+			_, err = p.repoCategory.GetById(ctx, scope.Id)
+			if errors.Is(err, repo.ErrCategoryNotFound) {
+				return ErrCategoryNotFound
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *promoCode) Create(ctx context.Context, promoCodeToCreate entity.PromoCode) (*entity.PromoCode, error) {
+	promoCodeToCreate.Id = uuid.New()
+	promoCodeToCreate.CreatedAt = time.Now()
+	err := p.check(ctx, &promoCodeToCreate)
+	if err != nil {
+		return nil, err
+	}
+	err = p.repo.Create(ctx, promoCodeToCreate)
+	if err != nil {
+		return nil, err
+	}
+	newPromoCode, err := p.repo.GetById(ctx, promoCodeToCreate.Id)
+	if err != nil {
+		return nil, err
+	}
+	return newPromoCode, nil
+}
+
+// Update replaces the promo code. Orders placed with it keep their discounts.
+func (p *promoCode) Update(ctx context.Context, promoCodeToUpdate entity.PromoCode) (*entity.PromoCode, error) {
+	_, err := p.GetById(ctx, promoCodeToUpdate.Id)
+	if err != nil {
+		return nil, err
+	}
+	err = p.check(ctx, &promoCodeToUpdate)
+	if err != nil {
+		return nil, err
+	}
+	err = p.repo.Update(ctx, promoCodeToUpdate)
+	if err != nil {
+		return nil, err
+	}
+	updatedPromoCode, err := p.repo.GetById(ctx, promoCodeToUpdate.Id)
+	if err != nil {
+		return nil, err
+	}
+	return updatedPromoCode, nil
+}
+
+// DeleteById deletes a promo code no order was placed with, used codes can be ended instead.
+func (p *promoCode) DeleteById(ctx context.Context, id uuid.UUID) error {
+	_, err := p.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	used, err := p.repo.CheckIfUsedById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if used {
+		return ErrPromoCodeIsUsed
+	}
+	return p.repo.DeleteById(ctx, id)
+}
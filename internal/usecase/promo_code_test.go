@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCartPromoCode_apply(t *testing.T) {
+	printerId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	tonerId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	paperId := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	lines := func() []*entity.ProductInCart {
+		return []*entity.ProductInCart{
+			{
+				Product: &entity.Product{Id: printerId, Price: entity.NewMoney(2000000, entity.CurrencyKZT), Status: entity.ProductStatusPublished},
+				Count:   1,
+			},
+			{
+				Product: &entity.Product{Id: tonerId, Price: entity.NewMoney(333333, entity.CurrencyKZT), Status: entity.ProductStatusPublished},
+				Count:   3,
+			},
+			{
+				Product: &entity.Product{Id: paperId, Price: entity.NewMoney(50000, entity.CurrencyKZT), Status: entity.ProductStatusHidden},
+				Count:   10,
+			},
+		}
+	}
+	testTable := []struct {
+		name              string
+		promoCode         entity.PromoCode
+		productIds        map[uuid.UUID]bool
+		expectedDiscounts []int64
+		expectedErr       error
+	}{
+		{
+			name:              "percentage of all products",
+			promoCode:         entity.PromoCode{DiscountType: entity.DiscountTypePercentage, DiscountValue: 10, Currency: entity.CurrencyRUB},
+			expectedDiscounts: []int64{200000, 100000, 0},
+		},
+		{
+			name:              "fixed amount is converted and split",
+			promoCode:         entity.PromoCode{DiscountType: entity.DiscountTypeFixed, DiscountValue: 100000, Currency: entity.CurrencyRUB},
+			expectedDiscounts: []int64{333333, 166667, 0},
+		},
+		{
+			name:              "fixed amount is limited by scoped lines",
+			promoCode:         entity.PromoCode{DiscountType: entity.DiscountTypeFixed, DiscountValue: 1000000, Currency: entity.CurrencyRUB},
+			productIds:        map[uuid.UUID]bool{tonerId: true},
+			expectedDiscounts: []int64{0, 999999, 0},
+		},
+		{
+			name:        "hidden products only",
+			promoCode:   entity.PromoCode{DiscountType: entity.DiscountTypePercentage, DiscountValue: 10, Currency: entity.CurrencyRUB},
+			productIds:  map[uuid.UUID]bool{paperId: true},
+			expectedErr: ErrPromoCodeNotApplicable,
+		},
+		{
+			name: "minimum order amount",
+			promoCode: entity.PromoCode{DiscountType: entity.DiscountTypePercentage, DiscountValue: 10, MinOrderAmount: 1000000,
+				Currency: entity.CurrencyRUB},
+			expectedErr: ErrPromoCodeMinOrderAmount,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			promo := &cartPromoCode{promoCode: &testCase.promoCode, productIds: testCase.productIds}
+			cartLines := lines()
+
+			promo.apply(cartLines, entity.CurrencyKZT, 5)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, promo.err, testCase.expectedErr)
+				for _, line := range cartLines {
+					assert.Nil(t, line.Discount)
+				}
+				return
+			}
+			assert.NoError(t, promo.err)
+			for i, line := range cartLines {
+				if testCase.expectedDiscounts[i] == 0 {
+					assert.Nil(t, line.Discount)
+					continue
+				}
+				assert.Equal(t, entity.NewMoney(testCase.expectedDiscounts[i], entity.CurrencyKZT), *line.Discount)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+)
+
+type promotion struct {
+	repo         repo.Promotion
+	repoProduct  repo.Product
+	exchangeRate ExchangeRate
+}
+
+func NewPromotion(r repo.Promotion, p repo.Product, e ExchangeRate) Promotion {
+	return &promotion{
+		repo:         r,
+		repoProduct:  p,
+		exchangeRate: e,
+	}
+}
+
+// applyPromotions discounts the lines with the promotions in the order of their priority and
+// explains which promotions gave the discounts, line prices must be in currency already.
+// Discounts the lines had before are reset. Lines of hidden products aren't discounted and
+// every item is discounted by one promotion at most.
+func applyPromotions(promotions []*entity.Promotion, lines []*entity.ProductInCart, currency entity.Currency, rate float64) {
+	available := map[uuid.UUID]int{}
+	byProduct := map[uuid.UUID]*entity.ProductInCart{}
+	for _, line := range lines {
+		line.Discount = nil
+		line.Promotions = nil
+		if line.Product.Status == entity.ProductStatusHidden {
+			continue
+		}
+		available[line.Product.Id] += line.Count
+		byProduct[line.Product.Id] = line
+	}
+	promotions = slices.Clone(promotions)
+	slices.SortStableFunc(promotions, func(a, b *entity.Promotion) int {
+		return a.Priority - b.Priority
+	})
+	for _, promo := range promotions {
+		switch promo.Type {
+		case entity.PromotionTypeBundle:
+			if len(promo.Products) == 0 {
+				continue
+			}
+			bundles := math.MaxInt
+			for _, product := range promo.Products {
+				bundles = min(bundles, available[product.ProductId]/max(product.Count, 1))
+			}
+			if bundles == 0 {
+				continue
+			}
+			for _, product := range promo.Products {
+				units := bundles * max(product.Count, 1)
+				line := byProduct[product.ProductId]
+				available[product.ProductId] -= units
+				discount := int64(math.Round(float64(line.Product.Price.Mul(units).Amount) * float64(promo.Percent) / 100))
+				addPromotionDiscount(line, promo, discount)
+			}
+		case entity.PromotionTypeBuyXGetY:
+			group := promo.BuyCount + promo.FreeCount
+			if group == 0 {
+				continue
+			}
+			for _, product := range promo.Products {
+				groups := available[product.ProductId] / group
+				if groups == 0 {
+					continue
+				}
+				line := byProduct[product.ProductId]
+				available[product.ProductId] -= groups * group
+				addPromotionDiscount(line, promo, line.Product.Price.Mul(groups*promo.FreeCount).Amount)
+			}
+		case entity.PromotionTypeVolumeTier:
+			for _, product := range promo.Products {
+				units := available[product.ProductId]
+				var tier *entity.PromotionTier
+				for _, t := range promo.Tiers {
+					if t.MinCount <= units && (tier == nil || t.MinCount > tier.MinCount) {
+						tier = t
+					}
+				}
+				if tier == nil {
+					continue
+				}
+				line := byProduct[product.ProductId]
+				tierPrice := entity.NewMoney(tier.Price, promo.Currency).Convert(currency, rate)
+				if tierPrice.Amount >= line.Product.Price.Amount {
+					continue
+				}
+				available[product.ProductId] = 0
+				addPromotionDiscount(line, promo, line.Product.Price.Sub(tierPrice).Mul(units).Amount)
+			}
+		}
+	}
+}
+
+// addPromotionDiscount adds the discount the promotion gives to the line.
+func addPromotionDiscount(line *entity.ProductInCart, promo *entity.Promotion, discount int64) {
+	if discount <= 0 {
+		return
+	}
+	amount := entity.NewMoney(discount, line.Product.Price.Currency)
+	if line.Discount == nil {
+		line.Discount = &amount
+	} else {
+		total := line.Discount.Add(amount)
+		line.Discount = &total
+	}
+	id := promo.Id
+	line.Promotions = append(line.Promotions, &entity.AppliedPromotion{
+		PromotionId: &id,
+		Name:        promo.Name,
+		Type:        promo.Type,
+		Discount:    amount,
+	})
+}
+
+func (p *promotion) GetAll(ctx context.Context) ([]*entity.Promotion, error) {
+	return p.repo.GetAll(ctx)
+}
+
+func (p *promotion) GetById(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	receivedPromotion, err := p.repo.GetById(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrPromotionNotFound):
+			return nil, ErrPromotionNotFound
+		default:
+			return nil, err
+		}
+	}
+	return receivedPromotion, nil
+}
+
+// check validates the currency and the products of the promotion.
+func (p *promotion) check(ctx context.Context, promo *entity.Promotion) error {
+	if promo.Currency == "" {
+		promo.Currency = p.exchangeRate.BaseCurrency()
+	}
+	if promo.Currency != p.exchangeRate.BaseCurrency() {
+		return ErrUnsupportedCurrency
+	}
+	for _, product := range promo.Products {
+		_, err := p.repoProduct.GetById(ctx, product.ProductId)
+		if err != nil {
+			switch {
+			case errors.Is(err, repo.ErrProductNotFound):
+				return ErrProductNotFound
+			default:
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *promotion) Create(ctx context.Context, promotionToCreate entity.Promotion) (*entity.Promotion, error) {
+	promotionToCreate.Id = uuid.New()
+	promotionToCreate.CreatedAt = time.Now()
+	err := p.check(ctx, &promotionToCreate)
+	if err != nil {
+		return nil, err
+	}
+	err = p.repo.Create(ctx, promotionToCreate)
+	if err != nil {
+		return nil, err
+	}
+	newPromotion, err := p.repo.GetById(ctx, promotionToCreate.Id)
+	if err != nil {
+		return nil, err
+	}
+	return newPromotion, nil
+}
+
+// Update replaces the promotion. Placed orders keep their discounts until their lines are changed.
+func (p *promotion) Update(ctx context.Context, promotionToUpdate entity.Promotion) (*entity.Promotion, error) {
+	_, err := p.GetById(ctx, promotionToUpdate.Id)
+	if err != nil {
+		return nil, err
+	}
+	err = p.check(ctx, &promotionToUpdate)
+	if err != nil {
+		return nil, err
+	}
+	err = p.repo.Update(ctx, promotionToUpdate)
+	if err != nil {
+		return nil, err
+	}
+	updatedPromotion, err := p.repo.GetById(ctx, promotionToUpdate.Id)
+	if err != nil {
+		return nil, err
+	}
+	return updatedPromotion, nil
+}
+
+func (p *promotion) DeleteById(ctx context.Context, id uuid.UUID) error {
+	_, err := p.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	return p.repo.DeleteById(ctx, id)
+}
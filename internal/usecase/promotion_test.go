@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPromotions(t *testing.T) {
+	printerId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	tonerId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	paperId := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	hiddenId := uuid.MustParse("00000000-0000-0000-0000-000000000004")
+	lines := func() []*entity.ProductInCart {
+		return []*entity.ProductInCart{
+			{
+				Product: &entity.Product{Id: printerId, Price: entity.NewMoney(2000000, entity.CurrencyKZT), Status: entity.ProductStatusPublished},
+				Count:   1,
+			},
+			{
+				Product: &entity.Product{Id: tonerId, Price: entity.NewMoney(300000, entity.CurrencyKZT), Status: entity.ProductStatusPublished},
+				Count:   3,
+			},
+			{
+				Product: &entity.Product{Id: paperId, Price: entity.NewMoney(50000, entity.CurrencyKZT), Status: entity.ProductStatusPublished},
+				Count:   10,
+			},
+			{
+				Product: &entity.Product{Id: hiddenId, Price: entity.NewMoney(50000, entity.CurrencyKZT), Status: entity.ProductStatusHidden},
+				Count:   4,
+			},
+		}
+	}
+	bundle := &entity.Promotion{
+		Name:     "printer + 2 toners",
+		Type:     entity.PromotionTypeBundle,
+		Products: []*entity.PromotionProduct{{ProductId: printerId, Count: 1}, {ProductId: tonerId, Count: 2}},
+		Percent:  10,
+		Currency: entity.CurrencyRUB,
+	}
+	buyXGetY := &entity.Promotion{
+		Name:      "buy 3 get 1",
+		Type:      entity.PromotionTypeBuyXGetY,
+		Priority:  1,
+		Products:  []*entity.PromotionProduct{{ProductId: paperId, Count: 1}, {ProductId: hiddenId, Count: 1}},
+		BuyCount:  3,
+		FreeCount: 1,
+		Currency:  entity.CurrencyRUB,
+	}
+	tiers := &entity.Promotion{
+		Name:     "paper 5+ and 10+",
+		Type:     entity.PromotionTypeVolumeTier,
+		Priority: 2,
+		Products: []*entity.PromotionProduct{{ProductId: paperId, Count: 1}},
+		Tiers:    []*entity.PromotionTier{{MinCount: 5, Price: 8000}, {MinCount: 10, Price: 6000}},
+		Currency: entity.CurrencyRUB,
+	}
+	testTable := []struct {
+		name               string
+		promotions         []*entity.Promotion
+		expectedDiscounts  []int64
+		expectedPromotions [][]string
+	}{
+		{
+			name:               "bundle",
+			promotions:         []*entity.Promotion{bundle},
+			expectedDiscounts:  []int64{200000, 60000, 0, 0},
+			expectedPromotions: [][]string{{"printer + 2 toners"}, {"printer + 2 toners"}, nil, nil},
+		},
+		{
+			name:               "buy x get y skips hidden products",
+			promotions:         []*entity.Promotion{buyXGetY},
+			expectedDiscounts:  []int64{0, 0, 100000, 0},
+			expectedPromotions: [][]string{nil, nil, {"buy 3 get 1"}, nil},
+		},
+		{
+			name:               "highest tier reached",
+			promotions:         []*entity.Promotion{tiers},
+			expectedDiscounts:  []int64{0, 0, 200000, 0},
+			expectedPromotions: [][]string{nil, nil, {"paper 5+ and 10+"}, nil},
+		},
+		{
+			name:               "items discounted by a promotion of higher priority",
+			promotions:         []*entity.Promotion{tiers, buyXGetY, bundle},
+			expectedDiscounts:  []int64{200000, 60000, 100000, 0},
+			expectedPromotions: [][]string{{"printer + 2 toners"}, {"printer + 2 toners"}, {"buy 3 get 1"}, nil},
+		},
+		{
+			name: "tier price over the product price",
+			promotions: []*entity.Promotion{{
+				Type:     entity.PromotionTypeVolumeTier,
+				Products: []*entity.PromotionProduct{{ProductId: paperId, Count: 1}},
+				Tiers:    []*entity.PromotionTier{{MinCount: 1, Price: 20000}},
+				Currency: entity.CurrencyRUB,
+			}},
+			expectedDiscounts:  []int64{0, 0, 0, 0},
+			expectedPromotions: [][]string{nil, nil, nil, nil},
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			cartLines := lines()
+
+			applyPromotions(testCase.promotions, cartLines, entity.CurrencyKZT, 5)
+
+			for i, line := range cartLines {
+				if testCase.expectedDiscounts[i] == 0 {
+					assert.Nil(t, line.Discount)
+				} else {
+					assert.Equal(t, entity.NewMoney(testCase.expectedDiscounts[i], entity.CurrencyKZT), *line.Discount)
+				}
+				var names []string
+				for _, applied := range line.Promotions {
+					names = append(names, applied.Name)
+				}
+				assert.Equal(t, testCase.expectedPromotions[i], names)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+)
+
+type shipment struct {
+	repo      repo.Shipment
+	repoOrder repo.Order
+}
+
+func NewShipment(r repo.Shipment, o repo.Order) Shipment {
+	return &shipment{
+		repo:      r,
+		repoOrder: o,
+	}
+}
+
+// checkShipmentLines checks that the lines ship products of the order in counts which are
+// still left to ship after the previous shipments. It returns whether the order is shipped
+// in full with the lines.
+func checkShipmentLines(order *entity.Order, lines []*entity.ShipmentLine) (bool, error) {
+	left := make(map[uuid.UUID]int, len(order.Products))
+	for _, p := range order.Products {
+		left[p.Product.Id] += p.Count
+	}
+	for _, s := range order.Shipments {
+		for _, line := range s.Lines {
+			left[line.ProductId] -= line.Count
+		}
+	}
+	for _, line := range lines {
+		count, ok := left[line.ProductId]
+		if !ok {
+			return false, fmt.Errorf("%w: %s", ErrShipmentProductNotInOrder, line.ProductId)
+		}
+		if line.Count > count {
+			return false, fmt.Errorf("%w: %d of %s, %d left", ErrShipmentExceedsOrder, line.Count, line.ProductId, count)
+		}
+		left[line.ProductId] = count - line.Count
+	}
+	for _, count := range left {
+		if count > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// shipmentAttempts is how many times a shipment is checked and recorded when the order
+// changes between the check and the record.
+const shipmentAttempts = 3
+
+// Create records the shipment of the order lines. The first shipment of a paid order moves it
+// in progress, the shipment of the last lines moves the order to shipped. A paid order shipped
+// at once goes through both statuses.
+func (s *shipment) Create(ctx context.Context, userId uuid.UUID, shipmentToCreate entity.Shipment) (*entity.Shipment, error) {
+	for attempt := 1; ; attempt++ {
+		err := s.create(ctx, userId, &shipmentToCreate)
+		if errors.Is(err, repo.ErrOrderChanged) {
+			if attempt < shipmentAttempts {
+				continue
+			}
+			return nil, fmt.Errorf("%w: %w", ErrOrderCantBeShipped, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &shipmentToCreate, nil
+	}
+}
+
+func (s *shipment) create(ctx context.Context, userId uuid.UUID, shipmentToCreate *entity.Shipment) error {
+	order, err := s.repoOrder.GetById(ctx, shipmentToCreate.OrderId)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrOrderNotFound):
+			return ErrOrderNotFound
+		default:
+			return err
+		}
+	}
+	if order.Status != entity.OrderStatusPaid && order.Status != entity.OrderStatusInProgress {
+		return ErrOrderCantBeShipped
+	}
+	shippedInFull, err := checkShipmentLines(order, shipmentToCreate.Lines)
+	if err != nil {
+		return err
+	}
+	status := order.Status
+	events := []entity.OrderEvent{}
+	changeStatus := func(to entity.OrderStatus, comment string) error {
+		err := checkOrderStatusTransition(status, to)
+		if err != nil {
+			return err
+		}
+		events = append(events, newOrderEvent(order.Id, userId, entity.OrderEventTypeStatusChanged, status, to, comment))
+		status = to
+		return nil
+	}
+	if status == entity.OrderStatusPaid {
+		comment := "partially shipped"
+		if shippedInFull {
+			comment = "shipping started"
+		}
+		err = changeStatus(entity.OrderStatusInProgress, comment)
+		if err != nil {
+			return err
+		}
+	}
+	if shippedInFull {
+		err = changeStatus(entity.OrderStatusShipped, "all lines shipped")
+		if err != nil {
+			return err
+		}
+	}
+	shipmentToCreate.Id = uuid.New()
+	shipmentToCreate.CreatedAt = time.Now()
+	if shipmentToCreate.ShippedAt.IsZero() {
+		shipmentToCreate.ShippedAt = shipmentToCreate.CreatedAt
+	}
+	err = s.repo.Create(ctx, shipmentToCreate, order.Status, status, events)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrOrderNotFound):
+			return ErrOrderNotFound
+		case errors.Is(err, repo.ErrShipmentExceedsOrder):
+			return ErrShipmentExceedsOrder
+		default:
+			return err
+		}
+	}
+	return nil
+}
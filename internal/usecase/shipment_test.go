@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+	mock_repo "github.com/krijebr/printer-shop/internal/repo/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShipment_Create(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockShipment, o *mock_repo.MockOrder, ctx context.Context)
+
+	orderId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	printerId := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	cartridgeId := uuid.MustParse("00000000-0000-0000-0000-000000000004")
+	existingOrder := func(status entity.OrderStatus, shipments ...*entity.Shipment) *entity.Order {
+		return &entity.Order{
+			Id:     orderId,
+			Status: status,
+			Products: []*entity.ProductInCart{
+				{Product: &entity.Product{Id: printerId}, Count: 1},
+				{Product: &entity.Product{Id: cartridgeId}, Count: 3},
+			},
+			Shipments: shipments,
+		}
+	}
+	shipped := func(lines ...*entity.ShipmentLine) *entity.Shipment {
+		return &entity.Shipment{Id: uuid.New(), OrderId: orderId, Carrier: "CDEK", Lines: lines}
+	}
+	// recorded expects the shipment to be recorded with the order going through the statuses.
+	recorded := func(s *mock_repo.MockShipment, ctx context.Context, statuses ...entity.OrderStatus) {
+		from, to := statuses[0], statuses[len(statuses)-1]
+		s.EXPECT().Create(ctx, gomock.Any(), from, to, gomock.Len(len(statuses)-1)).
+			DoAndReturn(func(ctx context.Context, shipment *entity.Shipment, from, to entity.OrderStatus, events []entity.OrderEvent) error {
+				for i, event := range events {
+					assert.Equal(t, entity.OrderEventTypeStatusChanged, event.Type)
+					assert.Equal(t, userId, *event.UserId)
+					assert.Equal(t, statuses[i], *event.FromStatus)
+					assert.Equal(t, statuses[i+1], *event.ToStatus)
+				}
+				return nil
+			})
+	}
+	testTable := []struct {
+		name         string
+		inputLines   []*entity.ShipmentLine
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			name:       "OK partial shipment of paid order",
+			inputLines: []*entity.ShipmentLine{{ProductId: printerId, Count: 1}, {ProductId: cartridgeId, Count: 1}},
+			mockBehavior: func(s *mock_repo.MockShipment, o *mock_repo.MockOrder, ctx context.Context) {
+				o.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusPaid), nil)
+				recorded(s, ctx, entity.OrderStatusPaid, entity.OrderStatusInProgress)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "OK partial shipment of order in progress",
+			inputLines: []*entity.ShipmentLine{{ProductId: cartridgeId, Count: 1}},
+			mockBehavior: func(s *mock_repo.MockShipment, o *mock_repo.MockOrder, ctx context.Context) {
+				o.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusInProgress,
+					shipped(&entity.ShipmentLine{ProductId: printerId, Count: 1})), nil)
+				recorded(s, ctx, entity.OrderStatusInProgress)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "OK last lines shipped",
+			inputLines: []*entity.ShipmentLine{{ProductId: cartridgeId, Count: 2}},
+			mockBehavior: func(s *mock_repo.MockShipment, o *mock_repo.MockOrder, ctx context.Context) {
+				o.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusInProgress,
+					shipped(&entity.ShipmentLine{ProductId: printerId, Count: 1}, &entity.ShipmentLine{ProductId: cartridgeId, Count: 1})), nil)
+				recorded(s, ctx, entity.OrderStatusInProgress, entity.OrderStatusShipped)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "OK paid order shipped at once",
+			inputLines: []*entity.ShipmentLine{{ProductId: printerId, Count: 1}, {ProductId: cartridgeId, Count: 3}},
+			mockBehavior: func(s *mock_repo.MockShipment, o *mock_repo.MockOrder, ctx context.Context) {
+				o.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusPaid), nil)
+				recorded(s, ctx, entity.OrderStatusPaid, entity.OrderStatusInProgress, entity.OrderStatusShipped)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:       "more than left to ship",
+			inputLines: []*entity.ShipmentLine{{ProductId: cartridgeId, Count: 3}},
+			mockBehavior: func(s *mock_repo.MockShipment, o *mock_repo.MockOrder, ctx context.Context) {
+				o.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusInProgress,
+					shipped(&entity.ShipmentLine{ProductId: cartridgeId, Count: 1})), nil)
+			},
+			expectedErr: ErrShipmentExceedsOrder,
+		},
+		{
+			name:       "concurrent shipment shipped the lines",
+			inputLines: []*entity.ShipmentLine{{ProductId: cartridgeId, Count: 1}},
+			mockBehavior: func(s *mock_repo.MockShipment, o *mock_repo.MockOrder, ctx context.Context) {
+				o.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusInProgress,
+					shipped(&entity.ShipmentLine{ProductId: printerId, Count: 1}, &entity.ShipmentLine{ProductId: cartridgeId, Count: 1})), nil)
+				s.EXPECT().Create(ctx, gomock.Any(), entity.OrderStatusInProgress, entity.OrderStatusInProgress, gomock.Len(0)).
+					Return(repo.ErrShipmentExceedsOrder)
+			},
+			expectedErr: ErrShipmentExceedsOrder,
+		},
+		{
+			name:       "order shipped in full concurrently is checked again",
+			inputLines: []*entity.ShipmentLine{{ProductId: cartridgeId, Count: 1}},
+			mockBehavior: func(s *mock_repo.MockShipment, o *mock_repo.MockOrder, ctx context.Context) {
+				o.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusPaid), nil)
+				s.EXPECT().Create(ctx, gomock.Any(), entity.OrderStatusPaid, entity.OrderStatusInProgress, gomock.Len(1)).
+					Return(repo.ErrOrderChanged)
+				o.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusShipped,
+					shipped(&entity.ShipmentLine{ProductId: printerId, Count: 1}, &entity.ShipmentLine{ProductId: cartridgeId, Count: 3})), nil)
+			},
+			expectedErr: ErrOrderCantBeShipped,
+		},
+		{
+			name:       "order keeps changing",
+			inputLines: []*entity.ShipmentLine{{ProductId: cartridgeId, Count: 1}},
+			mockBehavior: func(s *mock_repo.MockShipment, o *mock_repo.MockOrder, ctx context.Context) {
+				o.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusPaid), nil).Times(shipmentAttempts)
+				s.EXPECT().Create(ctx, gomock.Any(), entity.OrderStatusPaid, entity.OrderStatusInProgress, gomock.Len(1)).
+					Return(repo.ErrOrderChanged).Times(shipmentAttempts)
+			},
+			expectedErr: ErrOrderCantBeShipped,
+		},
+		{
+			name:       "product not in order",
+			inputLines: []*entity.ShipmentLine{{ProductId: uuid.New(), Count: 1}},
+			mockBehavior: func(s *mock_repo.MockShipment, o *mock_repo.MockOrder, ctx context.Context) {
+				o.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusPaid), nil)
+			},
+			expectedErr: ErrShipmentProductNotInOrder,
+		},
+		{
+			name:       "new order isn't shipped",
+			inputLines: []*entity.ShipmentLine{{ProductId: printerId, Count: 1}},
+			mockBehavior: func(s *mock_repo.MockShipment, o *mock_repo.MockOrder, ctx context.Context) {
+				o.EXPECT().GetById(ctx, orderId).Return(existingOrder(entity.OrderStatusNew), nil)
+			},
+			expectedErr: ErrOrderCantBeShipped,
+		},
+		{
+			name:       "order not found",
+			inputLines: []*entity.ShipmentLine{{ProductId: printerId, Count: 1}},
+			mockBehavior: func(s *mock_repo.MockShipment, o *mock_repo.MockOrder, ctx context.Context) {
+				o.EXPECT().GetById(ctx, orderId).Return(nil, repo.ErrOrderNotFound)
+			},
+			expectedErr: ErrOrderNotFound,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			shipmentRepo := mock_repo.NewMockShipment(c)
+			orderRepo := mock_repo.NewMockOrder(c)
+			testCase.mockBehavior(shipmentRepo, orderRepo, ctx)
+
+			shipmentUsecase := NewShipment(shipmentRepo, orderRepo)
+
+			shipment, err := shipmentUsecase.Create(ctx, userId, entity.Shipment{
+				OrderId: orderId,
+				Carrier: "CDEK",
+				Lines:   testCase.inputLines,
+			})
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				assert.Nil(t, shipment)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, shipment.Id)
+				assert.Equal(t, testCase.inputLines, shipment.Lines)
+				assert.False(t, shipment.ShippedAt.IsZero())
+			}
+		})
+	}
+}
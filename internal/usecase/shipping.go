@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+)
+
+// shippingCalculator returns the cost of shipping the parcel by the rate in the currency of the
+// method, false when the rate can't ship the parcel.
+type shippingCalculator func(rate *entity.ShippingRate, parcel entity.Parcel) (int64, bool)
+
+// shippingCalculators are the rate calculators by their types in the config, methods with
+// other calculators aren't available.
+var shippingCalculators = map[entity.ShippingCalculatorType]shippingCalculator{
+	entity.ShippingCalculatorFlat: func(rate *entity.ShippingRate, parcel entity.Parcel) (int64, bool) {
+		return rate.Price, true
+	},
+	entity.ShippingCalculatorWeightTable: func(rate *entity.ShippingRate, parcel entity.Parcel) (int64, bool) {
+		var price *entity.ShippingWeightPrice
+		for _, p := range rate.Weights {
+			if parcel.Weight <= p.MaxWeight && (price == nil || p.MaxWeight < price.MaxWeight) {
+				price = p
+			}
+		}
+		if price == nil {
+			return 0, false
+		}
+		return price.Price, true
+	},
+	entity.ShippingCalculatorFreeOverThreshold: func(rate *entity.ShippingRate, parcel entity.Parcel) (int64, bool) {
+		if parcel.Amount.Amount >= rate.Threshold {
+			return 0, true
+		}
+		return rate.Price, true
+	},
+}
+
+// newParcel makes the parcel of the lines shipped to the address, the amount is what is left
+// to pay for the lines converted back to the base currency. Lines of hidden products aren't shipped.
+func newParcel(lines []*entity.ProductInCart, base entity.Currency, rate float64, address *entity.Address) entity.Parcel {
+	parcel := entity.Parcel{Amount: entity.NewMoney(0, base)}
+	amount := entity.Money{}
+	for _, line := range lines {
+		if line.Product.Status == entity.ProductStatusHidden {
+			continue
+		}
+		parcel.Weight += line.Product.Weight * line.Count
+		amount = amount.Add(cartLineNet(line))
+	}
+	if rate != 0 {
+		parcel.Amount = amount.Convert(base, 1/rate)
+	}
+	if address != nil {
+		parcel.Region = address.Region
+		parcel.City = address.City
+	}
+	return parcel
+}
+
+// quoteShipping returns the cost of shipping the parcel by the method in the currency,
+// false when the method doesn't ship the parcel.
+func quoteShipping(method *entity.ShippingMethod, parcel entity.Parcel, currency entity.Currency, rate float64) (entity.Money, bool) {
+	calculate, ok := shippingCalculators[method.Calculator]
+	if !ok {
+		return entity.Money{}, false
+	}
+	for _, shippingRate := range method.Rates {
+		covered := len(shippingRate.Regions) == 0 || slices.ContainsFunc(shippingRate.Regions, func(region string) bool {
+			return strings.EqualFold(region, parcel.Region) || strings.EqualFold(region, parcel.City)
+		})
+		if !covered {
+			continue
+		}
+		cost, ok := calculate(shippingRate, parcel)
+		if !ok {
+			return entity.Money{}, false
+		}
+		return entity.NewMoney(cost, method.Currency).Convert(currency, rate), true
+	}
+	return entity.Money{}, false
+}
+
+type shipping struct {
+	methods      []*entity.ShippingMethod
+	cart         Cart
+	repoAddress  repo.Address
+	exchangeRate ExchangeRate
+}
+
+func NewShipping(methods []*entity.ShippingMethod, c Cart, a repo.Address, e ExchangeRate) Shipping {
+	return &shipping{
+		methods:      methods,
+		cart:         c,
+		repoAddress:  a,
+		exchangeRate: e,
+	}
+}
+
+// Quote returns the costs of the shipping methods available for the cart of the user. Without
+// the address only methods with rates for every region are quoted.
+func (s *shipping) Quote(ctx context.Context, userId uuid.UUID, currency entity.Currency, addressId *uuid.UUID) ([]*entity.ShippingQuote, error) {
+	var address *entity.Address
+	if addressId != nil {
+		var err error
+		address, err = getOwnedAddress(ctx, s.repoAddress, userId, *addressId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if currency == "" {
+		currency = s.exchangeRate.BaseCurrency()
+	}
+	rate, err := s.exchangeRate.GetRate(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := s.cart.GetSummary(ctx, userId, currency)
+	if err != nil {
+		return nil, err
+	}
+	if len(summary.Products) == 0 {
+		return nil, ErrCartIsEmpty
+	}
+	parcel := newParcel(summary.Products, s.exchangeRate.BaseCurrency(), rate, address)
+	quotes := []*entity.ShippingQuote{}
+	for _, method := range s.methods {
+		cost, ok := quoteShipping(method, parcel, currency, rate)
+		if !ok {
+			continue
+		}
+		quotes = append(quotes, &entity.ShippingQuote{
+			Method: method.Code,
+			Name:   method.Name,
+			Cost:   cost,
+		})
+	}
+	return quotes, nil
+}
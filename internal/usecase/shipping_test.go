@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	mock_repo "github.com/krijebr/printer-shop/internal/repo/mocks"
+	mock_usecase "github.com/krijebr/printer-shop/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShipping_Quote(t *testing.T) {
+	type mockBehavior func(cr *mock_usecase.MockCart, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context)
+
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	addressId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	methods := []*entity.ShippingMethod{
+		{
+			Code:       "courier",
+			Name:       "Courier",
+			Calculator: entity.ShippingCalculatorWeightTable,
+			Currency:   entity.CurrencyRUB,
+			Rates: []*entity.ShippingRate{{
+				Regions: []string{"Moscow"},
+				Weights: []*entity.ShippingWeightPrice{{MaxWeight: 30000, Price: 90000}, {MaxWeight: 10000, Price: 50000}},
+			}},
+		},
+		{
+			Code:       "pickup_point",
+			Name:       "Pickup point",
+			Calculator: entity.ShippingCalculatorFreeOverThreshold,
+			Currency:   entity.CurrencyRUB,
+			Rates:      []*entity.ShippingRate{{Price: 20000, Threshold: 5000000}},
+		},
+		{
+			Code:       "russian_post",
+			Name:       "Russian Post",
+			Calculator: entity.ShippingCalculatorFlat,
+			Currency:   entity.CurrencyRUB,
+			Rates:      []*entity.ShippingRate{{Price: 30000}},
+		},
+	}
+	summary := func(count int, price entity.Money) *entity.CartSummary {
+		return &entity.CartSummary{
+			Products: []*entity.ProductInCart{{
+				Product: &entity.Product{
+					Id:     uuid.New(),
+					Price:  price,
+					Status: entity.ProductStatusPublished,
+					Weight: 9500,
+				},
+				Count: count,
+			}},
+		}
+	}
+	address := func(owner uuid.UUID) *entity.Address {
+		return &entity.Address{Id: addressId, UserId: owner, City: "moscow"}
+	}
+	testTable := []struct {
+		name           string
+		inputCurrency  entity.Currency
+		inputAddressId *uuid.UUID
+		mockBehavior   mockBehavior
+		expectedQuotes []*entity.ShippingQuote
+		expectedErr    error
+	}{
+		{
+			name:          "OK without address",
+			inputCurrency: "",
+			mockBehavior: func(cr *mock_usecase.MockCart, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				e.EXPECT().BaseCurrency().Return(entity.CurrencyRUB).Times(2)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				cr.EXPECT().GetSummary(ctx, userId, entity.CurrencyRUB).Return(summary(1, entity.NewMoney(4999999, entity.CurrencyRUB)), nil)
+			},
+			expectedQuotes: []*entity.ShippingQuote{
+				{Method: "pickup_point", Name: "Pickup point", Cost: entity.NewMoney(20000, entity.CurrencyRUB)},
+				{Method: "russian_post", Name: "Russian Post", Cost: entity.NewMoney(30000, entity.CurrencyRUB)},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:           "OK with address over threshold",
+			inputCurrency:  entity.CurrencyRUB,
+			inputAddressId: &addressId,
+			mockBehavior: func(cr *mock_usecase.MockCart, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				a.EXPECT().GetById(ctx, addressId).Return(address(userId), nil)
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				cr.EXPECT().GetSummary(ctx, userId, entity.CurrencyRUB).Return(summary(2, entity.NewMoney(4999999, entity.CurrencyRUB)), nil)
+				e.EXPECT().BaseCurrency().Return(entity.CurrencyRUB)
+			},
+			expectedQuotes: []*entity.ShippingQuote{
+				{Method: "courier", Name: "Courier", Cost: entity.NewMoney(90000, entity.CurrencyRUB)},
+				{Method: "pickup_point", Name: "Pickup point", Cost: entity.NewMoney(0, entity.CurrencyRUB)},
+				{Method: "russian_post", Name: "Russian Post", Cost: entity.NewMoney(30000, entity.CurrencyRUB)},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:          "OK converted to kzt",
+			inputCurrency: entity.CurrencyKZT,
+			mockBehavior: func(cr *mock_usecase.MockCart, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				e.EXPECT().GetRate(ctx, entity.CurrencyKZT).Return(5.9, nil)
+				cr.EXPECT().GetSummary(ctx, userId, entity.CurrencyKZT).Return(summary(1, entity.NewMoney(29499994, entity.CurrencyKZT)), nil)
+				e.EXPECT().BaseCurrency().Return(entity.CurrencyRUB)
+			},
+			expectedQuotes: []*entity.ShippingQuote{
+				{Method: "pickup_point", Name: "Pickup point", Cost: entity.NewMoney(118000, entity.CurrencyKZT)},
+				{Method: "russian_post", Name: "Russian Post", Cost: entity.NewMoney(177000, entity.CurrencyKZT)},
+			},
+			expectedErr: nil,
+		},
+		{
+			name:           "address of another user",
+			inputCurrency:  entity.CurrencyRUB,
+			inputAddressId: &addressId,
+			mockBehavior: func(cr *mock_usecase.MockCart, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				a.EXPECT().GetById(ctx, addressId).Return(address(uuid.New()), nil)
+			},
+			expectedQuotes: nil,
+			expectedErr:    ErrAddressNotFound,
+		},
+		{
+			name:          "cart is empty",
+			inputCurrency: entity.CurrencyRUB,
+			mockBehavior: func(cr *mock_usecase.MockCart, a *mock_repo.MockAddress, e *mock_usecase.MockExchangeRate, ctx context.Context) {
+				e.EXPECT().GetRate(ctx, entity.CurrencyRUB).Return(1.0, nil)
+				cr.EXPECT().GetSummary(ctx, userId, entity.CurrencyRUB).Return(&entity.CartSummary{}, nil)
+			},
+			expectedQuotes: nil,
+			expectedErr:    ErrCartIsEmpty,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			cart := mock_usecase.NewMockCart(c)
+			addressRepo := mock_repo.NewMockAddress(c)
+			exchangeRate := mock_usecase.NewMockExchangeRate(c)
+			testCase.mockBehavior(cart, addressRepo, exchangeRate, ctx)
+
+			shippingUsecase := NewShipping(methods, cart, addressRepo, exchangeRate)
+
+			quotes, err := shippingUsecase.Quote(ctx, userId, testCase.inputCurrency, testCase.inputAddressId)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				assert.Nil(t, quotes)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedQuotes, quotes)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+)
+
+type stock struct {
+	repo          repo.Stock
+	repoProduct   repo.Product
+	repoWarehouse repo.Warehouse
+}
+
+func NewStock(r repo.Stock, p repo.Product, w repo.Warehouse) Stock {
+	return &stock{
+		repo:          r,
+		repoProduct:   p,
+		repoWarehouse: w,
+	}
+}
+
+func (s *stock) checkProduct(ctx context.Context, productId uuid.UUID) error {
+	_, err := s.repoProduct.GetById(ctx, productId)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrProductNotFound):
+			return ErrProductNotFound
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stock) Adjust(ctx context.Context, userId uuid.UUID, productId uuid.UUID, warehouseId uuid.UUID, delta int,
+	reason string) (*entity.Product, error) {
+	err := s.checkProduct(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.repoWarehouse.GetById(ctx, warehouseId)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrWarehouseNotFound):
+			return nil, ErrWarehouseNotFound
+		default:
+			return nil, err
+		}
+	}
+	movement := entity.StockMovement{
+		ProductId:   productId,
+		WarehouseId: &warehouseId,
+		Delta:       delta,
+		Type:        entity.StockMovementTypeAdjustment,
+		Reason:      reason,
+	}
+	if userId != uuid.Nil {
+		movement.UserId = &userId
+	}
+	err = s.repo.Adjust(ctx, movement)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrOutOfStock):
+			return nil, ErrOutOfStock
+		default:
+			return nil, err
+		}
+	}
+	return s.repoProduct.GetById(ctx, productId)
+}
+
+func (s *stock) GetLevels(ctx context.Context, productId uuid.UUID) ([]*entity.WarehouseStock, error) {
+	err := s.checkProduct(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	return s.repoWarehouse.GetStock(ctx, &entity.WarehouseStockFilter{ProductIds: []uuid.UUID{productId}})
+}
+
+func (s *stock) GetMovements(ctx context.Context, productId uuid.UUID) ([]*entity.StockMovement, error) {
+	err := s.checkProduct(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetMovements(ctx, productId)
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+	mock_repo "github.com/krijebr/printer-shop/internal/repo/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStock_Adjust(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockStock, p *mock_repo.MockProduct, w *mock_repo.MockWarehouse, ctx context.Context)
+
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	productId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	warehouseId := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	stockAfter := 7
+	testTable := []struct {
+		name         string
+		delta        int
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			name:  "OK",
+			delta: 5,
+			mockBehavior: func(s *mock_repo.MockStock, p *mock_repo.MockProduct, w *mock_repo.MockWarehouse, ctx context.Context) {
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId}, nil)
+				w.EXPECT().GetById(ctx, warehouseId).Return(&entity.Warehouse{Id: warehouseId}, nil)
+				s.EXPECT().Adjust(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, movement entity.StockMovement) error {
+					assert.Equal(t, productId, movement.ProductId)
+					assert.Equal(t, warehouseId, *movement.WarehouseId)
+					assert.Equal(t, 5, movement.Delta)
+					assert.Equal(t, entity.StockMovementTypeAdjustment, movement.Type)
+					assert.Equal(t, "delivery", movement.Reason)
+					assert.Equal(t, userId, *movement.UserId)
+					return nil
+				})
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId, Stock: &stockAfter}, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:  "not enough stock",
+			delta: -5,
+			mockBehavior: func(s *mock_repo.MockStock, p *mock_repo.MockProduct, w *mock_repo.MockWarehouse, ctx context.Context) {
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId}, nil)
+				w.EXPECT().GetById(ctx, warehouseId).Return(&entity.Warehouse{Id: warehouseId}, nil)
+				s.EXPECT().Adjust(ctx, gomock.Any()).Return(repo.ErrOutOfStock)
+			},
+			expectedErr: ErrOutOfStock,
+		},
+		{
+			name:  "warehouse not found",
+			delta: 5,
+			mockBehavior: func(s *mock_repo.MockStock, p *mock_repo.MockProduct, w *mock_repo.MockWarehouse, ctx context.Context) {
+				p.EXPECT().GetById(ctx, productId).Return(&entity.Product{Id: productId}, nil)
+				w.EXPECT().GetById(ctx, warehouseId).Return(nil, repo.ErrWarehouseNotFound)
+			},
+			expectedErr: ErrWarehouseNotFound,
+		},
+		{
+			name:  "product not found",
+			delta: 5,
+			mockBehavior: func(s *mock_repo.MockStock, p *mock_repo.MockProduct, w *mock_repo.MockWarehouse, ctx context.Context) {
+				p.EXPECT().GetById(ctx, productId).Return(nil, repo.ErrProductNotFound)
+			},
+			expectedErr: ErrProductNotFound,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			stockRepo := mock_repo.NewMockStock(c)
+			productRepo := mock_repo.NewMockProduct(c)
+			warehouseRepo := mock_repo.NewMockWarehouse(c)
+			testCase.mockBehavior(stockRepo, productRepo, warehouseRepo, ctx)
+
+			stockUsecase := NewStock(stockRepo, productRepo, warehouseRepo)
+
+			product, err := stockUsecase.Adjust(ctx, userId, productId, warehouseId, testCase.delta, "delivery")
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				assert.Nil(t, product)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, stockAfter, *product.Stock)
+			}
+		})
+	}
+}
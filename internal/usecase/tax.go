@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"github.com/krijebr/printer-shop/internal/entity"
+)
+
+// applyTax sets the VAT rates and amounts of the lines and returns their sum. The tax is
+// calculated on what is left to pay for a line after its discount, so it must be applied
+// after promotions and promo codes. Lines of hidden products aren't taxed.
+func applyTax(settings entity.TaxSettings, lines []*entity.ProductInCart, currency entity.Currency) entity.Money {
+	total := entity.NewMoney(0, currency)
+	for _, line := range lines {
+		line.TaxRate = nil
+		line.Tax = nil
+		if line.Product.Status == entity.ProductStatusHidden {
+			continue
+		}
+		rate := settings.Rate(line.Product.TaxClass)
+		tax := settings.Tax(cartLineNet(line), rate)
+		line.TaxRate = &rate
+		line.Tax = &tax
+		total = total.Add(tax)
+	}
+	return total
+}
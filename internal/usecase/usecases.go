@@ -1,21 +1,43 @@
 package usecase
 
 type UseCases struct {
-	Auth     Auth
-	Cart     Cart
-	Order    Order
-	Producer Producer
-	Product  Product
-	User     User
+	Address      Address
+	Auth         Auth
+	Cart         Cart
+	Category     Category
+	ExchangeRate ExchangeRate
+	GuestCart    GuestCart
+	Order        Order
+	Producer     Producer
+	Product      Product
+	PromoCode    PromoCode
+	Promotion    Promotion
+	Shipment     Shipment
+	Shipping     Shipping
+	Stock        Stock
+	User         User
+	Warehouse    Warehouse
+	Wishlist     Wishlist
 }
 
-func NewUseCases(a Auth, c Cart, o Order, p Producer, pr Product, u User) *UseCases {
+func NewUseCases(ad Address, a Auth, c Cart, cat Category, e ExchangeRate, g GuestCart, o Order, p Producer, pr Product, pc PromoCode, pm Promotion, sm Shipment, sh Shipping, s Stock, u User, w Warehouse, wl Wishlist) *UseCases {
 	return &UseCases{
-		Auth:     a,
-		Cart:     c,
-		Order:    o,
-		Producer: p,
-		Product:  pr,
-		User:     u,
+		Address:      ad,
+		Auth:         a,
+		Cart:         c,
+		Category:     cat,
+		ExchangeRate: e,
+		GuestCart:    g,
+		Order:        o,
+		Producer:     p,
+		Product:      pr,
+		PromoCode:    pc,
+		Promotion:    pm,
+		Shipment:     sm,
+		Shipping:     sh,
+		Stock:        s,
+		User:         u,
+		Warehouse:    w,
+		Wishlist:     wl,
 	}
 }
@@ -25,8 +25,17 @@ func NewUser(r repo.User, c repo.Cart, o repo.Order, authUseCase Auth) User {
 	}
 }
 
-func (u *user) GetAll(ctx context.Context, filter *entity.UserFilter) ([]*entity.User, error) {
-	return u.repo.GetAll(ctx, filter)
+func (u *user) GetAll(ctx context.Context, filter *entity.UserFilter, pagination entity.Pagination) (*entity.UserPage, error) {
+	page, err := u.repo.GetPage(ctx, filter, pagination)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrInvalidCursor):
+			return nil, ErrInvalidCursor
+		default:
+			return nil, err
+		}
+	}
+	return page, nil
 }
 
 func (u *user) GetById(ctx context.Context, id uuid.UUID) (*entity.User, error) {
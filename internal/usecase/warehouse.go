@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+)
+
+type warehouse struct {
+	repo repo.Warehouse
+}
+
+func NewWarehouse(r repo.Warehouse) Warehouse {
+	return &warehouse{
+		repo: r,
+	}
+}
+
+func (w *warehouse) GetAll(ctx context.Context) ([]*entity.Warehouse, error) {
+	return w.repo.GetAll(ctx)
+}
+
+func (w *warehouse) GetById(ctx context.Context, id uuid.UUID) (*entity.Warehouse, error) {
+	receivedWarehouse, err := w.repo.GetById(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrWarehouseNotFound):
+			return nil, ErrWarehouseNotFound
+		default:
+			return nil, err
+		}
+	}
+	return receivedWarehouse, nil
+}
+
+func (w *warehouse) Create(ctx context.Context, warehouseToCreate entity.Warehouse) (*entity.Warehouse, error) {
+	warehouseToCreate.Id = uuid.New()
+	warehouseToCreate.CreatedAt = time.Now()
+	err := w.repo.Create(ctx, warehouseToCreate)
+	if err != nil {
+		return nil, err
+	}
+	newWarehouse, err := w.repo.GetById(ctx, warehouseToCreate.Id)
+	if err != nil {
+		return nil, err
+	}
+	return newWarehouse, nil
+}
+
+func (w *warehouse) Update(ctx context.Context, warehouseToUpdate entity.Warehouse) (*entity.Warehouse, error) {
+	_, err := w.GetById(ctx, warehouseToUpdate.Id)
+	if err != nil {
+		return nil, err
+	}
+	err = w.repo.Update(ctx, warehouseToUpdate)
+	if err != nil {
+		return nil, err
+	}
+	updatedWarehouse, err := w.repo.GetById(ctx, warehouseToUpdate.Id)
+	if err != nil {
+		return nil, err
+	}
+	return updatedWarehouse, nil
+}
+
+func (w *warehouse) DeleteById(ctx context.Context, id uuid.UUID) error {
+	_, err := w.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	used, err := w.repo.CheckIfUsedById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if used {
+		return ErrWarehouseIsUsed
+	}
+	return w.repo.DeleteById(ctx, id)
+}
+
+func (w *warehouse) GetStock(ctx context.Context, id uuid.UUID) ([]*entity.WarehouseStock, error) {
+	_, err := w.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return w.repo.GetStock(ctx, &entity.WarehouseStockFilter{WarehouseId: &id})
+}
@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+)
+
+type wishlist struct {
+	repo        repo.Wishlist
+	repoProduct repo.Product
+	cart        Cart
+}
+
+func NewWishlist(r repo.Wishlist, p repo.Product, c Cart) Wishlist {
+	return &wishlist{
+		repo:        r,
+		repoProduct: p,
+		cart:        c,
+	}
+}
+
+// loadProducts fills the products of the wishlist in the order they were added.
+func (w *wishlist) loadProducts(ctx context.Context, wishlistToLoad *entity.Wishlist, publishedOnly bool) error {
+	productIds, err := w.repo.GetProductIds(ctx, wishlistToLoad.Id)
+	if err != nil {
+		return err
+	}
+	wishlistToLoad.Products = []*entity.Product{}
+	if len(productIds) == 0 {
+		return nil
+	}
+	filter := &entity.ProductFilter{Ids: productIds}
+	if publishedOnly {
+		status := entity.ProductStatusPublished
+		filter.Status = &status
+	}
+	products, err := w.repoProduct.GetAll(ctx, filter)
+	if err != nil {
+		return err
+	}
+	for _, id := range productIds {
+		i := slices.IndexFunc(products, func(p *entity.Product) bool { return p.Id == id })
+		if i >= 0 {
+			wishlistToLoad.Products = append(wishlistToLoad.Products, products[i])
+		}
+	}
+	return nil
+}
+
+// getOwned returns the wishlist of the user, wishlists of other users are not found.
+func (w *wishlist) getOwned(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*entity.Wishlist, error) {
+	receivedWishlist, err := w.repo.GetById(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrWishlistNotFound):
+			return nil, ErrWishlistNotFound
+		default:
+			return nil, err
+		}
+	}
+	if receivedWishlist.UserId != userId {
+		return nil, ErrWishlistNotFound
+	}
+	return receivedWishlist, nil
+}
+
+func (w *wishlist) GetAll(ctx context.Context, userId uuid.UUID) ([]*entity.Wishlist, error) {
+	wishlists, err := w.repo.GetAllByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	for _, receivedWishlist := range wishlists {
+		err = w.loadProducts(ctx, receivedWishlist, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return wishlists, nil
+}
+
+func (w *wishlist) GetById(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*entity.Wishlist, error) {
+	receivedWishlist, err := w.getOwned(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	err = w.loadProducts(ctx, receivedWishlist, false)
+	if err != nil {
+		return nil, err
+	}
+	return receivedWishlist, nil
+}
+
+// GetShared returns the wishlist shared by the token with its published products only.
+func (w *wishlist) GetShared(ctx context.Context, token uuid.UUID) (*entity.Wishlist, error) {
+	receivedWishlist, err := w.repo.GetByShareToken(ctx, token)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrWishlistNotFound):
+			return nil, ErrWishlistNotFound
+		default:
+			return nil, err
+		}
+	}
+	err = w.loadProducts(ctx, receivedWishlist, true)
+	if err != nil {
+		return nil, err
+	}
+	return receivedWishlist, nil
+}
+
+func (w *wishlist) Create(ctx context.Context, wishlistToCreate entity.Wishlist) (*entity.Wishlist, error) {
+	exists, err := w.repo.CheckIfNameExists(ctx, wishlistToCreate.UserId, wishlistToCreate.Name, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrWishlistNameExists
+	}
+	wishlistToCreate.Id = uuid.New()
+	wishlistToCreate.CreatedAt = time.Now()
+	err = w.repo.Create(ctx, wishlistToCreate)
+	if err != nil {
+		return nil, err
+	}
+	return w.GetById(ctx, wishlistToCreate.UserId, wishlistToCreate.Id)
+}
+
+func (w *wishlist) Update(ctx context.Context, wishlistToUpdate entity.Wishlist) (*entity.Wishlist, error) {
+	_, err := w.getOwned(ctx, wishlistToUpdate.UserId, wishlistToUpdate.Id)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := w.repo.CheckIfNameExists(ctx, wishlistToUpdate.UserId, wishlistToUpdate.Name, wishlistToUpdate.Id)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrWishlistNameExists
+	}
+	err = w.repo.Update(ctx, wishlistToUpdate)
+	if err != nil {
+		return nil, err
+	}
+	return w.GetById(ctx, wishlistToUpdate.UserId, wishlistToUpdate.Id)
+}
+
+func (w *wishlist) DeleteById(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	_, err := w.getOwned(ctx, userId, id)
+	if err != nil {
+		return err
+	}
+	return w.repo.DeleteById(ctx, id)
+}
+
+func (w *wishlist) AddProduct(ctx context.Context, userId uuid.UUID, id uuid.UUID, productId uuid.UUID) error {
+	_, err := w.getOwned(ctx, userId, id)
+	if err != nil {
+		return err
+	}
+	product, err := w.repoProduct.GetById(ctx, productId)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrProductNotFound):
+			return ErrProductNotFound
+		default:
+			return err
+		}
+	}
+	if product.Status == entity.ProductStatusHidden {
+		return ErrProductIsHidden
+	}
+	return w.repo.AddProduct(ctx, id, productId)
+}
+
+func (w *wishlist) DeleteProduct(ctx context.Context, userId uuid.UUID, id uuid.UUID, productId uuid.UUID) error {
+	_, err := w.getOwned(ctx, userId, id)
+	if err != nil {
+		return err
+	}
+	deleted, err := w.repo.DeleteProduct(ctx, id, productId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrProductNotInWishlist
+	}
+	return nil
+}
+
+// MoveToCart adds count items of the product to the cart of the user and removes it from the wishlist.
+func (w *wishlist) MoveToCart(ctx context.Context, userId uuid.UUID, id uuid.UUID, productId uuid.UUID, count int) error {
+	_, err := w.getOwned(ctx, userId, id)
+	if err != nil {
+		return err
+	}
+	productIds, err := w.repo.GetProductIds(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(productIds, productId) {
+		return ErrProductNotInWishlist
+	}
+	err = w.cart.AddProduct(ctx, userId, productId, count)
+	if err != nil {
+		return err
+	}
+	_, err = w.repo.DeleteProduct(ctx, id, productId)
+	return err
+}
+
+// MoveFromCart removes the product from the cart of the user and saves it in the wishlist.
+func (w *wishlist) MoveFromCart(ctx context.Context, userId uuid.UUID, id uuid.UUID, productId uuid.UUID) error {
+	_, err := w.getOwned(ctx, userId, id)
+	if err != nil {
+		return err
+	}
+	err = w.cart.DeleteProduct(ctx, userId, productId)
+	if err != nil {
+		return err
+	}
+	return w.repo.AddProduct(ctx, id, productId)
+}
+
+// Share makes the wishlist viewable by its share token, an already shared wishlist keeps its token.
+func (w *wishlist) Share(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*entity.Wishlist, error) {
+	sharedWishlist, err := w.getOwned(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	if sharedWishlist.ShareToken == nil {
+		token := uuid.New()
+		err = w.repo.SetShareToken(ctx, id, &token)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return w.GetById(ctx, userId, id)
+}
+
+func (w *wishlist) Unshare(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	_, err := w.getOwned(ctx, userId, id)
+	if err != nil {
+		return err
+	}
+	return w.repo.SetShareToken(ctx, id, nil)
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/krijebr/printer-shop/internal/entity"
+	"github.com/krijebr/printer-shop/internal/repo"
+	mock_repo "github.com/krijebr/printer-shop/internal/repo/mocks"
+	mock_usecase "github.com/krijebr/printer-shop/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWishlist_MoveToCart(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockWishlist, cart *mock_usecase.MockCart, ctx context.Context)
+
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	wishlistId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	productId := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	testTable := []struct {
+		name         string
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(s *mock_repo.MockWishlist, cart *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().GetById(ctx, wishlistId).Return(&entity.Wishlist{Id: wishlistId, UserId: userId}, nil)
+				s.EXPECT().GetProductIds(ctx, wishlistId).Return([]uuid.UUID{productId}, nil)
+				cart.EXPECT().AddProduct(ctx, userId, productId, 2).Return(nil)
+				s.EXPECT().DeleteProduct(ctx, wishlistId, productId).Return(true, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "wishlist of another user",
+			mockBehavior: func(s *mock_repo.MockWishlist, cart *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().GetById(ctx, wishlistId).Return(&entity.Wishlist{Id: wishlistId, UserId: uuid.New()}, nil)
+			},
+			expectedErr: ErrWishlistNotFound,
+		},
+		{
+			name: "product is not in wishlist",
+			mockBehavior: func(s *mock_repo.MockWishlist, cart *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().GetById(ctx, wishlistId).Return(&entity.Wishlist{Id: wishlistId, UserId: userId}, nil)
+				s.EXPECT().GetProductIds(ctx, wishlistId).Return([]uuid.UUID{}, nil)
+			},
+			expectedErr: ErrProductNotInWishlist,
+		},
+		{
+			name: "not enough stock keeps product in wishlist",
+			mockBehavior: func(s *mock_repo.MockWishlist, cart *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().GetById(ctx, wishlistId).Return(&entity.Wishlist{Id: wishlistId, UserId: userId}, nil)
+				s.EXPECT().GetProductIds(ctx, wishlistId).Return([]uuid.UUID{productId}, nil)
+				cart.EXPECT().AddProduct(ctx, userId, productId, 2).Return(ErrOutOfStock)
+			},
+			expectedErr: ErrOutOfStock,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			wishlistRepo := mock_repo.NewMockWishlist(c)
+			cart := mock_usecase.NewMockCart(c)
+			testCase.mockBehavior(wishlistRepo, cart, ctx)
+
+			wishlistUsecase := NewWishlist(wishlistRepo, mock_repo.NewMockProduct(c), cart)
+
+			err := wishlistUsecase.MoveToCart(ctx, userId, wishlistId, productId, 2)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWishlist_MoveFromCart(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockWishlist, cart *mock_usecase.MockCart, ctx context.Context)
+
+	userId := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	wishlistId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	productId := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	testTable := []struct {
+		name         string
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func(s *mock_repo.MockWishlist, cart *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().GetById(ctx, wishlistId).Return(&entity.Wishlist{Id: wishlistId, UserId: userId}, nil)
+				cart.EXPECT().DeleteProduct(ctx, userId, productId).Return(nil)
+				s.EXPECT().AddProduct(ctx, wishlistId, productId).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "product is not in cart",
+			mockBehavior: func(s *mock_repo.MockWishlist, cart *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().GetById(ctx, wishlistId).Return(&entity.Wishlist{Id: wishlistId, UserId: userId}, nil)
+				cart.EXPECT().DeleteProduct(ctx, userId, productId).Return(ErrProductNotInCart)
+			},
+			expectedErr: ErrProductNotInCart,
+		},
+		{
+			name: "wishlist not found",
+			mockBehavior: func(s *mock_repo.MockWishlist, cart *mock_usecase.MockCart, ctx context.Context) {
+				s.EXPECT().GetById(ctx, wishlistId).Return(nil, repo.ErrWishlistNotFound)
+			},
+			expectedErr: ErrWishlistNotFound,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			wishlistRepo := mock_repo.NewMockWishlist(c)
+			cart := mock_usecase.NewMockCart(c)
+			testCase.mockBehavior(wishlistRepo, cart, ctx)
+
+			wishlistUsecase := NewWishlist(wishlistRepo, mock_repo.NewMockProduct(c), cart)
+
+			err := wishlistUsecase.MoveFromCart(ctx, userId, wishlistId, productId)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWishlist_GetShared(t *testing.T) {
+	type mockBehavior func(s *mock_repo.MockWishlist, p *mock_repo.MockProduct, ctx context.Context)
+
+	token := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	wishlistId := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	firstProductId := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	secondProductId := uuid.MustParse("00000000-0000-0000-0000-000000000004")
+	published := entity.ProductStatusPublished
+	testTable := []struct {
+		name               string
+		mockBehavior       mockBehavior
+		expectedProductIds []uuid.UUID
+		expectedErr        error
+	}{
+		{
+			name: "OK products in the order they were added",
+			mockBehavior: func(s *mock_repo.MockWishlist, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetByShareToken(ctx, token).Return(&entity.Wishlist{Id: wishlistId, ShareToken: &token}, nil)
+				s.EXPECT().GetProductIds(ctx, wishlistId).Return([]uuid.UUID{firstProductId, secondProductId}, nil)
+				p.EXPECT().GetAll(ctx, &entity.ProductFilter{Ids: []uuid.UUID{firstProductId, secondProductId}, Status: &published}).
+					Return([]*entity.Product{{Id: secondProductId}, {Id: firstProductId}}, nil)
+			},
+			expectedProductIds: []uuid.UUID{firstProductId, secondProductId},
+			expectedErr:        nil,
+		},
+		{
+			name: "not shared",
+			mockBehavior: func(s *mock_repo.MockWishlist, p *mock_repo.MockProduct, ctx context.Context) {
+				s.EXPECT().GetByShareToken(ctx, token).Return(nil, repo.ErrWishlistNotFound)
+			},
+			expectedErr: ErrWishlistNotFound,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			wishlistRepo := mock_repo.NewMockWishlist(c)
+			productRepo := mock_repo.NewMockProduct(c)
+			testCase.mockBehavior(wishlistRepo, productRepo, ctx)
+
+			wishlistUsecase := NewWishlist(wishlistRepo, productRepo, mock_usecase.NewMockCart(c))
+
+			wishlist, err := wishlistUsecase.GetShared(ctx, token)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				productIds := []uuid.UUID{}
+				for _, p := range wishlist.Products {
+					productIds = append(productIds, p.Id)
+				}
+				assert.Equal(t, testCase.expectedProductIds, productIds)
+			}
+		})
+	}
+}
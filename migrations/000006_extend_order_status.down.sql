@@ -0,0 +1,9 @@
+UPDATE orders SET status = 'new' WHERE status IN ('paid', 'cancelled');
+UPDATE orders SET status = 'in_progress' WHERE status = 'shipped';
+UPDATE orders SET status = 'done' WHERE status IN ('delivered', 'refunded');
+ALTER TYPE order_status RENAME TO order_status_old;
+CREATE TYPE order_status 
+AS 
+ENUM('new', 'in_progress', 'done');
+ALTER TABLE orders ALTER COLUMN status TYPE order_status USING status::text::order_status;
+DROP TYPE order_status_old;
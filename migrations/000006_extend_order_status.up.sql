@@ -0,0 +1,5 @@
+ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'paid' AFTER 'new';
+ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'shipped' AFTER 'in_progress';
+ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'delivered' AFTER 'shipped';
+ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'cancelled';
+ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'refunded';
@@ -0,0 +1,45 @@
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_type WHERE typname = 'order_event_type'
+	) THEN
+		CREATE TYPE order_event_type 
+		AS 
+		ENUM('created', 'updated', 'status_changed');
+	END IF;
+END;
+$$;
+CREATE TABLE IF NOT EXISTS "order_events" (
+	id uuid NOT NULL,
+	order_id uuid NOT NULL,
+	user_id uuid NULL,
+	type order_event_type NOT NULL,
+	from_status order_status NULL,
+	to_status order_status NULL,
+	comment varchar NOT NULL DEFAULT '',
+	created_at timestamp NOT NULL,
+	CONSTRAINT order_events_pk PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS order_events_order_id_idx ON order_events (order_id, created_at);
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint WHERE conname = 'fk_order_events_orders'
+	) THEN
+		EXECUTE 'ALTER TABLE order_events ADD CONSTRAINT fk_order_events_orders FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE ON UPDATE CASCADE';
+	END IF;
+END;
+$$;
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint WHERE conname = 'fk_order_events_users'
+	) THEN
+		EXECUTE 'ALTER TABLE order_events ADD CONSTRAINT fk_order_events_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL ON UPDATE CASCADE';
+	END IF;
+END;
+$$;
+INSERT INTO order_events (id, order_id, user_id, type, from_status, to_status, created_at)
+SELECT gen_random_uuid(), orders.id, orders.user_id, 'created', NULL, orders.status, orders.created_at
+FROM orders
+WHERE NOT EXISTS (SELECT 1 FROM order_events WHERE order_events.order_id = orders.id);
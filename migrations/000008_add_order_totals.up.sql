@@ -0,0 +1,13 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS subtotal numeric(12,2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount numeric(12,2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax numeric(12,2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS total numeric(12,2) NOT NULL DEFAULT 0;
+UPDATE orders SET 
+	subtotal = totals.subtotal,
+	total = totals.subtotal
+FROM (
+	SELECT order_id, sum(product_price::numeric(12,2) * product_count) AS subtotal
+	FROM order_products
+	GROUP BY order_id
+) AS totals
+WHERE totals.order_id = orders.id;
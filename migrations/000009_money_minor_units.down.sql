@@ -0,0 +1,8 @@
+ALTER TABLE orders ALTER COLUMN total TYPE numeric(12,2) USING total::numeric / 100;
+ALTER TABLE orders ALTER COLUMN tax TYPE numeric(12,2) USING tax::numeric / 100;
+ALTER TABLE orders ALTER COLUMN discount TYPE numeric(12,2) USING discount::numeric / 100;
+ALTER TABLE orders ALTER COLUMN subtotal TYPE numeric(12,2) USING subtotal::numeric / 100;
+ALTER TABLE orders DROP COLUMN IF EXISTS currency;
+ALTER TABLE order_products ALTER COLUMN product_price TYPE float4 USING product_price::numeric / 100;
+ALTER TABLE products DROP COLUMN IF EXISTS currency;
+ALTER TABLE products ALTER COLUMN price TYPE float4 USING price::numeric / 100;
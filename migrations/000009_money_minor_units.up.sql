@@ -0,0 +1,8 @@
+ALTER TABLE products ALTER COLUMN price TYPE bigint USING round(price::float8::numeric * 100)::bigint;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL DEFAULT 'RUB';
+ALTER TABLE order_products ALTER COLUMN product_price TYPE bigint USING round(product_price::float8::numeric * 100)::bigint;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL DEFAULT 'RUB';
+ALTER TABLE orders ALTER COLUMN subtotal TYPE bigint USING round(subtotal * 100)::bigint;
+ALTER TABLE orders ALTER COLUMN discount TYPE bigint USING round(discount * 100)::bigint;
+ALTER TABLE orders ALTER COLUMN tax TYPE bigint USING round(tax * 100)::bigint;
+ALTER TABLE orders ALTER COLUMN total TYPE bigint USING round(total * 100)::bigint;
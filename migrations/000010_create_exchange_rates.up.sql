@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS exchange_rates
+(
+    currency varchar(3) NOT NULL PRIMARY KEY,
+    rate numeric(18,6) NOT NULL CHECK (rate > 0),
+    updated_at timestamp NOT NULL
+);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS exchange_rate numeric(18,6) NOT NULL DEFAULT 1;
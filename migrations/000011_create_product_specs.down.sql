@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS product_specs;
+DROP TYPE IF EXISTS paper_format;
+DROP TYPE IF EXISTS print_technology;
@@ -0,0 +1,41 @@
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_type WHERE typname = 'print_technology'
+	) THEN
+		CREATE TYPE "print_technology"
+		AS
+		ENUM('laser', 'inkjet', 'thermal');
+	END IF;
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_type WHERE typname = 'paper_format'
+	) THEN
+		CREATE TYPE "paper_format"
+		AS
+		ENUM('A4', 'A3');
+	END IF;
+END;
+$$;
+CREATE TABLE IF NOT EXISTS "product_specs" (
+	product_id uuid NOT NULL,
+	print_technology print_technology NOT NULL,
+	color boolean NOT NULL DEFAULT false,
+	max_format paper_format NOT NULL,
+	dpi integer NOT NULL,
+	pages_per_minute integer NOT NULL,
+	duplex boolean NOT NULL DEFAULT false,
+	wifi boolean NOT NULL DEFAULT false,
+	ethernet boolean NOT NULL DEFAULT false,
+	scan boolean NOT NULL DEFAULT false,
+	copy boolean NOT NULL DEFAULT false,
+	CONSTRAINT product_specs_pk PRIMARY KEY (product_id)
+);
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint WHERE conname = 'fk_product_specs_products'
+	) THEN
+		EXECUTE 'ALTER TABLE product_specs ADD CONSTRAINT fk_product_specs_products FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE ON UPDATE CASCADE';
+	END IF;
+END;
+$$;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS "categories" (
+	id uuid NOT NULL,
+	parent_id uuid NULL,
+	name varchar NOT NULL,
+	created_at timestamp NOT NULL,
+	CONSTRAINT categories_pk PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS categories_parent_id_idx ON categories (parent_id);
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint WHERE conname = 'fk_categories_categories'
+	) THEN
+		EXECUTE 'ALTER TABLE categories ADD CONSTRAINT fk_categories_categories FOREIGN KEY (parent_id) REFERENCES categories(id) ON DELETE RESTRICT ON UPDATE CASCADE';
+	END IF;
+END;
+$$;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS category_id uuid NULL;
+CREATE INDEX IF NOT EXISTS products_category_id_idx ON products (category_id);
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint WHERE conname = 'fk_products_categories'
+	) THEN
+		EXECUTE 'ALTER TABLE products ADD CONSTRAINT fk_products_categories FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE RESTRICT ON UPDATE CASCADE';
+	END IF;
+END;
+$$;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS "product_compatibility" (
+	product_id uuid NOT NULL,
+	compatible_id uuid NOT NULL,
+	created_at timestamp NOT NULL,
+	CONSTRAINT product_compatibility_pk PRIMARY KEY (product_id, compatible_id),
+	CONSTRAINT product_compatibility_ordered CHECK (product_id < compatible_id)
+);
+CREATE INDEX IF NOT EXISTS product_compatibility_compatible_id_idx ON product_compatibility (compatible_id);
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint WHERE conname = 'fk_product_compatibility_product'
+	) THEN
+		EXECUTE 'ALTER TABLE product_compatibility ADD CONSTRAINT fk_product_compatibility_product FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE ON UPDATE CASCADE';
+	END IF;
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint WHERE conname = 'fk_product_compatibility_compatible'
+	) THEN
+		EXECUTE 'ALTER TABLE product_compatibility ADD CONSTRAINT fk_product_compatibility_compatible FOREIGN KEY (compatible_id) REFERENCES products(id) ON DELETE CASCADE ON UPDATE CASCADE';
+	END IF;
+END;
+$$;
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS producers_search_vector_update ON producers;
+DROP TRIGGER IF EXISTS product_specs_search_vector_update ON product_specs;
+DROP TRIGGER IF EXISTS products_search_vector_update ON products;
+DROP FUNCTION IF EXISTS producers_search_vector_trigger();
+DROP FUNCTION IF EXISTS product_specs_search_vector_trigger();
+DROP FUNCTION IF EXISTS products_search_vector_trigger();
+DROP FUNCTION IF EXISTS product_search_vector(uuid, varchar, uuid);
+DROP INDEX IF EXISTS products_search_vector_idx;
+ALTER TABLE products DROP COLUMN IF EXISTS search_vector;
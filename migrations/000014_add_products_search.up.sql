@@ -0,0 +1,81 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+CREATE OR REPLACE FUNCTION product_search_vector(p_id uuid, p_name varchar, p_producer_id uuid) RETURNS tsvector AS $$
+DECLARE
+	producer_name text;
+	producer_description text;
+	specs_text text;
+BEGIN
+	SELECT name, description INTO producer_name, producer_description FROM producers WHERE id = p_producer_id;
+	SELECT concat_ws(' ',
+		CASE print_technology
+			WHEN 'laser' THEN 'laser лазерный'
+			WHEN 'inkjet' THEN 'inkjet струйный'
+			WHEN 'thermal' THEN 'thermal термопринтер'
+		END,
+		max_format::text,
+		CASE WHEN color THEN 'color цветной' ELSE 'mono монохромный' END,
+		CASE WHEN duplex THEN 'duplex дуплекс' END,
+		CASE WHEN wifi THEN 'wifi wi-fi' END,
+		CASE WHEN ethernet THEN 'ethernet lan' END,
+		CASE WHEN scan THEN 'scan сканер мфу' END,
+		CASE WHEN copy THEN 'copy копир' END,
+		dpi || 'dpi',
+		pages_per_minute || 'ppm')
+	INTO specs_text FROM product_specs WHERE product_id = p_id;
+	RETURN
+		setweight(to_tsvector('simple', coalesce(p_name, '')), 'A') ||
+		setweight(to_tsvector('russian', coalesce(p_name, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(p_name, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(producer_name, '')), 'B') ||
+		setweight(to_tsvector('simple', coalesce(specs_text, '')), 'B') ||
+		setweight(to_tsvector('russian', coalesce(specs_text, '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(specs_text, '')), 'B') ||
+		setweight(to_tsvector('russian', coalesce(producer_description, '')), 'C') ||
+		setweight(to_tsvector('english', coalesce(producer_description, '')), 'C');
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+CREATE OR REPLACE FUNCTION products_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+	NEW.search_vector := product_search_vector(NEW.id, NEW.name, NEW.producer_id);
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION product_specs_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		UPDATE products SET search_vector = product_search_vector(id, name, producer_id) WHERE id = OLD.product_id;
+		RETURN OLD;
+	END IF;
+	UPDATE products SET search_vector = product_search_vector(id, name, producer_id) WHERE id = NEW.product_id;
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION producers_search_vector_trigger() RETURNS trigger AS $$
+BEGIN
+	UPDATE products SET search_vector = product_search_vector(id, name, producer_id) WHERE producer_id = NEW.id;
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS products_search_vector_update ON products;
+CREATE TRIGGER products_search_vector_update
+	BEFORE INSERT OR UPDATE OF name, producer_id ON products
+	FOR EACH ROW EXECUTE FUNCTION products_search_vector_trigger();
+
+DROP TRIGGER IF EXISTS product_specs_search_vector_update ON product_specs;
+CREATE TRIGGER product_specs_search_vector_update
+	AFTER INSERT OR UPDATE OR DELETE ON product_specs
+	FOR EACH ROW EXECUTE FUNCTION product_specs_search_vector_trigger();
+
+DROP TRIGGER IF EXISTS producers_search_vector_update ON producers;
+CREATE TRIGGER producers_search_vector_update
+	AFTER UPDATE OF name, description ON producers
+	FOR EACH ROW EXECUTE FUNCTION producers_search_vector_trigger();
+
+UPDATE products SET search_vector = product_search_vector(id, name, producer_id);
+
+CREATE INDEX IF NOT EXISTS products_search_vector_idx ON products USING gin (search_vector);
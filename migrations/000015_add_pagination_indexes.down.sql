@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS users_created_at_id_idx;
+DROP INDEX IF EXISTS orders_total_id_idx;
+DROP INDEX IF EXISTS orders_created_at_id_idx;
+DROP INDEX IF EXISTS products_name_id_idx;
+DROP INDEX IF EXISTS products_price_id_idx;
+DROP INDEX IF EXISTS products_created_at_id_idx;
@@ -0,0 +1,6 @@
+CREATE INDEX IF NOT EXISTS products_created_at_id_idx ON products (created_at, id);
+CREATE INDEX IF NOT EXISTS products_price_id_idx ON products (price, id);
+CREATE INDEX IF NOT EXISTS products_name_id_idx ON products (name, id);
+CREATE INDEX IF NOT EXISTS orders_created_at_id_idx ON orders (created_at, id);
+CREATE INDEX IF NOT EXISTS orders_total_id_idx ON orders (total, id);
+CREATE INDEX IF NOT EXISTS users_created_at_id_idx ON users (created_at, id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "stock_movements";
+DROP TYPE IF EXISTS "stock_movement_type";
+ALTER TABLE products DROP COLUMN IF EXISTS stock;
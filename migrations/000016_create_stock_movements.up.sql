@@ -0,0 +1,26 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS stock integer NULL CHECK (stock >= 0);
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM pg_type WHERE typname = 'stock_movement_type'
+	) THEN
+		CREATE TYPE stock_movement_type 
+		AS 
+		ENUM('adjustment', 'order_placed', 'order_changed', 'order_cancelled', 'order_deleted');
+	END IF;
+END;
+$$;
+CREATE TABLE IF NOT EXISTS "stock_movements" (
+	id uuid NOT NULL,
+	product_id uuid NOT NULL,
+	delta integer NOT NULL,
+	stock integer NOT NULL,
+	type stock_movement_type NOT NULL,
+	reason varchar NOT NULL DEFAULT '',
+	order_id uuid NULL,
+	user_id uuid NULL,
+	created_at timestamp NOT NULL,
+	CONSTRAINT stock_movements_pk PRIMARY KEY (id),
+	CONSTRAINT stock_movements_products_fk FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS stock_movements_product_id_idx ON stock_movements (product_id, created_at);
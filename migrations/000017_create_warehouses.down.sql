@@ -0,0 +1,5 @@
+ALTER TABLE stock_movements DROP CONSTRAINT IF EXISTS stock_movements_warehouses_fk;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS warehouse_id;
+DROP TABLE IF EXISTS "order_allocations";
+DROP TABLE IF EXISTS "warehouse_stock";
+DROP TABLE IF EXISTS "warehouses";
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS "warehouses" (
+	id uuid NOT NULL,
+	name varchar(100) NOT NULL,
+	city varchar(100) NOT NULL,
+	priority integer NOT NULL DEFAULT 0,
+	created_at timestamp NOT NULL,
+	CONSTRAINT warehouses_pk PRIMARY KEY (id),
+	CONSTRAINT warehouses_name_unique UNIQUE (name)
+);
+CREATE TABLE IF NOT EXISTS "warehouse_stock" (
+	warehouse_id uuid NOT NULL,
+	product_id uuid NOT NULL,
+	quantity integer NOT NULL CHECK (quantity >= 0),
+	CONSTRAINT warehouse_stock_pk PRIMARY KEY (warehouse_id, product_id),
+	CONSTRAINT warehouse_stock_warehouses_fk FOREIGN KEY (warehouse_id) REFERENCES warehouses(id) ON DELETE CASCADE,
+	CONSTRAINT warehouse_stock_products_fk FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS warehouse_stock_product_id_idx ON warehouse_stock (product_id);
+CREATE TABLE IF NOT EXISTS "order_allocations" (
+	order_id uuid NOT NULL,
+	product_id uuid NOT NULL,
+	warehouse_id uuid NOT NULL,
+	count integer NOT NULL CHECK (count > 0),
+	CONSTRAINT order_allocations_pk PRIMARY KEY (order_id, product_id, warehouse_id),
+	CONSTRAINT order_allocations_orders_fk FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+	CONSTRAINT order_allocations_products_fk FOREIGN KEY (product_id) REFERENCES products(id),
+	CONSTRAINT order_allocations_warehouses_fk FOREIGN KEY (warehouse_id) REFERENCES warehouses(id)
+);
+CREATE INDEX IF NOT EXISTS order_allocations_warehouse_id_idx ON order_allocations (warehouse_id);
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS warehouse_id uuid NULL;
+ALTER TABLE stock_movements DROP CONSTRAINT IF EXISTS stock_movements_warehouses_fk;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_warehouses_fk FOREIGN KEY (warehouse_id) REFERENCES warehouses(id) ON DELETE SET NULL;
+INSERT INTO warehouses (id, name, city, priority, created_at) VALUES
+	(gen_random_uuid(), 'Москва', 'Москва', 1, now()),
+	(gen_random_uuid(), 'Новосибирск', 'Новосибирск', 2, now())
+ON CONFLICT (name) DO NOTHING;
+INSERT INTO warehouse_stock (warehouse_id, product_id, quantity)
+SELECT warehouses.id, products.id, products.stock FROM products JOIN warehouses ON warehouses.name = 'Москва'
+WHERE products.stock IS NOT NULL
+ON CONFLICT DO NOTHING;
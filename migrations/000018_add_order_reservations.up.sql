@@ -0,0 +1,2 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS reserved_until timestamp NULL;
+CREATE INDEX IF NOT EXISTS orders_reserved_until_idx ON orders (reserved_until) WHERE reserved_until IS NOT NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE carts ADD COLUMN IF NOT EXISTS price bigint NULL;
+ALTER TABLE carts ADD COLUMN IF NOT EXISTS currency varchar(3) NULL;
+UPDATE carts SET price = products.price, currency = products.currency
+FROM products
+WHERE products.id = carts.product_id AND carts.price IS NULL;
+ALTER TABLE carts ALTER COLUMN price SET NOT NULL;
+ALTER TABLE carts ALTER COLUMN currency SET NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "wishlist_products";
+DROP TABLE IF EXISTS "wishlists";
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS "wishlists" (
+	id uuid NOT NULL,
+	user_id uuid NOT NULL,
+	name varchar(100) NOT NULL,
+	share_token uuid NULL,
+	created_at timestamp NOT NULL,
+	CONSTRAINT wishlists_pk PRIMARY KEY (id),
+	CONSTRAINT wishlists_user_name_unique UNIQUE (user_id, name),
+	CONSTRAINT wishlists_share_token_unique UNIQUE (share_token),
+	CONSTRAINT wishlists_users_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS "wishlist_products" (
+	wishlist_id uuid NOT NULL,
+	product_id uuid NOT NULL,
+	created_at timestamp NOT NULL,
+	CONSTRAINT wishlist_products_pk PRIMARY KEY (wishlist_id, product_id),
+	CONSTRAINT wishlist_products_wishlists_fk FOREIGN KEY (wishlist_id) REFERENCES wishlists(id) ON DELETE CASCADE,
+	CONSTRAINT wishlist_products_products_fk FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS wishlist_products_product_id_idx ON wishlist_products (product_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS orders_promo_code_id_idx;
+ALTER TABLE order_products DROP COLUMN IF EXISTS discount;
+ALTER TABLE orders DROP COLUMN IF EXISTS promo_code;
+ALTER TABLE orders DROP COLUMN IF EXISTS promo_code_id;
+DROP TABLE IF EXISTS "cart_promo_codes";
+DROP TABLE IF EXISTS "promo_code_scopes";
+DROP TABLE IF EXISTS "promo_codes";
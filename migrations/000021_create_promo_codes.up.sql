@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS "promo_codes" (
+	id uuid NOT NULL,
+	code varchar(32) NOT NULL,
+	description varchar(255) NOT NULL DEFAULT '',
+	discount_type varchar(20) NOT NULL,
+	discount_value bigint NOT NULL,
+	min_order_amount bigint NOT NULL DEFAULT 0,
+	currency varchar(3) NOT NULL,
+	starts_at timestamp NULL,
+	ends_at timestamp NULL,
+	usage_limit integer NULL,
+	usage_limit_per_user integer NULL,
+	created_at timestamp NOT NULL,
+	CONSTRAINT promo_codes_pk PRIMARY KEY (id),
+	CONSTRAINT promo_codes_code_unique UNIQUE (code),
+	CONSTRAINT promo_codes_discount_type_check CHECK (discount_type IN ('percentage', 'fixed'))
+);
+CREATE TABLE IF NOT EXISTS "promo_code_scopes" (
+	promo_code_id uuid NOT NULL,
+	scope_type varchar(20) NOT NULL,
+	scope_id uuid NOT NULL,
+	CONSTRAINT promo_code_scopes_pk PRIMARY KEY (promo_code_id, scope_type, scope_id),
+	CONSTRAINT promo_code_scopes_promo_codes_fk FOREIGN KEY (promo_code_id) REFERENCES promo_codes(id) ON DELETE CASCADE,
+	CONSTRAINT promo_code_scopes_scope_type_check CHECK (scope_type IN ('product', 'producer', 'category'))
+);
+CREATE TABLE IF NOT EXISTS "cart_promo_codes" (
+	user_id uuid NOT NULL,
+	promo_code_id uuid NOT NULL,
+	CONSTRAINT cart_promo_codes_pk PRIMARY KEY (user_id),
+	CONSTRAINT cart_promo_codes_users_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT cart_promo_codes_promo_codes_fk FOREIGN KEY (promo_code_id) REFERENCES promo_codes(id) ON DELETE CASCADE
+);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS promo_code_id uuid NULL REFERENCES promo_codes(id);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS promo_code varchar(32) NULL;
+ALTER TABLE order_products ADD COLUMN IF NOT EXISTS discount bigint NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS orders_promo_code_id_idx ON orders (promo_code_id);
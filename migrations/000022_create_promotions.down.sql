@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "order_line_promotions";
+DROP TABLE IF EXISTS "promotion_tiers";
+DROP TABLE IF EXISTS "promotion_products";
+DROP TABLE IF EXISTS "promotions";
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS "promotions" (
+	id uuid NOT NULL,
+	name varchar(100) NOT NULL,
+	type varchar(20) NOT NULL,
+	priority integer NOT NULL DEFAULT 0,
+	percent integer NOT NULL DEFAULT 0,
+	buy_count integer NOT NULL DEFAULT 0,
+	free_count integer NOT NULL DEFAULT 0,
+	currency varchar(3) NOT NULL,
+	active boolean NOT NULL DEFAULT true,
+	starts_at timestamp NULL,
+	ends_at timestamp NULL,
+	created_at timestamp NOT NULL,
+	CONSTRAINT promotions_pk PRIMARY KEY (id),
+	CONSTRAINT promotions_type_check CHECK (type IN ('bundle', 'buy_x_get_y', 'volume_tier'))
+);
+CREATE TABLE IF NOT EXISTS "promotion_products" (
+	promotion_id uuid NOT NULL,
+	product_id uuid NOT NULL,
+	count integer NOT NULL DEFAULT 1,
+	CONSTRAINT promotion_products_pk PRIMARY KEY (promotion_id, product_id),
+	CONSTRAINT promotion_products_promotions_fk FOREIGN KEY (promotion_id) REFERENCES promotions(id) ON DELETE CASCADE,
+	CONSTRAINT promotion_products_products_fk FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS "promotion_tiers" (
+	promotion_id uuid NOT NULL,
+	min_count integer NOT NULL,
+	price bigint NOT NULL,
+	CONSTRAINT promotion_tiers_pk PRIMARY KEY (promotion_id, min_count),
+	CONSTRAINT promotion_tiers_promotions_fk FOREIGN KEY (promotion_id) REFERENCES promotions(id) ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS "order_line_promotions" (
+	order_id uuid NOT NULL,
+	product_id uuid NOT NULL,
+	promotion_id uuid NULL,
+	name varchar(100) NOT NULL,
+	type varchar(20) NOT NULL,
+	discount bigint NOT NULL,
+	CONSTRAINT order_line_promotions_orders_fk FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+	CONSTRAINT order_line_promotions_promotions_fk FOREIGN KEY (promotion_id) REFERENCES promotions(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS order_line_promotions_order_id_idx ON order_line_promotions (order_id);
+CREATE INDEX IF NOT EXISTS promotion_products_product_id_idx ON promotion_products (product_id);
@@ -0,0 +1,4 @@
+ALTER TABLE order_products DROP COLUMN IF EXISTS tax;
+ALTER TABLE order_products DROP COLUMN IF EXISTS tax_rate;
+ALTER TABLE orders DROP COLUMN IF EXISTS prices_include_tax;
+ALTER TABLE products DROP COLUMN IF EXISTS tax_class;
@@ -0,0 +1,4 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS tax_class varchar(20) NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS prices_include_tax boolean NOT NULL DEFAULT false;
+ALTER TABLE order_products ADD COLUMN IF NOT EXISTS tax_rate numeric(5,2) NOT NULL DEFAULT 0;
+ALTER TABLE order_products ADD COLUMN IF NOT EXISTS tax bigint NOT NULL DEFAULT 0;
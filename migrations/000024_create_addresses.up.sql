@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS "addresses" (
+	id uuid NOT NULL,
+	user_id uuid NOT NULL,
+	label varchar(50) NOT NULL DEFAULT '',
+	recipient varchar(100) NOT NULL,
+	phone varchar(20) NOT NULL,
+	country varchar(60) NOT NULL,
+	region varchar(100) NOT NULL DEFAULT '',
+	city varchar(100) NOT NULL,
+	street varchar(200) NOT NULL,
+	postal_code varchar(20) NOT NULL,
+	is_default boolean NOT NULL DEFAULT false,
+	created_at timestamp NOT NULL,
+	CONSTRAINT addresses_pk PRIMARY KEY (id),
+	CONSTRAINT addresses_users_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS addresses_user_id_idx ON addresses (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS addresses_user_default_unique ON addresses (user_id) WHERE is_default;
+CREATE TABLE IF NOT EXISTS "order_addresses" (
+	order_id uuid NOT NULL,
+	type varchar(10) NOT NULL,
+	recipient varchar(100) NOT NULL,
+	phone varchar(20) NOT NULL,
+	country varchar(60) NOT NULL,
+	region varchar(100) NOT NULL DEFAULT '',
+	city varchar(100) NOT NULL,
+	street varchar(200) NOT NULL,
+	postal_code varchar(20) NOT NULL,
+	CONSTRAINT order_addresses_pk PRIMARY KEY (order_id, type),
+	CONSTRAINT order_addresses_orders_fk FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
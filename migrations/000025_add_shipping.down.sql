@@ -0,0 +1,6 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping;
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_method;
+ALTER TABLE products DROP COLUMN IF EXISTS height;
+ALTER TABLE products DROP COLUMN IF EXISTS width;
+ALTER TABLE products DROP COLUMN IF EXISTS length;
+ALTER TABLE products DROP COLUMN IF EXISTS weight;
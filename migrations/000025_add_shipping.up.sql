@@ -0,0 +1,6 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS weight integer NULL;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS length integer NULL;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS width integer NULL;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS height integer NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_method varchar(50) NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS "shipments" (
+	id uuid NOT NULL,
+	order_id uuid NOT NULL,
+	carrier varchar(50) NOT NULL,
+	tracking_number varchar(100) NOT NULL DEFAULT '',
+	shipped_at timestamp NOT NULL,
+	created_at timestamp NOT NULL,
+	CONSTRAINT shipments_pk PRIMARY KEY (id),
+	CONSTRAINT shipments_orders_fk FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS shipments_order_id_idx ON shipments (order_id);
+CREATE TABLE IF NOT EXISTS "shipment_lines" (
+	shipment_id uuid NOT NULL,
+	product_id uuid NOT NULL,
+	count integer NOT NULL,
+	CONSTRAINT shipment_lines_pk PRIMARY KEY (shipment_id, product_id),
+	CONSTRAINT shipment_lines_shipments_fk FOREIGN KEY (shipment_id) REFERENCES shipments(id) ON DELETE CASCADE,
+	CONSTRAINT shipment_lines_products_fk FOREIGN KEY (product_id) REFERENCES products(id),
+	CONSTRAINT shipment_lines_count_check CHECK (count > 0)
+);
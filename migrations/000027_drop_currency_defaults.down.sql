@@ -0,0 +1,2 @@
+ALTER TABLE orders ALTER COLUMN currency SET DEFAULT 'RUB';
+ALTER TABLE products ALTER COLUMN currency SET DEFAULT 'RUB';
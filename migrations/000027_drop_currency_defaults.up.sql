@@ -0,0 +1,2 @@
+ALTER TABLE products ALTER COLUMN currency DROP DEFAULT;
+ALTER TABLE orders ALTER COLUMN currency DROP DEFAULT;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS orders_total_id_idx;
+CREATE INDEX IF NOT EXISTS orders_base_total_id_idx ON orders ((total / exchange_rate), id);